@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/cache"
+	"github.com/thomaskoefod/githubProjectTUI/internal/logging"
+	"github.com/thomaskoefod/githubProjectTUI/internal/outbox"
+	"github.com/thomaskoefod/githubProjectTUI/internal/ui"
+)
+
+func main() {
+	noCache := flag.Bool("no-cache", false, "bypass the local cache and always load from GitHub")
+	clearCache := flag.Bool("clear-cache", false, "delete the local cache before starting")
+	logLevel := flag.String("log-level", "info", "minimum level to log: debug, info, warn or error")
+	record := flag.String("record", "", "save every GitHub request and response to this cassette file")
+	replay := flag.String("replay", "", "serve GitHub responses from this cassette file instead of the network")
+	flag.Parse()
+
+	if *record != "" && *replay != "" {
+		fmt.Fprintln(os.Stderr, "Error: --record and --replay can't be used together")
+		os.Exit(2)
+	}
+
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	// Everything logs through slog from here on; the terminal belongs to the TUI
+	logPath, err := logging.Setup(level)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, logging to memory only\n", err)
+	}
+
+	if *record != "" {
+		if err := api.StartRecording(*record); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *replay != "" {
+		if err := api.StartReplay(*replay); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	store, err := cache.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: cache unavailable: %v\n", err)
+		store = nil
+	}
+
+	if *clearCache {
+		if err := store.Clear(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to clear cache: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *noCache {
+		store = nil
+	}
+
+	// Without an outbox, edits made offline fail instead of being queued
+	box, err := outbox.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: offline queue unavailable: %v\n", err)
+		box = nil
+	}
+
+	// A replayed session must not show or change the user's real local state
+	if *replay != "" {
+		store = nil
+		box = nil
+	}
+
+	p := tea.NewProgram(ui.NewModel(ui.Options{Cache: store, Outbox: box, LogPath: logPath}), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/logging"
+)
+
+// cassetteVersion is bumped when the cassette format changes incompatibly
+const cassetteVersion = 1
+
+// recordedHeaders are the response headers kept in a cassette. They drive
+// rate limit tracking and error classification; everything else is dropped.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Retry-After",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Used",
+	"X-RateLimit-Reset",
+}
+
+// baseTransport is what every new Client sends requests through. It is
+// http.DefaultTransport unless a session is being recorded or replayed.
+var baseTransport http.RoundTripper = http.DefaultTransport
+
+// replaying is set when requests are served from a cassette instead of GitHub
+var replaying bool
+
+// Cassette is a recorded session of GraphQL requests and their responses
+type Cassette struct {
+	Version      int           `json:"version"`
+	RecordedAt   time.Time     `json:"recorded_at"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one request and the response GitHub gave to it. Request
+// headers are never stored, so the token doesn't end up in the file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request used to match it on replay
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
+}
+
+// RecordedResponse is a response as it will be served on replay
+type RecordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+}
+
+// StartRecording makes every Client created afterwards save its requests and
+// responses to the cassette at path, with credentials scrubbed
+func StartRecording(path string) error {
+	r := &recordingTransport{
+		base:     http.DefaultTransport,
+		path:     path,
+		cassette: Cassette{Version: cassetteVersion, RecordedAt: time.Now()},
+	}
+	// Fail now rather than after the session the user wanted to capture
+	if err := r.save(); err != nil {
+		return err
+	}
+	baseTransport = r
+	return nil
+}
+
+// StartReplay makes every Client created afterwards answer requests from the
+// cassette at path without touching the network
+func StartReplay(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return fmt.Errorf("cassette file is corrupted: %w", err)
+	}
+	if cassette.Version != cassetteVersion {
+		return fmt.Errorf("cassette has version %d, this build reads version %d", cassette.Version, cassetteVersion)
+	}
+
+	t := &replayTransport{responses: make(map[string][]RecordedResponse)}
+	for _, interaction := range cassette.Interactions {
+		key := replayKey(interaction.Request)
+		t.responses[key] = append(t.responses[key], interaction.Response)
+	}
+
+	baseTransport = t
+	replaying = true
+	slog.Info("replaying recorded session", "path", path, "interactions", len(cassette.Interactions))
+	return nil
+}
+
+// Replaying reports whether responses come from a cassette rather than GitHub
+func Replaying() bool {
+	return replaying
+}
+
+// recordingTransport passes requests to GitHub and appends each exchange to the cassette
+type recordingTransport struct {
+	base     http.RoundTripper
+	path     string
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		// Network failures have no response to replay
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	headers := make(map[string]string)
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   logging.Scrub(string(reqBody)),
+		},
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: headers,
+			Body:    logging.Scrub(string(respBody)),
+		},
+	})
+	if err := r.save(); err != nil {
+		// Losing the recording must not break the session being recorded
+		slog.Warn("failed to save cassette", "path", r.path, "err", err)
+	}
+
+	return resp, nil
+}
+
+// save writes the cassette to disk. Callers must hold r.mu.
+func (r *recordingTransport) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// replayTransport serves recorded responses. Identical requests get their
+// recorded responses in order, and the last one again once those run out, so
+// a session that refreshes more often than the recording still works.
+type replayTransport struct {
+	mu        sync.Mutex
+	responses map[string][]RecordedResponse
+	served    map[string]int
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := replayKey(RecordedRequest{Method: req.Method, URL: req.URL.String(), Body: logging.Scrub(string(body))})
+
+	t.mu.Lock()
+	responses := t.responses[key]
+	if t.served == nil {
+		t.served = make(map[string]int)
+	}
+	n := t.served[key]
+	t.served[key]++
+	t.mu.Unlock()
+
+	if len(responses) == 0 {
+		slog.Warn("no recorded response for request", "url", req.URL.String())
+		// A GraphQL error rather than a transport error, so the app reports it
+		// instead of treating the session as offline
+		return replayResponse(req, RecordedResponse{
+			Status:  http.StatusOK,
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    `{"errors":[{"type":"NOT_RECORDED","message":"This request is not in the recorded session"}]}`,
+		}), nil
+	}
+	if n >= len(responses) {
+		n = len(responses) - 1
+	}
+	return replayResponse(req, responses[n]), nil
+}
+
+// replayResponse builds an http.Response from a recorded one
+func replayResponse(req *http.Request, recorded RecordedResponse) *http.Response {
+	header := make(http.Header)
+	for name, value := range recorded.Headers {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+// replayKey identifies requests that should get the same recorded response
+func replayKey(req RecordedRequest) string {
+	return req.Method + " " + req.URL + "\n" + req.Body
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+"context"
 "fmt"
+"net/http"
 "time"
 
 "github.com/cli/go-gh/v2/pkg/api"
@@ -11,11 +13,40 @@ import (
 // Client wraps the GitHub API client for Projects V2
 type Client struct {
 client *api.GraphQLClient
+limiter *rateLimiter
+}
+
+// ClientOptions points a Client somewhere other than the GitHub host gh is
+// logged in to. Fields left empty fall back to the gh configuration.
+type ClientOptions struct {
+Host      string            // e.g. "github.example.com", or the host:port of a local server
+AuthToken string            // Skips the gh token lookup when set
+Transport http.RoundTripper // Replaces the default transport, e.g. an httptest server's
 }
 
 // NewClient creates a new API client
 func NewClient() (*Client, error) {
-opts := api.ClientOptions{}
+return NewClientWithOptions(ClientOptions{})
+}
+
+// NewClientWithOptions creates an API client with the given host, token and transport
+func NewClientWithOptions(options ClientOptions) (*Client, error) {
+base := options.Transport
+if base == nil {
+base = baseTransport
+}
+
+limiter := &rateLimiter{}
+opts := api.ClientOptions{
+Host:      options.Host,
+AuthToken: options.AuthToken,
+Transport: &rateLimitTransport{base: base, limiter: limiter},
+}
+if replaying && opts.Host == "" {
+// Nothing is sent, so there is no need to find the user's token
+opts.Host = "github.com"
+opts.AuthToken = "replay"
+}
 client, err := api.NewGraphQLClient(opts)
 if err != nil {
 return nil, fmt.Errorf("failed to create API client: %w", err)
@@ -23,11 +54,17 @@ return nil, fmt.Errorf("failed to create API client: %w", err)
 
 return &Client{
 client: client,
+limiter: limiter,
 }, nil
 }
 
+// RateLimit returns the most recently observed rate limit budget
+func (c *Client) RateLimit() RateLimit {
+return c.limiter.Status()
+}
+
 // GetViewer returns information about the authenticated user
-func (c *Client) GetViewer() (string, error) {
+func (c *Client) GetViewer(ctx context.Context) (string, error) {
 query := `query {
 viewer {
 login
@@ -40,7 +77,7 @@ Login string
 }
 }
 
-err := c.client.Do(query, nil, &response)
+err := c.query(ctx, query, nil, &response)
 if err != nil {
 return "", fmt.Errorf("failed to get viewer: %w", err)
 }
@@ -49,7 +86,7 @@ return response.Viewer.Login, nil
 }
 
 // ListUserProjects retrieves all projects for the authenticated user
-func (c *Client) ListUserProjects(login string, first int) ([]models.Project, error) {
+func (c *Client) ListUserProjects(ctx context.Context, login string, first int) ([]models.Project, error) {
 query := `query($login: String!, $first: Int!) {
 user(login: $login) {
 projectsV2(first: $first) {
@@ -60,6 +97,7 @@ title
 shortDescription
 public
 closed
+template
 url
 createdAt
 updatedAt
@@ -86,6 +124,7 @@ Title            string
 ShortDescription string
 Public           bool
 Closed           bool
+Template         bool
 URL              string
 CreatedAt        time.Time
 UpdatedAt        time.Time
@@ -97,7 +136,7 @@ TotalCount int
 }
 }
 
-err := c.client.Do(query, variables, &response)
+err := c.query(ctx, query, variables, &response)
 if err != nil {
 return nil, fmt.Errorf("failed to list user projects: %w", err)
 }
@@ -111,6 +150,7 @@ Title:            node.Title,
 ShortDescription: node.ShortDescription,
 Public:           node.Public,
 Closed:           node.Closed,
+Template:         node.Template,
 URL:              node.URL,
 CreatedAt:        node.CreatedAt,
 UpdatedAt:        node.UpdatedAt,
@@ -126,7 +166,7 @@ return projects, nil
 }
 
 // ListOrgProjects retrieves all projects for an organization
-func (c *Client) ListOrgProjects(org string, first int) ([]models.Project, error) {
+func (c *Client) ListOrgProjects(ctx context.Context, org string, first int) ([]models.Project, error) {
 query := `query($org: String!, $first: Int!) {
 organization(login: $org) {
 projectsV2(first: $first) {
@@ -137,6 +177,7 @@ title
 shortDescription
 public
 closed
+template
 url
 createdAt
 updatedAt
@@ -163,6 +204,7 @@ Title            string
 ShortDescription string
 Public           bool
 Closed           bool
+Template         bool
 URL              string
 CreatedAt        time.Time
 UpdatedAt        time.Time
@@ -174,7 +216,7 @@ TotalCount int
 }
 }
 
-err := c.client.Do(query, variables, &response)
+err := c.query(ctx, query, variables, &response)
 if err != nil {
 return nil, fmt.Errorf("failed to list org projects: %w", err)
 }
@@ -188,6 +230,7 @@ Title:            node.Title,
 ShortDescription: node.ShortDescription,
 Public:           node.Public,
 Closed:           node.Closed,
+Template:         node.Template,
 URL:              node.URL,
 CreatedAt:        node.CreatedAt,
 UpdatedAt:        node.UpdatedAt,
@@ -203,7 +246,7 @@ return projects, nil
 }
 
 // GetUserOrganizations retrieves the user's organizations
-func (c *Client) GetUserOrganizations(username string) ([]string, error) {
+func (c *Client) GetUserOrganizations(ctx context.Context, username string) ([]string, error) {
 query := `query($login: String!) {
 user(login: $login) {
 organizations(first: 100) {
@@ -228,7 +271,7 @@ Login string
 }
 }
 
-err := c.client.Do(query, variables, &response)
+err := c.query(ctx, query, variables, &response)
 if err != nil {
 return nil, fmt.Errorf("failed to get organizations: %w", err)
 }
@@ -242,7 +285,7 @@ return orgs, nil
 }
 
 // GetUserNodeID retrieves the node ID for a user
-func (c *Client) GetUserNodeID(username string) (string, error) {
+func (c *Client) GetUserNodeID(ctx context.Context, username string) (string, error) {
 query := `query($login: String!) {
 user(login: $login) {
 id
@@ -259,7 +302,7 @@ ID string
 }
 }
 
-err := c.client.Do(query, variables, &response)
+err := c.query(ctx, query, variables, &response)
 if err != nil {
 return "", fmt.Errorf("failed to get user node ID: %w", err)
 }
@@ -268,7 +311,7 @@ return response.User.ID, nil
 }
 
 // GetOrgNodeID retrieves the node ID for an organization
-func (c *Client) GetOrgNodeID(org string) (string, error) {
+func (c *Client) GetOrgNodeID(ctx context.Context, org string) (string, error) {
 query := `query($login: String!) {
 organization(login: $login) {
 id
@@ -285,7 +328,7 @@ ID string
 }
 }
 
-err := c.client.Do(query, variables, &response)
+err := c.query(ctx, query, variables, &response)
 if err != nil {
 return "", fmt.Errorf("failed to get org node ID: %w", err)
 }
@@ -294,7 +337,7 @@ return response.Organization.ID, nil
 }
 
 // CreateProject creates a new project
-func (c *Client) CreateProject(input models.CreateProjectInput) (*models.Project, error) {
+func (c *Client) CreateProject(ctx context.Context, input models.CreateProjectInput) (*models.Project, error) {
 mutation := `mutation($input: CreateProjectV2Input!) {
 createProjectV2(input: $input) {
 projectV2 {
@@ -336,7 +379,7 @@ CreatedAt        time.Time
 }
 }
 
-err := c.client.Do(mutation, variables, &response)
+err := c.mutate(ctx, mutation, variables, &response)
 if err != nil {
 return nil, fmt.Errorf("failed to create project: %w", err)
 }
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// Collaborator roles accepted by updateProjectV2Collaborators
+const (
+	RoleNone   = "NONE"
+	RoleReader = "READER"
+	RoleWriter = "WRITER"
+	RoleAdmin  = "ADMIN"
+)
+
+// ListProjectCollaborators retrieves the users and teams that have a role on a project
+func (c *Client) ListProjectCollaborators(ctx context.Context, projectID string, first int) ([]models.ProjectCollaborator, error) {
+	query := `query($id: ID!, $first: Int!) {
+		node(id: $id) {
+			... on ProjectV2 {
+				collaborators(first: $first) {
+					edges {
+						role
+						node {
+							__typename
+							... on User {
+								id
+								login
+								name
+							}
+							... on Team {
+								id
+								slug
+								name
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id":    projectID,
+		"first": first,
+	}
+
+	var response struct {
+		Node struct {
+			Collaborators struct {
+				Edges []struct {
+					Role string `json:"role"`
+					Node struct {
+						TypeName string `json:"__typename"`
+						ID       string `json:"id"`
+						Login    string `json:"login"`
+						Slug     string `json:"slug"`
+						Name     string `json:"name"`
+					} `json:"node"`
+				} `json:"edges"`
+			} `json:"collaborators"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project collaborators: %w", err)
+	}
+
+	collaborators := make([]models.ProjectCollaborator, 0, len(response.Node.Collaborators.Edges))
+	for _, edge := range response.Node.Collaborators.Edges {
+		login := edge.Node.Login
+		if edge.Node.TypeName == "Team" {
+			login = edge.Node.Slug
+		}
+		collaborators = append(collaborators, models.ProjectCollaborator{
+			ID:    edge.Node.ID,
+			Login: login,
+			Name:  edge.Node.Name,
+			Type:  edge.Node.TypeName,
+			Role:  edge.Role,
+		})
+	}
+
+	return collaborators, nil
+}
+
+// UpdateProjectCollaborators sets the role of each given user or team on a project.
+// A role of RoleNone removes the collaborator from the project.
+func (c *Client) UpdateProjectCollaborators(ctx context.Context, projectID string, collaborators []models.ProjectCollaborator) error {
+	mutation := `mutation($input: UpdateProjectV2CollaboratorsInput!) {
+		updateProjectV2Collaborators(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	entries := make([]map[string]interface{}, 0, len(collaborators))
+	for _, collaborator := range collaborators {
+		entry := map[string]interface{}{
+			"role": collaborator.Role,
+		}
+		if collaborator.Type == "Team" {
+			entry["teamId"] = collaborator.ID
+		} else {
+			entry["userId"] = collaborator.ID
+		}
+		entries = append(entries, entry)
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"projectId":     projectID,
+			"collaborators": entries,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to update project collaborators: %w", err)
+	}
+
+	return nil
+}
+
+// SearchOrgTeams searches for teams in an organization by name or slug
+func (c *Client) SearchOrgTeams(ctx context.Context, org string, query string, limit int) ([]models.Team, error) {
+	if query == "" {
+		return []models.Team{}, nil
+	}
+
+	gqlQuery := `query($org: String!, $query: String!, $first: Int!) {
+		organization(login: $org) {
+			teams(first: $first, query: $query) {
+				nodes {
+					id
+					slug
+					name
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"org":   org,
+		"query": query,
+		"first": limit,
+	}
+
+	var response struct {
+		Organization struct {
+			Teams struct {
+				Nodes []struct {
+					ID   string `json:"id"`
+					Slug string `json:"slug"`
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"teams"`
+		} `json:"organization"`
+	}
+
+	err := c.query(ctx, gqlQuery, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search teams: %w", err)
+	}
+
+	teams := make([]models.Team, 0, len(response.Organization.Teams.Nodes))
+	for _, node := range response.Organization.Teams.Nodes {
+		teams = append(teams, models.Team{
+			ID:   node.ID,
+			Slug: node.Slug,
+			Name: node.Name,
+		})
+	}
+
+	return teams, nil
+}
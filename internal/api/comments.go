@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// commentNode is the GraphQL shape of an IssueComment
+type commentNode struct {
+	ID     string `json:"id"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Body            string    `json:"body"`
+	CreatedAt       time.Time `json:"createdAt"`
+	ViewerCanUpdate bool      `json:"viewerCanUpdate"`
+	ViewerCanDelete bool      `json:"viewerCanDelete"`
+}
+
+func (n commentNode) toModel() models.Comment {
+	return models.Comment{
+		ID:        n.ID,
+		Author:    n.Author.Login,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+		CanUpdate: n.ViewerCanUpdate,
+		CanDelete: n.ViewerCanDelete,
+	}
+}
+
+// commentConnection is the GraphQL shape of an IssueCommentConnection
+type commentConnection struct {
+	TotalCount int `json:"totalCount"`
+	PageInfo   struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []commentNode `json:"nodes"`
+}
+
+func (c commentConnection) toModel() ([]models.Comment, models.PageInfo) {
+	comments := make([]models.Comment, len(c.Nodes))
+	for i, node := range c.Nodes {
+		comments[i] = node.toModel()
+	}
+	return comments, models.PageInfo{
+		HasNextPage: c.PageInfo.HasNextPage,
+		EndCursor:   c.PageInfo.EndCursor,
+	}
+}
+
+// ListComments retrieves a page of comments on an issue or pull request, oldest first
+func (c *Client) ListComments(ctx context.Context, contentID string, first int, after string) ([]models.Comment, models.PageInfo, error) {
+	query := `query($id: ID!, $first: Int!, $after: String) {
+		node(id: $id) {
+			... on Issue {
+				comments(first: $first, after: $after) {
+					totalCount
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						id
+						author {
+							login
+						}
+						body
+						createdAt
+						viewerCanUpdate
+						viewerCanDelete
+					}
+				}
+			}
+			... on PullRequest {
+				comments(first: $first, after: $after) {
+					totalCount
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						id
+						author {
+							login
+						}
+						body
+						createdAt
+						viewerCanUpdate
+						viewerCanDelete
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id":    contentID,
+		"first": first,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Node struct {
+			Comments commentConnection `json:"comments"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, models.PageInfo{}, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	comments, pageInfo := response.Node.Comments.toModel()
+	return comments, pageInfo, nil
+}
+
+// AddComment posts a new comment on an issue or pull request
+func (c *Client) AddComment(ctx context.Context, subjectID, body string) (*models.Comment, error) {
+	mutation := `mutation($input: AddCommentInput!) {
+		addComment(input: $input) {
+			commentEdge {
+				node {
+					id
+					author {
+						login
+					}
+					body
+					createdAt
+					viewerCanUpdate
+					viewerCanDelete
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"subjectId": subjectID,
+			"body":      body,
+		},
+	}
+
+	var response struct {
+		AddComment struct {
+			CommentEdge struct {
+				Node commentNode `json:"node"`
+			} `json:"commentEdge"`
+		} `json:"addComment"`
+	}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	comment := response.AddComment.CommentEdge.Node.toModel()
+	return &comment, nil
+}
+
+// UpdateComment replaces the body of an existing issue or pull request comment
+func (c *Client) UpdateComment(ctx context.Context, commentID, body string) (*models.Comment, error) {
+	mutation := `mutation($input: UpdateIssueCommentInput!) {
+		updateIssueComment(input: $input) {
+			issueComment {
+				id
+				author {
+					login
+				}
+				body
+				createdAt
+				viewerCanUpdate
+				viewerCanDelete
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"id":   commentID,
+			"body": body,
+		},
+	}
+
+	var response struct {
+		UpdateIssueComment struct {
+			IssueComment commentNode `json:"issueComment"`
+		} `json:"updateIssueComment"`
+	}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	comment := response.UpdateIssueComment.IssueComment.toModel()
+	return &comment, nil
+}
+
+// DeleteComment deletes an issue or pull request comment
+func (c *Client) DeleteComment(ctx context.Context, commentID string) error {
+	mutation := `mutation($input: DeleteIssueCommentInput!) {
+		deleteIssueComment(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"id": commentID,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
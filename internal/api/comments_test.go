@@ -0,0 +1,52 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+)
+
+func TestListCommentsPages(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	r := backend.AddRepository("octocat", "api")
+	issue := backend.AddIssue(p.ID, r.ID, "Crash on start", "")
+	for i := 0; i < 5; i++ {
+		if _, err := backend.AddComment(context.Background(), issue.ContentID, fmt.Sprintf("Comment %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client, _ := newClient(t, backend)
+
+	var bodies []string
+	after := ""
+	for pages := 1; ; pages++ {
+		comments, page, err := client.ListComments(context.Background(), issue.ContentID, 2, after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(comments) > 2 {
+			t.Fatalf("page %d has %d comments, want at most 2", pages, len(comments))
+		}
+		for _, comment := range comments {
+			bodies = append(bodies, comment.Body)
+		}
+		if !page.HasNextPage {
+			if pages != 3 {
+				t.Errorf("got %d pages, want 3", pages)
+			}
+			break
+		}
+		if pages == 3 {
+			t.Fatal("still more comments after 3 pages")
+		}
+		after = page.EndCursor
+	}
+
+	want := "[Comment 0 Comment 1 Comment 2 Comment 3 Comment 4]"
+	if got := fmt.Sprint(bodies); got != want {
+		t.Errorf("bodies = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// dependencyFields selects the issues an Issue is blocked by and blocking,
+// with their counts
+const dependencyFields = `blockedBy(first: 20) {
+									nodes {
+										` + issueRefFields + `
+									}
+								}
+								blocking(first: 20) {
+									nodes {
+										` + issueRefFields + `
+									}
+								}
+								issueDependenciesSummary {
+									blockedBy
+									totalBlockedBy
+									totalBlocking
+								}`
+
+// dependenciesNode is the GraphQL shape of dependencyFields
+type dependenciesNode struct {
+	BlockedBy struct {
+		Nodes []issueRefNode `json:"nodes"`
+	} `json:"blockedBy"`
+	Blocking struct {
+		Nodes []issueRefNode `json:"nodes"`
+	} `json:"blocking"`
+	IssueDependenciesSummary struct {
+		BlockedBy      int `json:"blockedBy"`
+		TotalBlockedBy int `json:"totalBlockedBy"`
+		TotalBlocking  int `json:"totalBlocking"`
+	} `json:"issueDependenciesSummary"`
+}
+
+// apply sets the issues an item is blocked by and blocking, and their counts
+func (n dependenciesNode) apply(item *models.ProjectItem) {
+	for _, node := range n.BlockedBy.Nodes {
+		item.BlockedBy = append(item.BlockedBy, node.toModel())
+	}
+	for _, node := range n.Blocking.Nodes {
+		item.Blocking = append(item.Blocking, node.toModel())
+	}
+	item.Dependencies = models.DependencySummary{
+		OpenBlockers:   n.IssueDependenciesSummary.BlockedBy,
+		TotalBlockedBy: n.IssueDependenciesSummary.TotalBlockedBy,
+		TotalBlocking:  n.IssueDependenciesSummary.TotalBlocking,
+	}
+}
+
+// AddBlockedBy marks an issue as blocked by another
+func (c *Client) AddBlockedBy(ctx context.Context, issueID, blockingIssueID string) error {
+	mutation := `mutation($input: AddBlockedByInput!) {
+		addBlockedBy(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"issueId":         issueID,
+			"blockingIssueId": blockingIssueID,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveBlockedBy removes the dependency of an issue on one blocking it
+func (c *Client) RemoveBlockedBy(ctx context.Context, issueID, blockingIssueID string) error {
+	mutation := `mutation($input: RemoveBlockedByInput!) {
+		removeBlockedBy(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"issueId":         issueID,
+			"blockingIssueId": blockingIssueID,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	return nil
+}
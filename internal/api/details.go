@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// GetItemDetails retrieves the body, first page of comments and recent timeline
+// of an issue, pull request or draft issue by its content ID
+func (c *Client) GetItemDetails(ctx context.Context, contentID string) (*models.ItemDetails, error) {
+	query := `query($id: ID!, $issueTypes: [IssueTimelineItemsItemType!], $pullRequestTypes: [PullRequestTimelineItemsItemType!]) {
+		node(id: $id) {
+			__typename
+			... on Issue {
+				body
+				updatedAt
+				comments(first: 50) {
+					totalCount
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						id
+						author {
+							login
+						}
+						body
+						createdAt
+						viewerCanUpdate
+						viewerCanDelete
+					}
+				}
+				timelineItems(last: 20, itemTypes: $issueTypes) {
+					nodes {` + timelineItemFields + `}
+				}
+				` + developmentFields + `
+			}
+			... on PullRequest {
+				body
+				updatedAt
+				comments(first: 50) {
+					totalCount
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						id
+						author {
+							login
+						}
+						body
+						createdAt
+						viewerCanUpdate
+						viewerCanDelete
+					}
+				}
+				timelineItems(last: 20, itemTypes: $pullRequestTypes) {
+					nodes {` + timelineItemFields + `}
+				}
+			}
+			... on DraftIssue {
+				body
+				updatedAt
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id":               contentID,
+		"issueTypes":       issueTimelineItemTypes(timelineItemTypes),
+		"pullRequestTypes": timelineItemTypes,
+	}
+
+	var response struct {
+		Node struct {
+			TypeName      string            `json:"__typename"`
+			Body          string            `json:"body"`
+			UpdatedAt     time.Time         `json:"updatedAt"`
+			Comments      commentConnection `json:"comments"`
+			TimelineItems struct {
+				Nodes []timelineNode `json:"nodes"`
+			} `json:"timelineItems"`
+
+			// Set on issues only
+			developmentNode
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item details: %w", err)
+	}
+
+	comments, commentsPage := response.Node.Comments.toModel()
+
+	details := &models.ItemDetails{
+		Body:         response.Node.Body,
+		Comments:     comments,
+		CommentCount: response.Node.Comments.TotalCount,
+		CommentsPage: commentsPage,
+		Timeline:     timelineNodesToModel(response.Node.TimelineItems.Nodes),
+		UpdatedAt:    response.Node.UpdatedAt,
+	}
+	response.Node.developmentNode.apply(details)
+	return details, nil
+}
+
+// GetItemUpdatedAt returns when an issue, pull request or draft issue was last updated
+func (c *Client) GetItemUpdatedAt(ctx context.Context, contentID string) (time.Time, error) {
+	query := `query($id: ID!) {
+		node(id: $id) {
+			... on Issue {
+				updatedAt
+			}
+			... on PullRequest {
+				updatedAt
+			}
+			... on DraftIssue {
+				updatedAt
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id": contentID,
+	}
+
+	var response struct {
+		Node *struct {
+			UpdatedAt time.Time `json:"updatedAt"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get item: %w", err)
+	}
+	if response.Node == nil {
+		return time.Time{}, apierrors.ValidationError("The item no longer exists", nil)
+	}
+
+	return response.Node.UpdatedAt, nil
+}
+
+// GetItemContent returns the current title and body of an issue, pull request
+// or draft issue, for comparing against a local edit
+func (c *Client) GetItemContent(ctx context.Context, contentID string) (*models.ItemContent, error) {
+	query := `query($id: ID!) {
+		node(id: $id) {
+			... on Issue {
+				title
+				body
+				updatedAt
+			}
+			... on PullRequest {
+				title
+				body
+				updatedAt
+			}
+			... on DraftIssue {
+				title
+				body
+				updatedAt
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id": contentID,
+	}
+
+	var response struct {
+		Node *struct {
+			Title     string    `json:"title"`
+			Body      string    `json:"body"`
+			UpdatedAt time.Time `json:"updatedAt"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+	if response.Node == nil {
+		return nil, apierrors.ValidationError("The item no longer exists", nil)
+	}
+
+	return &models.ItemContent{
+		Title:     response.Node.Title,
+		Body:      response.Node.Body,
+		UpdatedAt: response.Node.UpdatedAt,
+	}, nil
+}
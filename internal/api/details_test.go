@@ -0,0 +1,61 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+)
+
+func TestGetItemDetailsRecentActivity(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	r := backend.AddRepository("octocat", "api")
+	bug := backend.AddLabel(r.ID, "bug", "d73a4a")
+	issue := backend.AddIssue(p.ID, r.ID, "Crash on start", "It crashes")
+	if _, err := backend.AddComment(context.Background(), issue.ContentID, "Same here"); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.AddLabels(context.Background(), issue.ContentID, []string{bug.ID}); err != nil {
+		t.Fatal(err)
+	}
+	client, _ := newClient(t, backend)
+
+	details, err := client.GetItemDetails(context.Background(), issue.ContentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if details.Body != "It crashes" || len(details.Comments) != 1 {
+		t.Errorf("details = %+v, want the body and comment", details)
+	}
+	timeline := details.Timeline
+	if len(timeline) != 2 || timeline[0].Summary != "added this to Roadmap" || timeline[1].Summary != `added label "bug"` {
+		t.Errorf("timeline = %+v, want the project and label events", timeline)
+	}
+}
+
+func TestGetItemDetailsDevelopment(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	r := backend.AddRepository("octocat", "api")
+	issue := backend.AddIssue(p.ID, r.ID, "Crash on start", "")
+	pr := backend.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+	backend.LinkPullRequest(issue.ContentID, pr.ContentID)
+	backend.LinkBranch(issue.ContentID, r.ID, "fix-crash")
+	client, _ := newClient(t, backend)
+
+	details, err := client.GetItemDetails(context.Background(), issue.ContentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prs := details.LinkedPullRequests
+	if len(prs) != 1 || prs[0].ID != pr.ContentID || prs[0].Number != 2 || prs[0].State != "OPEN" || prs[0].Repository != "octocat/api" {
+		t.Errorf("linked pull requests = %+v, want #2", prs)
+	}
+	branches := details.LinkedBranches
+	if len(branches) != 1 || branches[0].Name != "fix-crash" || branches[0].Repository != "octocat/api" {
+		t.Errorf("linked branches = %+v, want fix-crash", branches)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import "github.com/thomaskoefod/githubProjectTUI/internal/models"
+
+// developmentFields selects the pull requests that close an Issue and the
+// branches linked to it
+const developmentFields = `closedByPullRequestsReferences(first: 10, includeClosedPrs: true) {
+					nodes {
+						` + issueRefFields + `
+					}
+				}
+				linkedBranches(first: 10) {
+					nodes {
+						ref {
+							name
+							repository {
+								nameWithOwner
+							}
+						}
+					}
+				}`
+
+// developmentNode is the GraphQL shape of developmentFields
+type developmentNode struct {
+	ClosedByPullRequestsReferences struct {
+		Nodes []issueRefNode `json:"nodes"`
+	} `json:"closedByPullRequestsReferences"`
+	LinkedBranches struct {
+		Nodes []struct {
+			Ref *struct {
+				Name       string `json:"name"`
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+			} `json:"ref"`
+		} `json:"nodes"`
+	} `json:"linkedBranches"`
+}
+
+// apply sets the pull requests and branches linked to an issue
+func (n developmentNode) apply(details *models.ItemDetails) {
+	for _, node := range n.ClosedByPullRequestsReferences.Nodes {
+		details.LinkedPullRequests = append(details.LinkedPullRequests, node.toModel())
+	}
+	for _, node := range n.LinkedBranches.Nodes {
+		// The ref is gone once the branch is deleted
+		if node.Ref == nil {
+			continue
+		}
+		details.LinkedBranches = append(details.LinkedBranches, models.LinkedBranch{
+			Name:       node.Ref.Name,
+			Repository: node.Ref.Repository.NameWithOwner,
+		})
+	}
+}
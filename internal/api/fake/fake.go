@@ -0,0 +1,2239 @@
+// Package fake provides an in-memory implementation of api.Interface for
+// tests. It keeps users, organizations, projects, items, fields and
+// repositories in memory, runs on a deterministic clock and IDs, records
+// every call and can be told to fail the next call to a method. Server serves
+// the same data over GitHub's GraphQL API for testing the real api.Client.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// Epoch is the fake clock's starting time. Every mutation advances it by a minute.
+var Epoch = time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+// Client is an in-memory GitHub. Create it with New and seed it with the
+// Add methods; it is safe for concurrent use.
+type Client struct {
+	mu sync.Mutex
+
+	viewer       string
+	now          time.Time
+	nextID       int
+	users        map[string]string // Login to node ID
+	orgs         map[string]*org
+	projects     []*project
+	repositories []*repository
+	contents     map[string]*content
+	calls        []string
+	failures     map[string][]error
+	rateLimit    api.RateLimit
+}
+
+type org struct {
+	id      string
+	login   string
+	members []string
+	teams   []models.Team
+}
+
+type project struct {
+	models.Project
+	ownerID       string
+	items         []*item
+	fields        []models.ProjectField
+	collaborators []models.ProjectCollaborator
+	statusUpdates []models.StatusUpdate // Oldest first
+}
+
+type item struct {
+	id        string
+	contentID string
+}
+
+type repository struct {
+	models.Repository
+	issues     int // Last issue number used
+	labels     []models.Label
+	milestones []models.Milestone
+}
+
+type content struct {
+	id          string
+	typeName    string // "DraftIssue", "Issue" or "PullRequest"
+	title       string
+	body        string
+	number      int
+	state       string
+	stateReason string // Closed issues only
+	url         string
+	assignees   []string
+	comments    []models.Comment
+	createdAt   time.Time
+	updatedAt   time.Time
+
+	// Issues and pull requests only
+	repositoryID string
+	labels       []string // Label IDs
+	milestoneID  string
+
+	pullRequest models.PullRequestStatus // Pull requests only
+
+	// Sub-issue hierarchy, issues only
+	parentID  string
+	subIssues []string // Content IDs, in the order they were added
+
+	// Dependencies, issues only
+	blockedBy []string // Content IDs, in the order they were added
+	blocking  []string
+
+	// Development, issues only
+	closedBy []string // Content IDs of pull requests that close the issue
+	branches []linkedBranch
+
+	timeline []timelineEvent // Issues and pull requests only, oldest first
+}
+
+// timelineEvent is something that happened to an issue or pull request. Only
+// the fields for its type are set.
+type timelineEvent struct {
+	typeName       string // GraphQL type, e.g. "LabeledEvent"
+	actor          string
+	createdAt      time.Time
+	label          string // Label name
+	assignee       string // Login
+	source         *content
+	stateReason    string
+	previousTitle  string
+	currentTitle   string
+	milestone      string // Milestone title
+	project        string // Project title
+	previousStatus string
+	status         string
+}
+
+// linkedBranch is a branch linked to an issue
+type linkedBranch struct {
+	name         string
+	repositoryID string
+}
+
+// New returns an empty fake GitHub where viewer is the logged in user
+func New(viewer string) *Client {
+	c := &Client{
+		viewer:   viewer,
+		now:      Epoch,
+		users:    make(map[string]string),
+		orgs:     make(map[string]*org),
+		contents: make(map[string]*content),
+		failures: make(map[string][]error),
+		rateLimit: api.RateLimit{
+			Limit:     5000,
+			Remaining: 5000,
+			ResetAt:   Epoch.Add(time.Hour),
+			Known:     true,
+		},
+	}
+	c.AddUser(viewer)
+	return c
+}
+
+var _ api.Interface = (*Client)(nil)
+
+// --- Seeding and inspection ---
+
+// AddUser adds a user and returns its node ID
+func (c *Client) AddUser(login string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if id, ok := c.users[login]; ok {
+		return id
+	}
+	id := c.newID("U")
+	c.users[login] = id
+	return id
+}
+
+// AddOrg adds an organization with the given members and returns its node ID.
+// Members are added as users if they don't exist yet.
+func (c *Client) AddOrg(login string, members ...string) string {
+	for _, member := range members {
+		c.AddUser(member)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o := &org{id: c.newID("O"), login: login, members: members}
+	c.orgs[login] = o
+	return o.id
+}
+
+// AddTeam adds a team to an organization
+func (c *Client) AddTeam(orgLogin, slug, name string) models.Team {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	team := models.Team{ID: c.newID("T"), Slug: slug, Name: name}
+	if o, ok := c.orgs[orgLogin]; ok {
+		o.teams = append(o.teams, team)
+	}
+	return team
+}
+
+// AddProject adds a project owned by a user or organization
+func (c *Client) AddProject(owner, title string) models.Project {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ownerID, ownerType := c.ownerOf(owner)
+	p := c.newProject(ownerID, models.ProjectOwner{Login: owner, Type: ownerType}, title)
+	return p.Project
+}
+
+// AddRepository adds a repository that issues can be created in
+func (c *Client) AddRepository(owner, name string) models.Repository {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r := &repository{Repository: models.Repository{ID: c.newID("R"), Name: name, Owner: owner}}
+	c.repositories = append(c.repositories, r)
+	return r.Repository
+}
+
+// AddLabel adds a label to a repository. Color is hex without the '#'.
+func (c *Client) AddLabel(repositoryID, name, color string) models.Label {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r := c.repository(repositoryID)
+	if r == nil {
+		panic("fake: unknown repository " + repositoryID)
+	}
+	label := models.Label{ID: c.newID("LA"), Name: name, Color: color}
+	r.labels = append(r.labels, label)
+	return label
+}
+
+// AddMilestone adds an open milestone to a repository
+func (c *Client) AddMilestone(repositoryID, title string, dueOn time.Time) models.Milestone {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r := c.repository(repositoryID)
+	if r == nil {
+		panic("fake: unknown repository " + repositoryID)
+	}
+	milestone := models.Milestone{ID: c.newID("MI"), Title: title, Number: len(r.milestones) + 1, DueOn: dueOn}
+	r.milestones = append(r.milestones, milestone)
+	return milestone
+}
+
+// AddDraft adds a draft issue to a project
+func (c *Client) AddDraft(projectID, title, body string) models.ProjectItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.project(projectID)
+	if p == nil {
+		panic("fake: unknown project " + projectID)
+	}
+	now := c.tick()
+	ct := &content{id: c.newID("DI"), typeName: "DraftIssue", title: title, body: body, createdAt: now, updatedAt: now}
+	c.contents[ct.id] = ct
+	it := &item{id: c.newID("PVTI"), contentID: ct.id}
+	p.items = append(p.items, it)
+	return c.itemModel(it)
+}
+
+// AddIssue adds an open issue in a repository to a project
+func (c *Client) AddIssue(projectID, repositoryID, title, body string) models.ProjectItem {
+	return c.addRepositoryContent("Issue", projectID, repositoryID, title, body)
+}
+
+// AddPullRequest adds an open pull request in a repository to a project
+func (c *Client) AddPullRequest(projectID, repositoryID, title, body string) models.ProjectItem {
+	return c.addRepositoryContent("PullRequest", projectID, repositoryID, title, body)
+}
+
+func (c *Client) addRepositoryContent(typeName, projectID, repositoryID, title, body string) models.ProjectItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.project(projectID)
+	r := c.repository(repositoryID)
+	if p == nil || r == nil {
+		panic("fake: unknown project or repository")
+	}
+	ct := c.newContent(r, typeName, title, body, nil)
+	it := &item{id: c.newID("PVTI"), contentID: ct.id}
+	p.items = append(p.items, it)
+	c.record(ct, ct.createdAt, timelineEvent{typeName: "AddedToProjectV2Event", project: p.Title})
+	return c.itemModel(it)
+}
+
+// SetPullRequestStatus replaces a pull request's review and CI status.
+// Teams among the reviewers are given as "org/slug".
+func (c *Client) SetPullRequestStatus(contentID string, status models.PullRequestStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ct := c.contents[contentID]
+	if ct == nil || ct.typeName != "PullRequest" {
+		panic("fake: unknown pull request " + contentID)
+	}
+	status.Reviewers = append([]string(nil), status.Reviewers...)
+	ct.pullRequest = status
+}
+
+// LinkPullRequest makes a pull request one that closes an issue when merged,
+// which references the issue in its timeline
+func (c *Client) LinkPullRequest(issueID, pullRequestID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, pr := c.contents[issueID], c.contents[pullRequestID]
+	if issue == nil || issue.typeName != "Issue" || pr == nil || pr.typeName != "PullRequest" {
+		panic("fake: unknown issue or pull request")
+	}
+	issue.closedBy = append(issue.closedBy, pr.id)
+	c.record(issue, c.tick(), timelineEvent{typeName: "CrossReferencedEvent", source: pr})
+}
+
+// LinkBranch links a branch in a repository to an issue
+func (c *Client) LinkBranch(issueID, repositoryID, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, r := c.contents[issueID], c.repository(repositoryID)
+	if issue == nil || issue.typeName != "Issue" || r == nil {
+		panic("fake: unknown issue or repository")
+	}
+	issue.branches = append(issue.branches, linkedBranch{name: name, repositoryID: r.ID})
+}
+
+// AddField adds a custom field to a project. Options are only used by
+// SINGLE_SELECT fields.
+func (c *Client) AddField(projectID, name, dataType string, options ...string) models.ProjectField {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.project(projectID)
+	if p == nil {
+		panic("fake: unknown project " + projectID)
+	}
+	field := models.ProjectField{ID: c.newID("PVTF"), Name: name, DataType: dataType}
+	for _, option := range options {
+		field.Options = append(field.Options, models.ProjectFieldOption{ID: c.newID("OPT"), Name: option, Color: "GRAY"})
+	}
+	p.fields = append(p.fields, field)
+	return field
+}
+
+// Edit changes an item's title and body as if a teammate had edited it on GitHub
+func (c *Client) Edit(contentID, title, body string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ct, ok := c.contents[contentID]; ok {
+		ct.title = title
+		ct.body = body
+		ct.updatedAt = c.tick()
+	}
+}
+
+// Items returns a project's items as ListProjectItems would, including bodies
+func (c *Client) Items(projectID string) []models.ProjectItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.project(projectID)
+	if p == nil {
+		return nil
+	}
+	items := make([]models.ProjectItem, len(p.items))
+	for i, it := range p.items {
+		items[i] = c.itemModel(it)
+		items[i].Body = c.contents[it.contentID].body
+	}
+	return items
+}
+
+// Calls returns the names of the methods called so far, in order
+func (c *Client) Calls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.calls...)
+}
+
+// FailNext makes the next call to method return err instead of doing anything.
+// Calling it again queues further failures for later calls.
+func (c *Client) FailNext(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures[method] = append(c.failures[method], err)
+}
+
+// SetRateLimit sets the budget reported by RateLimit
+func (c *Client) SetRateLimit(rl api.RateLimit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = rl
+}
+
+// --- api.Interface ---
+
+// Mutations take the lock and delegate to an unexported helper, which Server
+// calls directly while it holds the lock for a whole request.
+
+func (c *Client) RateLimit() api.RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
+func (c *Client) GetViewer(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetViewer"); err != nil {
+		return "", err
+	}
+	return c.viewer, nil
+}
+
+func (c *Client) GetUserOrganizations(ctx context.Context, username string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetUserOrganizations"); err != nil {
+		return nil, err
+	}
+
+	orgs := []string{}
+	for _, o := range c.orgs {
+		for _, member := range o.members {
+			if member == username {
+				orgs = append(orgs, o.login)
+			}
+		}
+	}
+	sort.Strings(orgs)
+	return orgs, nil
+}
+
+func (c *Client) GetUserNodeID(ctx context.Context, username string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetUserNodeID"); err != nil {
+		return "", err
+	}
+
+	id, ok := c.users[username]
+	if !ok {
+		return "", notFound("user", username)
+	}
+	return id, nil
+}
+
+func (c *Client) GetOrgNodeID(ctx context.Context, orgLogin string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetOrgNodeID"); err != nil {
+		return "", err
+	}
+
+	o, ok := c.orgs[orgLogin]
+	if !ok {
+		return "", notFound("organization", orgLogin)
+	}
+	return o.id, nil
+}
+
+func (c *Client) SearchUsers(ctx context.Context, query string, limit int) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SearchUsers"); err != nil {
+		return nil, err
+	}
+
+	logins := make([]string, 0, len(c.users))
+	for login := range c.users {
+		logins = append(logins, login)
+	}
+	return matching(logins, query, limit), nil
+}
+
+func (c *Client) GetOrgMembers(ctx context.Context, orgLogin string, limit int) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetOrgMembers"); err != nil {
+		return nil, err
+	}
+
+	o, ok := c.orgs[orgLogin]
+	if !ok {
+		return nil, notFound("organization", orgLogin)
+	}
+	return matching(o.members, "", limit), nil
+}
+
+func (c *Client) SearchOrgMembers(ctx context.Context, orgLogin string, query string, limit int) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SearchOrgMembers"); err != nil {
+		return nil, err
+	}
+
+	o, ok := c.orgs[orgLogin]
+	if !ok {
+		return nil, notFound("organization", orgLogin)
+	}
+	return matching(o.members, query, limit), nil
+}
+
+func (c *Client) SearchOrgTeams(ctx context.Context, orgLogin string, query string, limit int) ([]models.Team, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SearchOrgTeams"); err != nil {
+		return nil, err
+	}
+
+	o, ok := c.orgs[orgLogin]
+	if !ok {
+		return nil, notFound("organization", orgLogin)
+	}
+	var teams []models.Team
+	for _, team := range o.teams {
+		if len(teams) == limit {
+			break
+		}
+		if contains(team.Slug, query) || contains(team.Name, query) {
+			teams = append(teams, team)
+		}
+	}
+	return teams, nil
+}
+
+func (c *Client) ListUserProjects(ctx context.Context, login string, first int) ([]models.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListUserProjects"); err != nil {
+		return nil, err
+	}
+	return c.projectsOf(login, "User", first), nil
+}
+
+func (c *Client) ListOrgProjects(ctx context.Context, orgLogin string, first int) ([]models.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListOrgProjects"); err != nil {
+		return nil, err
+	}
+	return c.projectsOf(orgLogin, "Organization", first), nil
+}
+
+func (c *Client) CreateProject(ctx context.Context, input models.CreateProjectInput) (*models.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreateProject"); err != nil {
+		return nil, err
+	}
+
+	p, err := c.createProject(input)
+	if err != nil {
+		return nil, err
+	}
+	result := p.Project
+	return &result, nil
+}
+
+func (c *Client) createProject(input models.CreateProjectInput) (*project, error) {
+	owner, ok := c.ownerByID(input.OwnerID)
+	if !ok {
+		return nil, notFound("owner", input.OwnerID)
+	}
+	p := c.newProject(input.OwnerID, owner, input.Title)
+	p.ShortDescription = input.ShortDescription
+	p.Public = input.Public
+	return p, nil
+}
+
+func (c *Client) UpdateProject(ctx context.Context, input models.UpdateProjectInput) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "UpdateProject"); err != nil {
+		return err
+	}
+
+	_, err := c.updateProject(input)
+	return err
+}
+
+func (c *Client) updateProject(input models.UpdateProjectInput) (*project, error) {
+	p := c.project(input.ProjectID)
+	if p == nil {
+		return nil, notFound("project", input.ProjectID)
+	}
+	if input.Title != nil {
+		p.Title = *input.Title
+	}
+	if input.ShortDescription != nil {
+		p.ShortDescription = *input.ShortDescription
+	}
+	if input.Public != nil {
+		p.Public = *input.Public
+	}
+	if input.Closed != nil {
+		p.Closed = *input.Closed
+	}
+	p.UpdatedAt = c.tick()
+	return p, nil
+}
+
+func (c *Client) CopyProject(ctx context.Context, input models.CopyProjectInput) (*models.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CopyProject"); err != nil {
+		return nil, err
+	}
+
+	p, err := c.copyProject(input)
+	if err != nil {
+		return nil, err
+	}
+	result := p.Project
+	return &result, nil
+}
+
+func (c *Client) copyProject(input models.CopyProjectInput) (*project, error) {
+	source := c.project(input.SourceProjectID)
+	if source == nil {
+		return nil, notFound("project", input.SourceProjectID)
+	}
+	owner, ok := c.ownerByID(input.OwnerID)
+	if !ok {
+		return nil, notFound("owner", input.OwnerID)
+	}
+
+	p := c.newProject(input.OwnerID, owner, input.Title)
+	p.ShortDescription = source.ShortDescription
+	p.fields = append([]models.ProjectField(nil), source.fields...)
+	if input.IncludeDraftIssues {
+		for _, it := range source.items {
+			original := c.contents[it.contentID]
+			if original.typeName != "DraftIssue" {
+				continue
+			}
+			copied := *original
+			copied.id = c.newID("DI")
+			copied.comments = nil
+			c.contents[copied.id] = &copied
+			p.items = append(p.items, &item{id: c.newID("PVTI"), contentID: copied.id})
+		}
+	}
+	p.ItemCount = len(p.items)
+	return p, nil
+}
+
+func (c *Client) SetProjectTemplate(ctx context.Context, projectID string, template bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SetProjectTemplate"); err != nil {
+		return err
+	}
+
+	_, err := c.setProjectTemplate(projectID, template)
+	return err
+}
+
+func (c *Client) setProjectTemplate(projectID string, template bool) (*project, error) {
+	p := c.project(projectID)
+	if p == nil {
+		return nil, notFound("project", projectID)
+	}
+	if p.Owner.Type != "Organization" {
+		return nil, apierrors.ValidationError("Only organization projects can be marked as templates", nil)
+	}
+	p.Template = template
+	p.UpdatedAt = c.tick()
+	return p, nil
+}
+
+func (c *Client) ListProjectCollaborators(ctx context.Context, projectID string, first int) ([]models.ProjectCollaborator, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListProjectCollaborators"); err != nil {
+		return nil, err
+	}
+
+	p := c.project(projectID)
+	if p == nil {
+		return nil, notFound("project", projectID)
+	}
+	collaborators := append([]models.ProjectCollaborator{}, p.collaborators...)
+	if len(collaborators) > first {
+		collaborators = collaborators[:first]
+	}
+	return collaborators, nil
+}
+
+func (c *Client) UpdateProjectCollaborators(ctx context.Context, projectID string, collaborators []models.ProjectCollaborator) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "UpdateProjectCollaborators"); err != nil {
+		return err
+	}
+
+	p := c.project(projectID)
+	if p == nil {
+		return notFound("project", projectID)
+	}
+
+	for _, update := range collaborators {
+		index := -1
+		for i, existing := range p.collaborators {
+			if existing.ID == update.ID && existing.Type == update.Type {
+				index = i
+			}
+		}
+		switch {
+		case update.Role == api.RoleNone && index >= 0:
+			p.collaborators = append(p.collaborators[:index], p.collaborators[index+1:]...)
+		case update.Role == api.RoleNone:
+			// Removing someone without access is a no-op
+		case index >= 0:
+			p.collaborators[index].Role = update.Role
+		default:
+			p.collaborators = append(p.collaborators, update)
+		}
+	}
+	return nil
+}
+
+func (c *Client) ListStatusUpdates(ctx context.Context, projectID string, first int) ([]models.StatusUpdate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListStatusUpdates"); err != nil {
+		return nil, err
+	}
+
+	p := c.project(projectID)
+	if p == nil {
+		return nil, notFound("project", projectID)
+	}
+	// Newest first, like the real query
+	updates := []models.StatusUpdate{}
+	for i := len(p.statusUpdates) - 1; i >= 0 && len(updates) < first; i-- {
+		updates = append(updates, p.statusUpdates[i])
+	}
+	return updates, nil
+}
+
+func (c *Client) CreateStatusUpdate(ctx context.Context, input models.CreateStatusUpdateInput) (*models.StatusUpdate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreateStatusUpdate"); err != nil {
+		return nil, err
+	}
+
+	p := c.project(input.ProjectID)
+	if p == nil {
+		return nil, notFound("project", input.ProjectID)
+	}
+	update := models.StatusUpdate{
+		ID:         c.newID("PVTSU"),
+		Status:     input.Status,
+		Body:       input.Body,
+		StartDate:  input.StartDate,
+		TargetDate: input.TargetDate,
+		Author:     c.viewer,
+		CreatedAt:  c.tick(),
+	}
+	p.statusUpdates = append(p.statusUpdates, update)
+	return &update, nil
+}
+
+func (c *Client) ListProjectItems(ctx context.Context, projectID string, first int) ([]models.ProjectItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListProjectItems"); err != nil {
+		return nil, err
+	}
+
+	p := c.project(projectID)
+	if p == nil {
+		return nil, notFound("project", projectID)
+	}
+	items := make([]models.ProjectItem, 0, len(p.items))
+	for _, it := range p.items {
+		if len(items) == first {
+			break
+		}
+		items = append(items, c.itemModel(it))
+	}
+	return items, nil
+}
+
+func (c *Client) AddProjectItem(ctx context.Context, input models.CreateItemInput) (*models.ProjectItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "AddProjectItem"); err != nil {
+		return nil, err
+	}
+
+	it, err := c.addProjectItem(input)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ProjectItem{ID: it.id}, nil
+}
+
+func (c *Client) addProjectItem(input models.CreateItemInput) (*item, error) {
+	p := c.project(input.ProjectID)
+	if p == nil {
+		return nil, notFound("project", input.ProjectID)
+	}
+	if _, ok := c.contents[input.ContentID]; !ok {
+		return nil, notFound("content", input.ContentID)
+	}
+	// Adding an item twice returns the existing one, as GitHub does
+	for _, it := range p.items {
+		if it.contentID == input.ContentID {
+			return it, nil
+		}
+	}
+	it := &item{id: c.newID("PVTI"), contentID: input.ContentID}
+	p.items = append(p.items, it)
+	p.ItemCount = len(p.items)
+	c.record(c.contents[input.ContentID], c.tick(), timelineEvent{typeName: "AddedToProjectV2Event", project: p.Title})
+	return it, nil
+}
+
+func (c *Client) CreateDraftIssue(ctx context.Context, input models.CreateItemInput) (*models.ProjectItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreateDraftIssue"); err != nil {
+		return nil, err
+	}
+
+	it, err := c.createDraftIssue(input)
+	if err != nil {
+		return nil, err
+	}
+	ct := c.contents[it.contentID]
+	return &models.ProjectItem{
+		ID:        it.id,
+		ContentID: ct.id,
+		Type:      "DraftIssue",
+		Title:     ct.title,
+		Body:      ct.body,
+		CreatedAt: ct.createdAt,
+		Assignees: []string{},
+	}, nil
+}
+
+func (c *Client) createDraftIssue(input models.CreateItemInput) (*item, error) {
+	p := c.project(input.ProjectID)
+	if p == nil {
+		return nil, notFound("project", input.ProjectID)
+	}
+	if strings.TrimSpace(input.Title) == "" {
+		return nil, apierrors.ValidationError("Title can't be blank", map[string]string{"title": "can't be blank"})
+	}
+
+	now := c.tick()
+	// Assignees are ignored on creation, as by the real mutation
+	ct := &content{id: c.newID("DI"), typeName: "DraftIssue", title: input.Title, body: input.Body, createdAt: now, updatedAt: now}
+	c.contents[ct.id] = ct
+	it := &item{id: c.newID("PVTI"), contentID: ct.id}
+	p.items = append(p.items, it)
+	p.ItemCount = len(p.items)
+	return it, nil
+}
+
+func (c *Client) UpdateDraftIssue(ctx context.Context, itemID, title, body string, assigneeIDs []string) (*models.ProjectItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "UpdateDraftIssue"); err != nil {
+		return nil, err
+	}
+
+	ct, err := c.updateDraftIssue(itemID, title, body, assigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ProjectItem{
+		ID:        ct.id,
+		Type:      "DraftIssue",
+		Title:     ct.title,
+		Body:      ct.body,
+		UpdatedAt: ct.updatedAt,
+		Assignees: append([]string{}, ct.assignees...),
+	}, nil
+}
+
+func (c *Client) updateDraftIssue(draftID, title, body string, assigneeIDs []string) (*content, error) {
+	ct, ok := c.contents[draftID]
+	if !ok || ct.typeName != "DraftIssue" {
+		return nil, notFound("draft issue", draftID)
+	}
+
+	assignees := []string{}
+	for _, id := range assigneeIDs {
+		login, ok := c.loginOf(id)
+		if !ok {
+			return nil, notFound("user", id)
+		}
+		assignees = append(assignees, login)
+	}
+
+	// Empty values are left out of the real mutation, so they don't change
+	// anything. Assignees are left out only when nil, an empty list clears them.
+	if title != "" {
+		ct.title = title
+	}
+	if body != "" {
+		ct.body = body
+	}
+	if assigneeIDs != nil {
+		ct.assignees = assignees
+	}
+	ct.updatedAt = c.tick()
+	return ct, nil
+}
+
+func (c *Client) DeleteProjectItem(ctx context.Context, projectID, itemID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "DeleteProjectItem"); err != nil {
+		return err
+	}
+	return c.deleteProjectItem(projectID, itemID)
+}
+
+func (c *Client) deleteProjectItem(projectID, itemID string) error {
+	p := c.project(projectID)
+	if p == nil {
+		return notFound("project", projectID)
+	}
+	for i, it := range p.items {
+		if it.id != itemID {
+			continue
+		}
+		p.items = append(p.items[:i], p.items[i+1:]...)
+		p.ItemCount = len(p.items)
+		// Drafts only exist inside their project
+		if c.contents[it.contentID].typeName == "DraftIssue" {
+			delete(c.contents, it.contentID)
+			return nil
+		}
+		c.record(c.contents[it.contentID], c.tick(), timelineEvent{typeName: "RemovedFromProjectV2Event", project: p.Title})
+		return nil
+	}
+	return notFound("project item", itemID)
+}
+
+func (c *Client) ConvertDraftIssueToIssue(ctx context.Context, projectItemID, repositoryID string) (*models.ProjectItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ConvertDraftIssueToIssue"); err != nil {
+		return nil, err
+	}
+
+	it, err := c.convertDraftIssue(projectItemID, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	issue := c.contents[it.contentID]
+	return &models.ProjectItem{
+		ID:     it.id,
+		Type:   "Issue",
+		Title:  issue.title,
+		Number: issue.number,
+		URL:    issue.url,
+	}, nil
+}
+
+func (c *Client) convertDraftIssue(projectItemID, repositoryID string) (*item, error) {
+	it := c.item(projectItemID)
+	if it == nil {
+		return nil, notFound("project item", projectItemID)
+	}
+	r := c.repository(repositoryID)
+	if r == nil {
+		return nil, notFound("repository", repositoryID)
+	}
+	draft := c.contents[it.contentID]
+	if draft.typeName != "DraftIssue" {
+		return nil, apierrors.ValidationError("Only draft issues can be converted", nil)
+	}
+
+	issue := c.newContent(r, "Issue", draft.title, draft.body, draft.assignees)
+	delete(c.contents, draft.id)
+	it.contentID = issue.id
+	return it, nil
+}
+
+func (c *Client) GetItemDetails(ctx context.Context, contentID string) (*models.ItemDetails, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetItemDetails"); err != nil {
+		return nil, err
+	}
+
+	ct, ok := c.contents[contentID]
+	if !ok {
+		return nil, notFound("item", contentID)
+	}
+	comments, page := commentPage(ct.comments, 50, "")
+	details := &models.ItemDetails{
+		Body:         ct.body,
+		Comments:     comments,
+		CommentCount: len(ct.comments),
+		CommentsPage: page,
+		UpdatedAt:    ct.updatedAt,
+	}
+	for _, event := range ct.timeline[max(len(ct.timeline)-20, 0):] {
+		details.Timeline = append(details.Timeline, event.model())
+	}
+	for _, id := range ct.closedBy {
+		details.LinkedPullRequests = append(details.LinkedPullRequests, c.issueRef(c.contents[id]))
+	}
+	for _, branch := range ct.branches {
+		r := c.repository(branch.repositoryID)
+		details.LinkedBranches = append(details.LinkedBranches, models.LinkedBranch{Name: branch.name, Repository: r.Owner + "/" + r.Name})
+	}
+	return details, nil
+}
+
+func (c *Client) GetItemUpdatedAt(ctx context.Context, contentID string) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetItemUpdatedAt"); err != nil {
+		return time.Time{}, err
+	}
+
+	ct, ok := c.contents[contentID]
+	if !ok {
+		return time.Time{}, apierrors.ValidationError("The item no longer exists", nil)
+	}
+	return ct.updatedAt, nil
+}
+
+func (c *Client) GetItemContent(ctx context.Context, contentID string) (*models.ItemContent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetItemContent"); err != nil {
+		return nil, err
+	}
+
+	ct, ok := c.contents[contentID]
+	if !ok {
+		return nil, apierrors.ValidationError("The item no longer exists", nil)
+	}
+	return &models.ItemContent{Title: ct.title, Body: ct.body, UpdatedAt: ct.updatedAt}, nil
+}
+
+func (c *Client) UpdateIssue(ctx context.Context, issueID, title, body string) (*models.ItemContent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "UpdateIssue"); err != nil {
+		return nil, err
+	}
+
+	ct, err := c.updateContent("Issue", issueID, title, body)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ItemContent{Title: ct.title, Body: ct.body, UpdatedAt: ct.updatedAt}, nil
+}
+
+func (c *Client) UpdatePullRequest(ctx context.Context, pullRequestID, title, body string) (*models.ItemContent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "UpdatePullRequest"); err != nil {
+		return nil, err
+	}
+
+	ct, err := c.updateContent("PullRequest", pullRequestID, title, body)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ItemContent{Title: ct.title, Body: ct.body, UpdatedAt: ct.updatedAt}, nil
+}
+
+// updateContent sets the title and body of an issue or pull request. Unlike
+// drafts, an empty body clears it.
+func (c *Client) updateContent(typeName, id, title, body string) (*content, error) {
+	ct, ok := c.contents[id]
+	if !ok || ct.typeName != typeName {
+		return nil, notFound(typeName, id)
+	}
+	if strings.TrimSpace(title) == "" {
+		return nil, apierrors.ValidationError("Title can't be blank", map[string]string{"title": "can't be blank"})
+	}
+	ct.updatedAt = c.tick()
+	if title != ct.title {
+		c.record(ct, ct.updatedAt, timelineEvent{typeName: "RenamedTitleEvent", previousTitle: ct.title, currentTitle: title})
+	}
+	ct.title = title
+	ct.body = body
+	return ct, nil
+}
+
+func (c *Client) AddAssignees(ctx context.Context, assignableID string, userIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "AddAssignees"); err != nil {
+		return err
+	}
+	_, err := c.changeAssignees(assignableID, userIDs, true)
+	return err
+}
+
+func (c *Client) RemoveAssignees(ctx context.Context, assignableID string, userIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "RemoveAssignees"); err != nil {
+		return err
+	}
+	_, err := c.changeAssignees(assignableID, userIDs, false)
+	return err
+}
+
+// changeAssignees adds or removes users from an issue's or pull request's
+// assignees. Adding someone already assigned or removing someone who isn't
+// changes nothing, as on GitHub.
+func (c *Client) changeAssignees(assignableID string, userIDs []string, add bool) (*content, error) {
+	ct, ok := c.contents[assignableID]
+	if !ok || ct.typeName == "DraftIssue" {
+		return nil, notFound("issue or pull request", assignableID)
+	}
+
+	now := c.tick()
+	for _, id := range userIDs {
+		login, ok := c.loginOf(id)
+		if !ok {
+			return nil, notFound("user", id)
+		}
+		index := -1
+		for i, assignee := range ct.assignees {
+			if assignee == login {
+				index = i
+			}
+		}
+		switch {
+		case add && index < 0:
+			ct.assignees = append(ct.assignees, login)
+			c.record(ct, now, timelineEvent{typeName: "AssignedEvent", assignee: login})
+		case !add && index >= 0:
+			ct.assignees = append(ct.assignees[:index], ct.assignees[index+1:]...)
+			c.record(ct, now, timelineEvent{typeName: "UnassignedEvent", assignee: login})
+		}
+	}
+	ct.updatedAt = now
+	return ct, nil
+}
+
+func (c *Client) CloseIssue(ctx context.Context, issueID, reason, duplicateOfID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CloseIssue"); err != nil {
+		return err
+	}
+	_, err := c.closeIssue(issueID, reason, duplicateOfID)
+	return err
+}
+
+// closeIssue closes an issue, or changes the reason it was closed for
+func (c *Client) closeIssue(issueID, reason, duplicateOfID string) (*content, error) {
+	ct, ok := c.contents[issueID]
+	if !ok || ct.typeName != "Issue" {
+		return nil, notFound("issue", issueID)
+	}
+	switch reason {
+	case "COMPLETED", "NOT_PLANNED", "DUPLICATE":
+	default:
+		return nil, apierrors.ValidationError(fmt.Sprintf("Unknown close reason %q", reason), nil)
+	}
+	if duplicateOfID != "" {
+		duplicate, ok := c.contents[duplicateOfID]
+		if !ok || duplicate.typeName != "Issue" {
+			return nil, notFound("issue", duplicateOfID)
+		}
+		if duplicate == ct {
+			return nil, apierrors.ValidationError("An issue can't be a duplicate of itself", nil)
+		}
+	}
+
+	ct.state = "CLOSED"
+	ct.stateReason = reason
+	ct.updatedAt = c.tick()
+	c.record(ct, ct.updatedAt, timelineEvent{typeName: "ClosedEvent", stateReason: reason})
+	return ct, nil
+}
+
+func (c *Client) ReopenIssue(ctx context.Context, issueID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ReopenIssue"); err != nil {
+		return err
+	}
+	_, err := c.reopenIssue(issueID)
+	return err
+}
+
+func (c *Client) reopenIssue(issueID string) (*content, error) {
+	ct, ok := c.contents[issueID]
+	if !ok || ct.typeName != "Issue" {
+		return nil, notFound("issue", issueID)
+	}
+	ct.state = "OPEN"
+	ct.stateReason = "REOPENED"
+	ct.updatedAt = c.tick()
+	c.record(ct, ct.updatedAt, timelineEvent{typeName: "ReopenedEvent"})
+	return ct, nil
+}
+
+func (c *Client) GetIssueID(ctx context.Context, repositoryID string, number int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetIssueID"); err != nil {
+		return "", err
+	}
+
+	if ct := c.issue(repositoryID, number); ct != nil {
+		return ct.id, nil
+	}
+	return "", notFound("issue", fmt.Sprintf("%s#%d", repositoryID, number))
+}
+
+func (c *Client) AddSubIssue(ctx context.Context, issueID, subIssueID string, replaceParent bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "AddSubIssue"); err != nil {
+		return err
+	}
+	_, _, err := c.addSubIssue(issueID, subIssueID, replaceParent)
+	return err
+}
+
+// addSubIssue makes an issue a sub-issue of another, moving it from its
+// current parent when replaceParent is set
+func (c *Client) addSubIssue(issueID, subIssueID string, replaceParent bool) (*content, *content, error) {
+	parent, ok := c.contents[issueID]
+	if !ok || parent.typeName != "Issue" {
+		return nil, nil, notFound("issue", issueID)
+	}
+	child, ok := c.contents[subIssueID]
+	if !ok || child.typeName != "Issue" {
+		return nil, nil, notFound("issue", subIssueID)
+	}
+	if child.parentID == parent.id {
+		return nil, nil, apierrors.ValidationError("Issue may not contain duplicate sub-issues", nil)
+	}
+	if child.parentID != "" && !replaceParent {
+		return nil, nil, apierrors.ValidationError("Sub issue may only have one parent", nil)
+	}
+	for ancestor := parent; ancestor != nil; ancestor = c.contents[ancestor.parentID] {
+		if ancestor == child {
+			return nil, nil, apierrors.ValidationError("An issue can't be its own ancestor", nil)
+		}
+	}
+
+	if old := c.contents[child.parentID]; old != nil {
+		old.subIssues = slices.DeleteFunc(old.subIssues, func(id string) bool { return id == child.id })
+		old.updatedAt = c.tick()
+	}
+	child.parentID = parent.id
+	parent.subIssues = append(parent.subIssues, child.id)
+	parent.updatedAt = c.tick()
+	child.updatedAt = parent.updatedAt
+	return parent, child, nil
+}
+
+func (c *Client) RemoveSubIssue(ctx context.Context, issueID, subIssueID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "RemoveSubIssue"); err != nil {
+		return err
+	}
+	_, _, err := c.removeSubIssue(issueID, subIssueID)
+	return err
+}
+
+func (c *Client) removeSubIssue(issueID, subIssueID string) (*content, *content, error) {
+	parent, ok := c.contents[issueID]
+	if !ok || parent.typeName != "Issue" {
+		return nil, nil, notFound("issue", issueID)
+	}
+	child, ok := c.contents[subIssueID]
+	if !ok || child.parentID != parent.id {
+		return nil, nil, apierrors.ValidationError(fmt.Sprintf("%s is not a sub-issue of #%d", subIssueID, parent.number), nil)
+	}
+
+	child.parentID = ""
+	parent.subIssues = slices.DeleteFunc(parent.subIssues, func(id string) bool { return id == child.id })
+	parent.updatedAt = c.tick()
+	child.updatedAt = parent.updatedAt
+	return parent, child, nil
+}
+
+func (c *Client) AddBlockedBy(ctx context.Context, issueID, blockingIssueID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "AddBlockedBy"); err != nil {
+		return err
+	}
+	_, _, err := c.addBlockedBy(issueID, blockingIssueID)
+	return err
+}
+
+// addBlockedBy marks an issue as blocked by another
+func (c *Client) addBlockedBy(issueID, blockingIssueID string) (*content, *content, error) {
+	issue, ok := c.contents[issueID]
+	if !ok || issue.typeName != "Issue" {
+		return nil, nil, notFound("issue", issueID)
+	}
+	blocker, ok := c.contents[blockingIssueID]
+	if !ok || blocker.typeName != "Issue" {
+		return nil, nil, notFound("issue", blockingIssueID)
+	}
+	if issue == blocker {
+		return nil, nil, apierrors.ValidationError("An issue can't be blocked by itself", nil)
+	}
+	if slices.Contains(issue.blockedBy, blocker.id) {
+		return nil, nil, apierrors.ValidationError("Issue is already blocked by this issue", nil)
+	}
+
+	issue.blockedBy = append(issue.blockedBy, blocker.id)
+	blocker.blocking = append(blocker.blocking, issue.id)
+	issue.updatedAt = c.tick()
+	blocker.updatedAt = issue.updatedAt
+	return issue, blocker, nil
+}
+
+func (c *Client) RemoveBlockedBy(ctx context.Context, issueID, blockingIssueID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "RemoveBlockedBy"); err != nil {
+		return err
+	}
+	_, _, err := c.removeBlockedBy(issueID, blockingIssueID)
+	return err
+}
+
+func (c *Client) removeBlockedBy(issueID, blockingIssueID string) (*content, *content, error) {
+	issue, ok := c.contents[issueID]
+	if !ok || issue.typeName != "Issue" {
+		return nil, nil, notFound("issue", issueID)
+	}
+	blocker, ok := c.contents[blockingIssueID]
+	if !ok || !slices.Contains(issue.blockedBy, blockingIssueID) {
+		return nil, nil, apierrors.ValidationError(fmt.Sprintf("#%d is not blocked by %s", issue.number, blockingIssueID), nil)
+	}
+
+	issue.blockedBy = slices.DeleteFunc(issue.blockedBy, func(id string) bool { return id == blocker.id })
+	blocker.blocking = slices.DeleteFunc(blocker.blocking, func(id string) bool { return id == issue.id })
+	issue.updatedAt = c.tick()
+	blocker.updatedAt = issue.updatedAt
+	return issue, blocker, nil
+}
+
+// dependencySummary counts an issue's dependencies and the open blockers among them
+func (c *Client) dependencySummary(ct *content) models.DependencySummary {
+	summary := models.DependencySummary{TotalBlockedBy: len(ct.blockedBy), TotalBlocking: len(ct.blocking)}
+	for _, id := range ct.blockedBy {
+		if c.contents[id].state == "OPEN" {
+			summary.OpenBlockers++
+		}
+	}
+	return summary
+}
+
+// issueRef returns the short reference to an issue
+func (c *Client) issueRef(ct *content) models.IssueRef {
+	ref := models.IssueRef{ID: ct.id, Number: ct.number, Title: ct.title, State: ct.state, URL: ct.url}
+	if r := c.repository(ct.repositoryID); r != nil {
+		ref.Repository = r.Owner + "/" + r.Name
+	}
+	return ref
+}
+
+// subIssuesSummary counts an issue's sub-issues and the closed ones among them
+func (c *Client) subIssuesSummary(ct *content) models.SubIssuesSummary {
+	summary := models.SubIssuesSummary{Total: len(ct.subIssues)}
+	for _, id := range ct.subIssues {
+		if c.contents[id].state == "CLOSED" {
+			summary.Completed++
+		}
+	}
+	return summary
+}
+
+func (c *Client) GetPullRequestStatus(ctx context.Context, pullRequestID string) (string, *models.PullRequestStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetPullRequestStatus"); err != nil {
+		return "", nil, err
+	}
+
+	ct, ok := c.contents[pullRequestID]
+	if !ok || ct.typeName != "PullRequest" {
+		return "", nil, notFound("pull request", pullRequestID)
+	}
+	status := ct.pullRequest
+	status.Reviewers = append([]string(nil), status.Reviewers...)
+	return ct.state, &status, nil
+}
+
+func (c *Client) RequestReviews(ctx context.Context, pullRequestID string, userIDs, teamIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "RequestReviews"); err != nil {
+		return err
+	}
+	_, err := c.requestReviews(pullRequestID, userIDs, teamIDs)
+	return err
+}
+
+// requestReviews adds users and teams to a pull request's requested reviewers
+func (c *Client) requestReviews(pullRequestID string, userIDs, teamIDs []string) (*content, error) {
+	ct, err := c.openPullRequest(pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewers []string
+	for _, id := range userIDs {
+		login, ok := c.loginOf(id)
+		if !ok {
+			return nil, notFound("user", id)
+		}
+		reviewers = append(reviewers, login)
+	}
+	for _, id := range teamIDs {
+		team, ok := c.teamOf(id)
+		if !ok {
+			return nil, notFound("team", id)
+		}
+		reviewers = append(reviewers, team)
+	}
+	for _, reviewer := range reviewers {
+		if !slices.Contains(ct.pullRequest.Reviewers, reviewer) {
+			ct.pullRequest.Reviewers = append(ct.pullRequest.Reviewers, reviewer)
+		}
+	}
+	ct.updatedAt = c.tick()
+	return ct, nil
+}
+
+func (c *Client) SubmitReview(ctx context.Context, pullRequestID, event, body string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SubmitReview"); err != nil {
+		return err
+	}
+	_, err := c.submitReview(pullRequestID, event, body)
+	return err
+}
+
+// submitReview reviews a pull request as the viewer. Where reviews are
+// required, an approval or a request for changes becomes the review decision.
+func (c *Client) submitReview(pullRequestID, event, body string) (*content, error) {
+	ct, err := c.openPullRequest(pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	switch event {
+	case "APPROVE":
+	case "COMMENT", "REQUEST_CHANGES":
+		if strings.TrimSpace(body) == "" {
+			return nil, apierrors.ValidationError("Review body can't be blank", nil)
+		}
+	default:
+		return nil, apierrors.ValidationError(fmt.Sprintf("Unknown review event %q", event), nil)
+	}
+
+	if ct.pullRequest.ReviewDecision != "" {
+		switch event {
+		case "APPROVE":
+			ct.pullRequest.ReviewDecision = "APPROVED"
+		case "REQUEST_CHANGES":
+			ct.pullRequest.ReviewDecision = "CHANGES_REQUESTED"
+		}
+	}
+	ct.pullRequest.Reviewers = slices.DeleteFunc(ct.pullRequest.Reviewers, func(reviewer string) bool {
+		return reviewer == c.viewer
+	})
+	ct.updatedAt = c.tick()
+	return ct, nil
+}
+
+func (c *Client) MarkReadyForReview(ctx context.Context, pullRequestID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "MarkReadyForReview"); err != nil {
+		return err
+	}
+	_, err := c.markReadyForReview(pullRequestID)
+	return err
+}
+
+func (c *Client) markReadyForReview(pullRequestID string) (*content, error) {
+	ct, err := c.openPullRequest(pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	ct.pullRequest.IsDraft = false
+	ct.updatedAt = c.tick()
+	return ct, nil
+}
+
+func (c *Client) MergePullRequest(ctx context.Context, pullRequestID, method string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "MergePullRequest"); err != nil {
+		return err
+	}
+	_, err := c.mergePullRequest(pullRequestID, method)
+	return err
+}
+
+// mergePullRequest merges a pull request the way a protected branch allows:
+// a review decision counts as a required review, and checks must have passed
+func (c *Client) mergePullRequest(pullRequestID, method string) (*content, error) {
+	ct, err := c.openPullRequest(pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	switch method {
+	case "MERGE", "SQUASH", "REBASE":
+	default:
+		return nil, apierrors.ValidationError(fmt.Sprintf("Unknown merge method %q", method), nil)
+	}
+
+	status := ct.pullRequest
+	switch {
+	case status.IsDraft:
+		return nil, apierrors.ValidationError("Pull request Pull request is in draft state", nil)
+	case status.Mergeable == "CONFLICTING":
+		return nil, apierrors.ValidationError("Pull request Pull request is not mergeable", nil)
+	case status.ReviewDecision != "" && status.ReviewDecision != "APPROVED":
+		return nil, apierrors.BranchProtectionError("At least 1 approving review is required by reviewers with write access.")
+	case status.Checks == "FAILURE" || status.Checks == "ERROR":
+		return nil, apierrors.BranchProtectionError(`Required status check "ci" is failing.`)
+	case status.Checks == "PENDING" || status.Checks == "EXPECTED":
+		return nil, apierrors.BranchProtectionError(`Required status check "ci" is expected.`)
+	}
+
+	ct.state = "MERGED"
+	ct.updatedAt = c.tick()
+	c.record(ct, ct.updatedAt, timelineEvent{typeName: "MergedEvent"})
+	return ct, nil
+}
+
+// openPullRequest returns the pull request with the ID if it's still open
+func (c *Client) openPullRequest(pullRequestID string) (*content, error) {
+	ct, ok := c.contents[pullRequestID]
+	if !ok || ct.typeName != "PullRequest" {
+		return nil, notFound("pull request", pullRequestID)
+	}
+	if ct.state != "OPEN" {
+		return nil, apierrors.ValidationError(fmt.Sprintf("Pull request #%d is %s", ct.number, strings.ToLower(ct.state)), nil)
+	}
+	return ct, nil
+}
+
+// teamOf returns the "org/slug" of the team with the node ID
+func (c *Client) teamOf(id string) (string, bool) {
+	for _, o := range c.orgs {
+		for _, team := range o.teams {
+			if team.ID == id {
+				return o.login + "/" + team.Slug, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (c *Client) ListRepositoryLabels(ctx context.Context, repositoryID string) ([]models.Label, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListRepositoryLabels"); err != nil {
+		return nil, err
+	}
+
+	r := c.repository(repositoryID)
+	if r == nil {
+		return nil, notFound("repository", repositoryID)
+	}
+	return r.sortedLabels(), nil
+}
+
+func (c *Client) ListRepositoryMilestones(ctx context.Context, repositoryID string) ([]models.Milestone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListRepositoryMilestones"); err != nil {
+		return nil, err
+	}
+
+	r := c.repository(repositoryID)
+	if r == nil {
+		return nil, notFound("repository", repositoryID)
+	}
+	return r.sortedMilestones(), nil
+}
+
+func (c *Client) AddLabels(ctx context.Context, labelableID string, labelIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "AddLabels"); err != nil {
+		return err
+	}
+	_, err := c.changeLabels(labelableID, labelIDs, true)
+	return err
+}
+
+func (c *Client) RemoveLabels(ctx context.Context, labelableID string, labelIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "RemoveLabels"); err != nil {
+		return err
+	}
+	_, err := c.changeLabels(labelableID, labelIDs, false)
+	return err
+}
+
+// changeLabels adds or removes labels of an issue or pull request. The labels
+// must belong to its repository.
+func (c *Client) changeLabels(labelableID string, labelIDs []string, add bool) (*content, error) {
+	ct, ok := c.contents[labelableID]
+	if !ok || ct.typeName == "DraftIssue" {
+		return nil, notFound("issue or pull request", labelableID)
+	}
+
+	r := c.repository(ct.repositoryID)
+	now := c.tick()
+	for _, id := range labelIDs {
+		label := r.label(id)
+		if label == nil {
+			return nil, notFound("label", id)
+		}
+		index := slices.Index(ct.labels, id)
+		switch {
+		case add && index < 0:
+			ct.labels = append(ct.labels, id)
+			c.record(ct, now, timelineEvent{typeName: "LabeledEvent", label: label.Name})
+		case !add && index >= 0:
+			ct.labels = slices.Delete(ct.labels, index, index+1)
+			c.record(ct, now, timelineEvent{typeName: "UnlabeledEvent", label: label.Name})
+		}
+	}
+	ct.updatedAt = now
+	return ct, nil
+}
+
+func (c *Client) SetIssueMilestone(ctx context.Context, issueID, milestoneID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SetIssueMilestone"); err != nil {
+		return err
+	}
+	_, err := c.setMilestone("Issue", issueID, milestoneID)
+	return err
+}
+
+func (c *Client) SetPullRequestMilestone(ctx context.Context, pullRequestID, milestoneID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SetPullRequestMilestone"); err != nil {
+		return err
+	}
+	_, err := c.setMilestone("PullRequest", pullRequestID, milestoneID)
+	return err
+}
+
+// setMilestone puts an issue or pull request in one of its repository's
+// milestones, or takes it out of its milestone when milestoneID is empty
+func (c *Client) setMilestone(typeName, id, milestoneID string) (*content, error) {
+	ct, ok := c.contents[id]
+	if !ok || ct.typeName != typeName {
+		return nil, notFound(typeName, id)
+	}
+	r := c.repository(ct.repositoryID)
+	if milestoneID != "" && r.milestone(milestoneID) == nil {
+		return nil, notFound("milestone", milestoneID)
+	}
+	ct.updatedAt = c.tick()
+	if milestoneID != ct.milestoneID {
+		if old := r.milestone(ct.milestoneID); old != nil {
+			c.record(ct, ct.updatedAt, timelineEvent{typeName: "DemilestonedEvent", milestone: old.Title})
+		}
+		if milestone := r.milestone(milestoneID); milestone != nil {
+			c.record(ct, ct.updatedAt, timelineEvent{typeName: "MilestonedEvent", milestone: milestone.Title})
+		}
+	}
+	ct.milestoneID = milestoneID
+	return ct, nil
+}
+
+func (c *Client) ListProjectFields(ctx context.Context, projectID string) ([]models.ProjectField, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListProjectFields"); err != nil {
+		return nil, err
+	}
+
+	p := c.project(projectID)
+	if p == nil {
+		return nil, notFound("project", projectID)
+	}
+	return append([]models.ProjectField{}, p.fields...), nil
+}
+
+func (c *Client) ListComments(ctx context.Context, contentID string, first int, after string) ([]models.Comment, models.PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListComments"); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	ct, ok := c.contents[contentID]
+	if !ok {
+		return nil, models.PageInfo{}, notFound("item", contentID)
+	}
+	comments, page := commentPage(ct.comments, first, after)
+	return comments, page, nil
+}
+
+func (c *Client) ListTimeline(ctx context.Context, contentID string, itemTypes []string, first int, after string) ([]models.TimelineEvent, models.PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListTimeline"); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	ct, ok := c.contents[contentID]
+	if !ok {
+		return nil, models.PageInfo{}, notFound("item", contentID)
+	}
+	events, page := pageOf(timelineOf(ct, itemTypes), first, after)
+	timeline := make([]models.TimelineEvent, len(events))
+	for i, event := range events {
+		timeline[i] = event.model()
+	}
+	return timeline, page, nil
+}
+
+// record adds an event done by the viewer to an issue's or pull request's
+// timeline. Drafts have no timeline.
+func (c *Client) record(ct *content, at time.Time, event timelineEvent) {
+	if ct.typeName == "DraftIssue" {
+		return
+	}
+	event.actor = c.viewer
+	event.createdAt = at
+	ct.timeline = append(ct.timeline, event)
+}
+
+// timelineOf returns the events in an item's timeline of the given item
+// types, or all of them when no types are given
+func timelineOf(ct *content, itemTypes []string) []timelineEvent {
+	if len(itemTypes) == 0 {
+		return ct.timeline
+	}
+	var events []timelineEvent
+	for _, event := range ct.timeline {
+		if slices.Contains(itemTypes, itemType(event.typeName)) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// itemType is the timeline item type enum value of a GraphQL event type,
+// e.g. "LABELED_EVENT" for "LabeledEvent"
+func itemType(typeName string) string {
+	var b strings.Builder
+	for i, r := range typeName {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// model describes an event the way api.Client does from the GraphQL response
+func (e timelineEvent) model() models.TimelineEvent {
+	var summary string
+	switch e.typeName {
+	case "LabeledEvent":
+		summary = fmt.Sprintf("added label %q", e.label)
+	case "UnlabeledEvent":
+		summary = fmt.Sprintf("removed label %q", e.label)
+	case "AssignedEvent":
+		summary = "assigned @" + e.assignee
+	case "UnassignedEvent":
+		summary = "unassigned @" + e.assignee
+	case "CrossReferencedEvent":
+		summary = fmt.Sprintf("referenced this from #%d %s", e.source.number, e.source.title)
+	case "ClosedEvent":
+		summary = "closed this"
+		if e.stateReason != "" {
+			summary += " as " + e.stateReason
+		}
+	case "ReopenedEvent":
+		summary = "reopened this"
+	case "RenamedTitleEvent":
+		summary = fmt.Sprintf("changed the title from %q to %q", e.previousTitle, e.currentTitle)
+	case "MergedEvent":
+		summary = "merged this"
+	case "MilestonedEvent":
+		summary = fmt.Sprintf("added this to the %s milestone", e.milestone)
+	case "DemilestonedEvent":
+		summary = fmt.Sprintf("removed this from the %s milestone", e.milestone)
+	case "AddedToProjectV2Event":
+		summary = fmt.Sprintf("added this to %s", e.project)
+	case "RemovedFromProjectV2Event":
+		summary = fmt.Sprintf("removed this from %s", e.project)
+	case "ProjectV2ItemStatusChangedEvent":
+		previous, status := e.previousStatus, e.status
+		if previous == "" {
+			previous = "No Status"
+		}
+		if status == "" {
+			status = "No Status"
+		}
+		summary = fmt.Sprintf("moved this from %s to %s in %s", previous, status, e.project)
+	}
+	return models.TimelineEvent{Type: e.typeName, Actor: e.actor, CreatedAt: e.createdAt, Summary: summary}
+}
+
+func (c *Client) AddComment(ctx context.Context, subjectID, body string) (*models.Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "AddComment"); err != nil {
+		return nil, err
+	}
+	return c.addComment(subjectID, body)
+}
+
+func (c *Client) addComment(subjectID, body string) (*models.Comment, error) {
+	ct, ok := c.contents[subjectID]
+	if !ok || ct.typeName == "DraftIssue" {
+		return nil, notFound("issue or pull request", subjectID)
+	}
+	comment := models.Comment{
+		ID:        c.newID("IC"),
+		Author:    c.viewer,
+		Body:      body,
+		CreatedAt: c.tick(),
+		CanUpdate: true,
+		CanDelete: true,
+	}
+	ct.comments = append(ct.comments, comment)
+	ct.updatedAt = comment.CreatedAt
+	return &comment, nil
+}
+
+func (c *Client) UpdateComment(ctx context.Context, commentID, body string) (*models.Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "UpdateComment"); err != nil {
+		return nil, err
+	}
+
+	for _, ct := range c.contents {
+		for i := range ct.comments {
+			if ct.comments[i].ID == commentID {
+				ct.comments[i].Body = body
+				ct.updatedAt = c.tick()
+				comment := ct.comments[i]
+				return &comment, nil
+			}
+		}
+	}
+	return nil, notFound("comment", commentID)
+}
+
+func (c *Client) DeleteComment(ctx context.Context, commentID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "DeleteComment"); err != nil {
+		return err
+	}
+
+	for _, ct := range c.contents {
+		for i := range ct.comments {
+			if ct.comments[i].ID == commentID {
+				ct.comments = append(ct.comments[:i], ct.comments[i+1:]...)
+				ct.updatedAt = c.tick()
+				return nil
+			}
+		}
+	}
+	return notFound("comment", commentID)
+}
+
+func (c *Client) ListRepositories(ctx context.Context, owner string, isUser bool) ([]models.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListRepositories"); err != nil {
+		return nil, err
+	}
+
+	repositories := []models.Repository{}
+	for _, r := range c.repositories {
+		if r.Owner == owner {
+			repositories = append(repositories, r.Repository)
+		}
+	}
+	return repositories, nil
+}
+
+func (c *Client) GetRepositoryNodeID(ctx context.Context, owner, name string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetRepositoryNodeID"); err != nil {
+		return "", err
+	}
+
+	for _, r := range c.repositories {
+		if r.Owner == owner && r.Name == name {
+			return r.ID, nil
+		}
+	}
+	return "", notFound("repository", owner+"/"+name)
+}
+
+// --- Helpers; callers hold c.mu ---
+
+// call records a method call and returns the failure queued for it, if any
+func (c *Client) call(ctx context.Context, method string) error {
+	c.calls = append(c.calls, method)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if queued := c.failures[method]; len(queued) > 0 {
+		c.failures[method] = queued[1:]
+		return queued[0]
+	}
+	return nil
+}
+
+// tick advances the clock and returns the new time
+func (c *Client) tick() time.Time {
+	c.now = c.now.Add(time.Minute)
+	return c.now
+}
+
+// newID returns a deterministic node ID with the given prefix
+func (c *Client) newID(prefix string) string {
+	c.nextID++
+	return prefix + "_" + strconv.Itoa(c.nextID)
+}
+
+func (c *Client) newProject(ownerID string, owner models.ProjectOwner, title string) *project {
+	number := 1
+	for _, p := range c.projects {
+		if p.Owner.Login == owner.Login && p.Number >= number {
+			number = p.Number + 1
+		}
+	}
+	now := c.tick()
+	p := &project{
+		Project: models.Project{
+			ID:        c.newID("PVT"),
+			Number:    number,
+			Title:     title,
+			URL:       fmt.Sprintf("https://github.com/%s/projects/%d", owner.Login, number),
+			CreatedAt: now,
+			UpdatedAt: now,
+			Owner:     owner,
+		},
+		ownerID: ownerID,
+	}
+	c.projects = append(c.projects, p)
+	return p
+}
+
+// newContent creates an issue or pull request. They share the repository's numbering.
+func (c *Client) newContent(r *repository, typeName, title, body string, assignees []string) *content {
+	r.issues++
+	now := c.tick()
+	prefix, path := "I", "issues"
+	if typeName == "PullRequest" {
+		prefix, path = "PR", "pull"
+	}
+	ct := &content{
+		id:        c.newID(prefix),
+		typeName:  typeName,
+		title:     title,
+		body:      body,
+		number:    r.issues,
+		state:     "OPEN",
+		url:       fmt.Sprintf("https://github.com/%s/%s/%s/%d", r.Owner, r.Name, path, r.issues),
+		assignees: append([]string(nil), assignees...),
+		createdAt: now,
+		updatedAt: now,
+
+		repositoryID: r.ID,
+	}
+	if typeName == "PullRequest" {
+		ct.pullRequest = models.PullRequestStatus{
+			ReviewDecision: "REVIEW_REQUIRED",
+			Mergeable:      "MERGEABLE",
+			HeadRef:        fmt.Sprintf("feature-%d", ct.number),
+			BaseRef:        "main",
+		}
+	}
+	c.contents[ct.id] = ct
+	return ct
+}
+
+// ownerOf returns the node ID and type of a user or organization login
+func (c *Client) ownerOf(login string) (string, string) {
+	if o, ok := c.orgs[login]; ok {
+		return o.id, "Organization"
+	}
+	if id, ok := c.users[login]; ok {
+		return id, "User"
+	}
+	panic("fake: unknown owner " + login)
+}
+
+func (c *Client) ownerByID(id string) (models.ProjectOwner, bool) {
+	for _, o := range c.orgs {
+		if o.id == id {
+			return models.ProjectOwner{Login: o.login, Type: "Organization"}, true
+		}
+	}
+	if login, ok := c.loginOf(id); ok {
+		return models.ProjectOwner{Login: login, Type: "User"}, true
+	}
+	return models.ProjectOwner{}, false
+}
+
+func (c *Client) loginOf(userID string) (string, bool) {
+	for login, id := range c.users {
+		if id == userID {
+			return login, true
+		}
+	}
+	return "", false
+}
+
+func (c *Client) projectsOf(owner, ownerType string, first int) []models.Project {
+	projects := []models.Project{}
+	for _, p := range c.projects {
+		if len(projects) == first {
+			break
+		}
+		if p.Owner.Login == owner && p.Owner.Type == ownerType {
+			result := p.Project
+			result.ItemCount = len(p.items)
+			projects = append(projects, result)
+		}
+	}
+	return projects
+}
+
+func (c *Client) project(id string) *project {
+	for _, p := range c.projects {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (c *Client) item(id string) *item {
+	for _, p := range c.projects {
+		for _, it := range p.items {
+			if it.id == id {
+				return it
+			}
+		}
+	}
+	return nil
+}
+
+// issue finds an issue by its number in a repository
+func (c *Client) issue(repositoryID string, number int) *content {
+	for _, ct := range c.contents {
+		if ct.typeName == "Issue" && ct.repositoryID == repositoryID && ct.number == number {
+			return ct
+		}
+	}
+	return nil
+}
+
+func (c *Client) repository(id string) *repository {
+	for _, r := range c.repositories {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// itemModel converts an item to the row-level model ListProjectItems returns
+func (c *Client) itemModel(it *item) models.ProjectItem {
+	ct := c.contents[it.contentID]
+	model := models.ProjectItem{
+		ID:          it.id,
+		ContentID:   ct.id,
+		Type:        ct.typeName,
+		Title:       ct.title,
+		Number:      ct.number,
+		State:       ct.state,
+		StateReason: ct.stateReason,
+		URL:         ct.url,
+		CreatedAt:   ct.createdAt,
+		UpdatedAt:   ct.updatedAt,
+		Assignees:   append([]string{}, ct.assignees...),
+		Fields:      make(map[string]interface{}),
+
+		RepositoryID: ct.repositoryID,
+	}
+	if r := c.repository(ct.repositoryID); r != nil {
+		for _, id := range ct.labels {
+			model.Labels = append(model.Labels, *r.label(id))
+		}
+		if milestone := r.milestone(ct.milestoneID); milestone != nil {
+			model.Milestone = milestone
+		}
+	}
+	if ct.typeName == "PullRequest" {
+		status := ct.pullRequest
+		status.Reviewers = append([]string(nil), status.Reviewers...)
+		model.PullRequest = &status
+	}
+	if parent := c.contents[ct.parentID]; parent != nil {
+		ref := c.issueRef(parent)
+		model.Parent = &ref
+	}
+	for _, id := range ct.subIssues {
+		model.SubIssues = append(model.SubIssues, c.issueRef(c.contents[id]))
+	}
+	model.SubIssuesSummary = c.subIssuesSummary(ct)
+	for _, id := range ct.blockedBy {
+		model.BlockedBy = append(model.BlockedBy, c.issueRef(c.contents[id]))
+	}
+	for _, id := range ct.blocking {
+		model.Blocking = append(model.Blocking, c.issueRef(c.contents[id]))
+	}
+	model.Dependencies = c.dependencySummary(ct)
+	return model
+}
+
+// sortedLabels returns the repository's labels sorted by name, as GitHub lists them
+func (r *repository) sortedLabels() []models.Label {
+	labels := append([]models.Label{}, r.labels...)
+	sort.SliceStable(labels, func(i, j int) bool {
+		return strings.ToLower(labels[i].Name) < strings.ToLower(labels[j].Name)
+	})
+	return labels
+}
+
+// sortedMilestones returns the repository's milestones soonest due first,
+// with those without a due date last
+func (r *repository) sortedMilestones() []models.Milestone {
+	milestones := append([]models.Milestone{}, r.milestones...)
+	sort.SliceStable(milestones, func(i, j int) bool {
+		a, b := milestones[i].DueOn, milestones[j].DueOn
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+	return milestones
+}
+
+func (r *repository) label(id string) *models.Label {
+	for i := range r.labels {
+		if r.labels[i].ID == id {
+			return &r.labels[i]
+		}
+	}
+	return nil
+}
+
+func (r *repository) milestone(id string) *models.Milestone {
+	for i := range r.milestones {
+		if r.milestones[i].ID == id {
+			milestone := r.milestones[i]
+			return &milestone
+		}
+	}
+	return nil
+}
+
+// commentPage returns up to first comments after the cursor, which is the
+// index of the last comment of the previous page
+func commentPage(comments []models.Comment, first int, after string) ([]models.Comment, models.PageInfo) {
+	return pageOf(comments, first, after)
+}
+
+// pageOf returns up to first values after the cursor, which is the index of
+// the last value of the previous page
+func pageOf[T any](values []T, first int, after string) ([]T, models.PageInfo) {
+	start := 0
+	if after != "" {
+		if index, err := strconv.Atoi(after); err == nil {
+			start = index + 1
+		}
+	}
+	if start > len(values) {
+		start = len(values)
+	}
+	end := start + first
+	if end > len(values) {
+		end = len(values)
+	}
+
+	page := models.PageInfo{HasNextPage: end < len(values)}
+	if end > start {
+		page.EndCursor = strconv.Itoa(end - 1)
+	}
+	return append([]T{}, values[start:end]...), page
+}
+
+// matching returns up to limit of the values containing query, sorted
+func matching(values []string, query string, limit int) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+
+	out := []string{}
+	for _, value := range sorted {
+		if len(out) == limit {
+			break
+		}
+		if contains(value, query) {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+func contains(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// notFound returns the error GitHub gives for an unknown node
+func notFound(kind, id string) error {
+	return notFoundError(fmt.Sprintf("Could not resolve to a %s with the id of '%s'.", kind, id))
+}
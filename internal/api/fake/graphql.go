@@ -0,0 +1,291 @@
+package fake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// operation is a parsed GraphQL request: a query or mutation and its
+// top-level selections, with variables already substituted into arguments
+type operation struct {
+	kind       string // "query" or "mutation"
+	selections []selection
+}
+
+// selection is a field, or an inline fragment when typeCondition is set
+type selection struct {
+	alias         string
+	name          string
+	args          map[string]interface{}
+	selections    []selection
+	typeCondition string
+}
+
+// key is the name the selection's value is returned under
+func (s selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// parseOperation parses the subset of GraphQL the client sends: a single
+// anonymous or named operation with fields, arguments and inline fragments.
+// Named fragments and directives are not supported.
+func parseOperation(document string, variables map[string]interface{}) (*operation, error) {
+	p := &parser{tokens: tokenize(document), variables: variables}
+
+	op := &operation{kind: "query"}
+	if p.peek() == "query" || p.peek() == "mutation" {
+		op.kind = p.next()
+		if isName(p.peek()) {
+			p.next()
+		}
+		if p.peek() == "(" {
+			// Variable definitions only matter to a real schema; values come from variables
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != "" {
+		return nil, fmt.Errorf("unexpected %q after the operation", p.peek())
+	}
+	op.selections = selections
+	return op, nil
+}
+
+type parser struct {
+	tokens    []string
+	pos       int
+	variables map[string]interface{}
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *parser) expect(token string) error {
+	if got := p.next(); got != token {
+		return fmt.Errorf("expected %q, got %q", token, got)
+	}
+	return nil
+}
+
+func (p *parser) skipBalanced(open, close string) error {
+	depth := 0
+	for {
+		switch p.next() {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		case "":
+			return fmt.Errorf("unterminated %q", open)
+		}
+	}
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var selections []selection
+	for p.peek() != "}" {
+		if p.peek() == "" {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+
+		if p.peek() == "..." {
+			p.next()
+			if err := p.expect("on"); err != nil {
+				return nil, fmt.Errorf("only inline fragments are supported: %w", err)
+			}
+			typeName := p.next()
+			inner, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			selections = append(selections, selection{typeCondition: typeName, selections: inner})
+			continue
+		}
+
+		sel := selection{name: p.next()}
+		if !isName(sel.name) {
+			return nil, fmt.Errorf("unexpected %q in selection set", sel.name)
+		}
+		if p.peek() == ":" {
+			p.next()
+			sel.alias, sel.name = sel.name, p.next()
+		}
+		if p.peek() == "(" {
+			args, err := p.arguments()
+			if err != nil {
+				return nil, err
+			}
+			sel.args = args
+		}
+		if p.peek() == "{" {
+			inner, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			sel.selections = inner
+		}
+		selections = append(selections, sel)
+	}
+	p.next()
+	return selections, nil
+}
+
+func (p *parser) arguments() (map[string]interface{}, error) {
+	p.next()
+	args := make(map[string]interface{})
+	for p.peek() != ")" {
+		name := p.next()
+		if !isName(name) {
+			return nil, fmt.Errorf("unexpected %q in arguments", name)
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+	}
+	p.next()
+	return args, nil
+}
+
+func (p *parser) value() (interface{}, error) {
+	token := p.next()
+	switch {
+	case token == "$":
+		return p.variables[p.next()], nil
+	case token == "[":
+		list := []interface{}{}
+		for p.peek() != "]" {
+			if p.peek() == "" {
+				return nil, fmt.Errorf("unterminated list")
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		p.next()
+		return list, nil
+	case token == "{":
+		object := make(map[string]interface{})
+		for p.peek() != "}" {
+			name := p.next()
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			object[name] = value
+		}
+		p.next()
+		return object, nil
+	case strings.HasPrefix(token, `"`):
+		return strconv.Unquote(token)
+	case token == "true", token == "false":
+		return token == "true", nil
+	case token == "null":
+		return nil, nil
+	case token != "" && (token[0] == '-' || unicode.IsDigit(rune(token[0]))):
+		// Numbers are float64, as they are when variables are decoded from JSON
+		return strconv.ParseFloat(token, 64)
+	case isName(token):
+		// Enum values such as DESC
+		return token, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q in value", token)
+	}
+}
+
+// tokenize splits a GraphQL document into punctuators, names, numbers and
+// quoted strings. Commas, whitespace and comments are dropped.
+func tokenize(document string) []string {
+	var tokens []string
+	for i := 0; i < len(document); {
+		ch := document[i]
+		switch {
+		case ch == ',' || unicode.IsSpace(rune(ch)):
+			i++
+		case ch == '#':
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(document[i:], "..."):
+			tokens = append(tokens, "...")
+			i += 3
+		case strings.ContainsRune("{}()[]:!$=@", rune(ch)):
+			tokens = append(tokens, string(ch))
+			i++
+		case ch == '"':
+			j := i + 1
+			for j < len(document) && document[j] != '"' {
+				if document[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, document[i:min(j+1, len(document))])
+			i = j + 1
+		default:
+			j := i
+			for j < len(document) && isNameChar(document[j]) {
+				j++
+			}
+			if j == i {
+				j++
+			}
+			tokens = append(tokens, document[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+func isName(token string) bool {
+	if token == "" || unicode.IsDigit(rune(token[0])) || token[0] == '-' {
+		return false
+	}
+	for i := 0; i < len(token); i++ {
+		if !isNameChar(token[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNameChar(ch byte) bool {
+	return ch == '_' || ch == '-' || ch == '.' || unicode.IsLetter(rune(ch)) || unicode.IsDigit(rune(ch))
+}
@@ -0,0 +1,1428 @@
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// maxPageSize is the largest first or last GitHub accepts on a connection
+const maxPageSize = 100
+
+// Server serves a Client's data over GitHub's GraphQL API, so the real
+// api.Client's query strings and response decoding can be exercised without
+// GitHub. It understands the queries and mutations for projects, items,
+// drafts, conversion, fields, comments, repositories, users and members;
+// anything else is answered with a GraphQL error naming the field.
+type Server struct {
+	backend *Client
+	server  *httptest.Server
+
+	mu       sync.Mutex
+	failures map[string][]Failure
+	requests []string
+}
+
+// Failure is an error the server returns instead of running a request
+type Failure struct {
+	Status  int         // HTTP status; 0 or 200 reports Type and Message as a GraphQL error
+	Type    string      // GraphQL error type, e.g. "NOT_FOUND", "FORBIDDEN" or "RATE_LIMITED"
+	Message string      // Defaults to the status text
+	Header  http.Header // Extra response headers, e.g. Retry-After
+}
+
+// NewServer starts a TLS server answering GraphQL requests from backend's
+// data. Close it when done.
+func NewServer(backend *Client) *Server {
+	s := &Server{backend: backend, failures: make(map[string][]Failure)}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Host returns the host:port to pass as api.ClientOptions.Host
+func (s *Server) Host() string {
+	return s.server.Listener.Addr().String()
+}
+
+// NewAPIClient returns a real api.Client that sends its requests to the server
+func (s *Server) NewAPIClient() (*api.Client, error) {
+	return api.NewClientWithOptions(api.ClientOptions{
+		Host:      s.Host(),
+		AuthToken: "fake",
+		Transport: s.server.Client().Transport,
+	})
+}
+
+// FailNext makes the next request selecting the top-level field (e.g. "node"
+// or "addProjectV2DraftIssue") fail. Calling it again queues further failures.
+func (s *Server) FailNext(field string, failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[field] = append(s.failures[field], failure)
+}
+
+// Requests returns the top-level fields of the requests served so far, in
+// order, e.g. "query node" or "mutation deleteProjectV2Item"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// graphQLError is an entry of a response's errors list
+type graphQLError struct {
+	Type    string        `json:"type,omitempty"`
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/api/graphql" {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+
+	var request struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "Problems parsing JSON"})
+		return
+	}
+
+	op, err := parseOperation(request.Query, request.Variables)
+	if err != nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"errors": []graphQLError{{Message: "Parse error: " + err.Error()}},
+		})
+		return
+	}
+
+	if failure, field, ok := s.takeFailure(op); ok {
+		s.writeFailure(w, failure, field)
+		return
+	}
+
+	s.backend.mu.Lock()
+	root := s.queryRoot()
+	if op.kind == "mutation" {
+		root = s.mutationRoot()
+	}
+	s.spendRateLimit()
+	var errs []graphQLError
+	data := s.execute(root, op.selections, nil, &errs)
+	s.setRateLimitHeaders(w.Header())
+	s.backend.mu.Unlock()
+
+	response := map[string]interface{}{"data": data}
+	if len(errs) > 0 {
+		response["errors"] = errs
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// takeFailure records the request and pops the failure queued for its first
+// top-level field that has one
+func (s *Server) takeFailure(op *operation) (Failure, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sel := range op.selections {
+		if sel.name == "rateLimit" {
+			continue
+		}
+		s.requests = append(s.requests, op.kind+" "+sel.name)
+	}
+	for _, sel := range op.selections {
+		if queued := s.failures[sel.name]; len(queued) > 0 {
+			s.failures[sel.name] = queued[1:]
+			return queued[0], sel.key(), true
+		}
+	}
+	return Failure{}, "", false
+}
+
+func (s *Server) writeFailure(w http.ResponseWriter, failure Failure, field string) {
+	for name, values := range failure.Header {
+		w.Header()[name] = values
+	}
+
+	status := failure.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	message := failure.Message
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	if status != http.StatusOK {
+		writeJSON(w, status, map[string]string{"message": message})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":   map[string]interface{}{field: nil},
+		"errors": []graphQLError{{Type: failure.Type, Message: message, Path: []interface{}{field}}},
+	})
+}
+
+// spendRateLimit charges a request one point of the backend's budget
+func (s *Server) spendRateLimit() {
+	rl := &s.backend.rateLimit
+	if rl.Remaining > 0 {
+		rl.Remaining--
+	}
+	rl.Used++
+	rl.Cost = 1
+}
+
+func (s *Server) setRateLimitHeaders(header http.Header) {
+	rl := s.backend.rateLimit
+	header.Set("X-RateLimit-Limit", strconv.Itoa(rl.Limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(rl.Remaining))
+	header.Set("X-RateLimit-Used", strconv.Itoa(rl.Used))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(rl.ResetAt.Unix(), 10))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// --- Execution ---
+
+// object is a GraphQL object: its type name, the interfaces it implements
+// for fragment matching, and a resolver for its fields
+type object struct {
+	typename   string
+	interfaces []string
+	resolve    func(field string, args map[string]interface{}) (interface{}, error)
+}
+
+// errUnknownField is returned by resolvers for fields their type doesn't have
+var errUnknownField = errors.New("unknown field")
+
+// execute resolves selections against obj. Failed fields are null in the
+// result and reported in errs, as GitHub does.
+func (s *Server) execute(obj *object, selections []selection, path []interface{}, errs *[]graphQLError) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, sel := range selections {
+		if sel.typeCondition != "" {
+			if obj.is(sel.typeCondition) {
+				for key, value := range s.execute(obj, sel.selections, path, errs) {
+					result[key] = value
+				}
+			}
+			continue
+		}
+
+		fieldPath := append(append([]interface{}(nil), path...), sel.key())
+		if sel.name == "__typename" {
+			result[sel.key()] = obj.typename
+			continue
+		}
+
+		value, err := obj.resolve(sel.name, sel.args)
+		if errors.Is(err, errUnknownField) {
+			err = fmt.Errorf("Field '%s' doesn't exist on type '%s'", sel.name, obj.typename)
+		}
+		if err == nil {
+			value, err = s.complete(value, sel, fieldPath, errs)
+		}
+		if err != nil {
+			*errs = append(*errs, graphQLError{Type: graphQLType(err), Message: err.Error(), Path: fieldPath})
+			value = nil
+		}
+		result[sel.key()] = value
+	}
+	return result
+}
+
+// complete turns a resolved value into JSON, executing sub-selections on objects
+func (s *Server) complete(value interface{}, sel selection, path []interface{}, errs *[]graphQLError) (interface{}, error) {
+	if value == nil {
+		// A nullable field such as an issue's milestone
+		return nil, nil
+	}
+	switch v := value.(type) {
+	case *object:
+		if v == nil {
+			return nil, nil
+		}
+		if len(sel.selections) == 0 {
+			return nil, fmt.Errorf("Field '%s' of type '%s' must have a selection of subfields", sel.name, v.typename)
+		}
+		return s.execute(v, sel.selections, path, errs), nil
+	case []*object:
+		if len(sel.selections) == 0 {
+			return nil, fmt.Errorf("Field '%s' must have a selection of subfields", sel.name)
+		}
+		list := make([]interface{}, len(v))
+		for i, element := range v {
+			list[i] = s.execute(element, sel.selections, append(path, i), errs)
+		}
+		return list, nil
+	}
+
+	if len(sel.selections) > 0 {
+		return nil, fmt.Errorf("Selections can't be made on scalars (field '%s')", sel.name)
+	}
+	if t, ok := value.(time.Time); ok {
+		if t.IsZero() {
+			return nil, nil
+		}
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	return value, nil
+}
+
+func (o *object) is(typeName string) bool {
+	if o.typename == typeName {
+		return true
+	}
+	for _, name := range o.interfaces {
+		if name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// graphQLType returns the GitHub error type for a backend error
+func graphQLType(err error) string {
+	var apiErr *apierrors.APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	if apiErr.GraphQLType != "" {
+		return apiErr.GraphQLType
+	}
+	switch apiErr.Type {
+	case apierrors.ErrorTypeValidation, apierrors.ErrorTypeBranchProtection:
+		return "UNPROCESSABLE"
+	case apierrors.ErrorTypePermission:
+		return "FORBIDDEN"
+	case apierrors.ErrorTypeRateLimit:
+		return "RATE_LIMITED"
+	default:
+		return "INTERNAL"
+	}
+}
+
+// connection pages nodes by the first/after or last/before arguments. GitHub
+// requires one of first or last unless only totalCount is selected.
+func connection(typename string, nodes []*object, args map[string]interface{}) (*object, error) {
+	first, hasFirst := intArg(args, "first")
+	last, hasLast := intArg(args, "last")
+	var unpaginated error
+	switch {
+	case !hasFirst && !hasLast:
+		unpaginated = fmt.Errorf("You must provide a `first` or `last` value to properly paginate the `%s` connection.", typename)
+	case first > maxPageSize || last > maxPageSize:
+		return nil, fmt.Errorf("Requesting %d records on the `%s` connection exceeds the limit of %d records.", max(first, last), typename, maxPageSize)
+	}
+
+	start, end := 0, len(nodes)
+	if after, ok := args["after"].(string); ok && after != "" {
+		index, err := decodeCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		start = min(index+1, len(nodes))
+	}
+	if before, ok := args["before"].(string); ok && before != "" {
+		index, err := decodeCursor(before)
+		if err != nil {
+			return nil, err
+		}
+		end = max(min(index, end), start)
+	}
+	if hasFirst && end-start > first {
+		end = start + first
+	}
+	if hasLast && end-start > last {
+		start = end - last
+	}
+
+	page := nodes[start:end]
+	return &object{typename: typename, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		if field == "totalCount" {
+			return len(nodes), nil
+		}
+		if unpaginated != nil {
+			return nil, unpaginated
+		}
+		switch field {
+		case "nodes":
+			return page, nil
+		case "edges":
+			edges := make([]*object, len(page))
+			for i, node := range page {
+				cursor, node := encodeCursor(start+i), node
+				edges[i] = &object{typename: strings.TrimSuffix(typename, "Connection") + "Edge", resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+					switch field {
+					case "cursor":
+						return cursor, nil
+					case "node":
+						return node, nil
+					}
+					return nil, errUnknownField
+				}}
+			}
+			return edges, nil
+		case "pageInfo":
+			return &object{typename: "PageInfo", resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+				switch field {
+				case "hasNextPage":
+					return end < len(nodes), nil
+				case "hasPreviousPage":
+					return start > 0, nil
+				case "startCursor":
+					if len(page) == 0 {
+						return nil, nil
+					}
+					return encodeCursor(start), nil
+				case "endCursor":
+					if len(page) == 0 {
+						return nil, nil
+					}
+					return encodeCursor(end - 1), nil
+				}
+				return nil, errUnknownField
+			}}, nil
+		}
+		return nil, errUnknownField
+	}}, nil
+}
+
+func encodeCursor(index int) string {
+	return base64.StdEncoding.EncodeToString([]byte("cursor:" + strconv.Itoa(index)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	data, err := base64.StdEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(data), "cursor:") {
+		if index, err := strconv.Atoi(strings.TrimPrefix(string(data), "cursor:")); err == nil {
+			return index, nil
+		}
+	}
+	return 0, fmt.Errorf("`%s` does not appear to be a valid cursor.", cursor)
+}
+
+// intArg reads an integer argument, which arrives as a float64 from JSON variables
+func intArg(args map[string]interface{}, name string) (int, bool) {
+	switch v := args[name].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	}
+	return 0, false
+}
+
+func stringArg(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+// stringsArg reads a list of IDs, which is absent or null when not given
+func stringsArg(args map[string]interface{}, name string) []string {
+	var values []string
+	if list, ok := args[name].([]interface{}); ok {
+		for _, value := range list {
+			values = append(values, fmt.Sprint(value))
+		}
+	}
+	return values
+}
+
+func boolArg(args map[string]interface{}, name string) bool {
+	b, _ := args[name].(bool)
+	return b
+}
+
+// inputArg returns a mutation's input object
+func inputArg(args map[string]interface{}) map[string]interface{} {
+	input, _ := args["input"].(map[string]interface{})
+	return input
+}
+
+// payload builds a mutation payload object from its fields
+func payload(typename string, fields map[string]interface{}) *object {
+	return &object{typename: typename, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		if value, ok := fields[field]; ok {
+			return value, nil
+		}
+		if field == "clientMutationId" {
+			return nil, nil
+		}
+		return nil, errUnknownField
+	}}
+}
+
+// --- Schema; resolvers run with the backend's lock held ---
+
+func (s *Server) queryRoot() *object {
+	c := s.backend
+	return &object{typename: "Query", resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "viewer":
+			return s.user(c.viewer), nil
+		case "user":
+			login := stringArg(args, "login")
+			if _, ok := c.users[login]; !ok {
+				return nil, notFoundError(fmt.Sprintf("Could not resolve to a User with the login of '%s'.", login))
+			}
+			return s.user(login), nil
+		case "organization":
+			o, ok := c.orgs[stringArg(args, "login")]
+			if !ok {
+				return nil, notFoundError(fmt.Sprintf("Could not resolve to an Organization with the login of '%s'.", stringArg(args, "login")))
+			}
+			return s.organization(o), nil
+		case "node":
+			return s.node(stringArg(args, "id"))
+		case "repository":
+			owner, name := stringArg(args, "owner"), stringArg(args, "name")
+			for _, r := range c.repositories {
+				if r.Owner == owner && r.Name == name {
+					return s.repository(r), nil
+				}
+			}
+			return nil, notFoundError(fmt.Sprintf("Could not resolve to a Repository with the name '%s/%s'.", owner, name))
+		case "search":
+			return s.search(args)
+		case "rateLimit":
+			return s.rateLimit(), nil
+		}
+		return nil, errUnknownField
+	}}
+}
+
+func (s *Server) mutationRoot() *object {
+	c := s.backend
+	return &object{typename: "Mutation", resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		input := inputArg(args)
+		switch field {
+		case "createProjectV2":
+			p, err := c.createProject(models.CreateProjectInput{
+				OwnerID:          stringArg(input, "ownerId"),
+				Title:            stringArg(input, "title"),
+				ShortDescription: stringArg(input, "shortDescription"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return payload("CreateProjectV2Payload", map[string]interface{}{"projectV2": s.project(p)}), nil
+
+		case "updateProjectV2":
+			update := models.UpdateProjectInput{ProjectID: stringArg(input, "projectId")}
+			if title, ok := input["title"].(string); ok {
+				update.Title = &title
+			}
+			if description, ok := input["shortDescription"].(string); ok {
+				update.ShortDescription = &description
+			}
+			if public, ok := input["public"].(bool); ok {
+				update.Public = &public
+			}
+			if closed, ok := input["closed"].(bool); ok {
+				update.Closed = &closed
+			}
+			p, err := c.updateProject(update)
+			if err != nil {
+				return nil, err
+			}
+			return payload("UpdateProjectV2Payload", map[string]interface{}{"projectV2": s.project(p)}), nil
+
+		case "copyProjectV2":
+			p, err := c.copyProject(models.CopyProjectInput{
+				SourceProjectID:    stringArg(input, "projectId"),
+				OwnerID:            stringArg(input, "ownerId"),
+				Title:              stringArg(input, "title"),
+				IncludeDraftIssues: boolArg(input, "includeDraftIssues"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return payload("CopyProjectV2Payload", map[string]interface{}{"projectV2": s.project(p)}), nil
+
+		case "markProjectV2AsTemplate", "unmarkProjectV2AsTemplate":
+			p, err := c.setProjectTemplate(stringArg(input, "projectId"), field == "markProjectV2AsTemplate")
+			if err != nil {
+				return nil, err
+			}
+			return payload("MarkProjectV2AsTemplatePayload", map[string]interface{}{"projectV2": s.project(p)}), nil
+
+		case "addProjectV2ItemById":
+			it, err := c.addProjectItem(models.CreateItemInput{
+				ProjectID: stringArg(input, "projectId"),
+				ContentID: stringArg(input, "contentId"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return payload("AddProjectV2ItemByIdPayload", map[string]interface{}{"item": s.item(it)}), nil
+
+		case "addProjectV2DraftIssue":
+			it, err := c.createDraftIssue(models.CreateItemInput{
+				ProjectID: stringArg(input, "projectId"),
+				Title:     stringArg(input, "title"),
+				Body:      stringArg(input, "body"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return payload("AddProjectV2DraftIssuePayload", map[string]interface{}{"projectItem": s.item(it)}), nil
+
+		case "updateProjectV2DraftIssue":
+			var assigneeIDs []string
+			if ids, ok := input["assigneeIds"].([]interface{}); ok {
+				assigneeIDs = []string{}
+				for _, id := range ids {
+					assigneeIDs = append(assigneeIDs, fmt.Sprint(id))
+				}
+			}
+			ct, err := c.updateDraftIssue(stringArg(input, "draftIssueId"), stringArg(input, "title"), stringArg(input, "body"), assigneeIDs)
+			if err != nil {
+				return nil, err
+			}
+			return payload("UpdateProjectV2DraftIssuePayload", map[string]interface{}{"draftIssue": s.content(ct)}), nil
+
+		case "deleteProjectV2Item":
+			itemID := stringArg(input, "itemId")
+			if err := c.deleteProjectItem(stringArg(input, "projectId"), itemID); err != nil {
+				return nil, err
+			}
+			return payload("DeleteProjectV2ItemPayload", map[string]interface{}{"deletedItemId": itemID}), nil
+
+		case "convertProjectV2DraftIssueItemToIssue":
+			it, err := c.convertDraftIssue(stringArg(input, "projectV2ItemId"), stringArg(input, "repositoryId"))
+			if err != nil {
+				return nil, err
+			}
+			return payload("ConvertProjectV2DraftIssueItemToIssuePayload", map[string]interface{}{
+				"item":          s.item(it),
+				"projectV2Item": s.item(it),
+				"newIssue":      s.content(c.contents[it.contentID]),
+			}), nil
+
+		case "updateIssue", "updatePullRequest":
+			typeName, idKey := "Issue", "id"
+			if field == "updatePullRequest" {
+				typeName, idKey = "PullRequest", "pullRequestId"
+			}
+			ct, ok := c.contents[stringArg(input, idKey)]
+			if !ok {
+				return nil, notFound(typeName, stringArg(input, idKey))
+			}
+			// Fields left out of the input keep their value
+			title, body := ct.title, ct.body
+			if value, ok := input["title"].(string); ok {
+				title = value
+			}
+			if value, ok := input["body"].(string); ok {
+				body = value
+			}
+			ct, err := c.updateContent(typeName, stringArg(input, idKey), title, body)
+			if err != nil {
+				return nil, err
+			}
+			// A null milestoneId takes it out of its milestone
+			if _, ok := input["milestoneId"]; ok {
+				if ct, err = c.setMilestone(typeName, ct.id, stringArg(input, "milestoneId")); err != nil {
+					return nil, err
+				}
+			}
+			key := "issue"
+			if typeName == "PullRequest" {
+				key = "pullRequest"
+			}
+			return payload(strings.ToUpper(field[:1])+field[1:]+"Payload", map[string]interface{}{key: s.content(ct)}), nil
+
+		case "addAssigneesToAssignable", "removeAssigneesFromAssignable":
+			var userIDs []string
+			if ids, ok := input["assigneeIds"].([]interface{}); ok {
+				for _, id := range ids {
+					userIDs = append(userIDs, fmt.Sprint(id))
+				}
+			}
+			ct, err := c.changeAssignees(stringArg(input, "assignableId"), userIDs, field == "addAssigneesToAssignable")
+			if err != nil {
+				return nil, err
+			}
+			return payload(strings.ToUpper(field[:1])+field[1:]+"Payload", map[string]interface{}{"assignable": s.content(ct)}), nil
+
+		case "addLabelsToLabelable", "removeLabelsFromLabelable":
+			var labelIDs []string
+			if ids, ok := input["labelIds"].([]interface{}); ok {
+				for _, id := range ids {
+					labelIDs = append(labelIDs, fmt.Sprint(id))
+				}
+			}
+			ct, err := c.changeLabels(stringArg(input, "labelableId"), labelIDs, field == "addLabelsToLabelable")
+			if err != nil {
+				return nil, err
+			}
+			return payload(strings.ToUpper(field[:1])+field[1:]+"Payload", map[string]interface{}{"labelable": s.content(ct)}), nil
+
+		case "closeIssue":
+			ct, err := c.closeIssue(stringArg(input, "issueId"), stringArg(input, "stateReason"), stringArg(input, "duplicateIssueId"))
+			if err != nil {
+				return nil, err
+			}
+			return payload("CloseIssuePayload", map[string]interface{}{"issue": s.content(ct)}), nil
+
+		case "reopenIssue":
+			ct, err := c.reopenIssue(stringArg(input, "issueId"))
+			if err != nil {
+				return nil, err
+			}
+			return payload("ReopenIssuePayload", map[string]interface{}{"issue": s.content(ct)}), nil
+
+		case "addSubIssue", "removeSubIssue":
+			add := c.addSubIssue
+			if field == "removeSubIssue" {
+				add = func(issueID, subIssueID string, _ bool) (*content, *content, error) {
+					return c.removeSubIssue(issueID, subIssueID)
+				}
+			}
+			parent, child, err := add(stringArg(input, "issueId"), stringArg(input, "subIssueId"), boolArg(input, "replaceParent"))
+			if err != nil {
+				return nil, err
+			}
+			return payload(strings.ToUpper(field[:1])+field[1:]+"Payload", map[string]interface{}{
+				"issue":    s.content(parent),
+				"subIssue": s.content(child),
+			}), nil
+
+		case "addBlockedBy", "removeBlockedBy":
+			change := c.addBlockedBy
+			if field == "removeBlockedBy" {
+				change = c.removeBlockedBy
+			}
+			issue, blocker, err := change(stringArg(input, "issueId"), stringArg(input, "blockingIssueId"))
+			if err != nil {
+				return nil, err
+			}
+			return payload(strings.ToUpper(field[:1])+field[1:]+"Payload", map[string]interface{}{
+				"issue":         s.content(issue),
+				"blockingIssue": s.content(blocker),
+			}), nil
+
+		case "requestReviews":
+			ct, err := c.requestReviews(stringArg(input, "pullRequestId"), stringsArg(input, "userIds"), stringsArg(input, "teamIds"))
+			if err != nil {
+				return nil, err
+			}
+			return payload("RequestReviewsPayload", map[string]interface{}{"pullRequest": s.content(ct)}), nil
+
+		case "addPullRequestReview":
+			ct, err := c.submitReview(stringArg(input, "pullRequestId"), stringArg(input, "event"), stringArg(input, "body"))
+			if err != nil {
+				return nil, err
+			}
+			return payload("AddPullRequestReviewPayload", map[string]interface{}{"pullRequest": s.content(ct)}), nil
+
+		case "markPullRequestReadyForReview":
+			ct, err := c.markReadyForReview(stringArg(input, "pullRequestId"))
+			if err != nil {
+				return nil, err
+			}
+			return payload("MarkPullRequestReadyForReviewPayload", map[string]interface{}{"pullRequest": s.content(ct)}), nil
+
+		case "mergePullRequest":
+			ct, err := c.mergePullRequest(stringArg(input, "pullRequestId"), stringArg(input, "mergeMethod"))
+			if err != nil {
+				return nil, err
+			}
+			return payload("MergePullRequestPayload", map[string]interface{}{"pullRequest": s.content(ct)}), nil
+
+		case "addComment":
+			comment, err := c.addComment(stringArg(input, "subjectId"), stringArg(input, "body"))
+			if err != nil {
+				return nil, err
+			}
+			node := s.comment(*comment)
+			edge := payload("IssueCommentEdge", map[string]interface{}{"node": node, "cursor": encodeCursor(0)})
+			return payload("AddCommentPayload", map[string]interface{}{
+				"commentEdge": edge,
+				"subject":     s.content(c.contents[stringArg(input, "subjectId")]),
+			}), nil
+		}
+		return nil, fmt.Errorf("Field '%s' is not supported by the fake server", field)
+	}}
+}
+
+// node looks up any object by its node ID
+func (s *Server) node(id string) (*object, error) {
+	c := s.backend
+	if p := c.project(id); p != nil {
+		return s.project(p), nil
+	}
+	if it := c.item(id); it != nil {
+		return s.item(it), nil
+	}
+	if ct, ok := c.contents[id]; ok {
+		return s.content(ct), nil
+	}
+	if r := c.repository(id); r != nil {
+		return s.repository(r), nil
+	}
+	if login, ok := c.loginOf(id); ok {
+		return s.user(login), nil
+	}
+	for _, o := range c.orgs {
+		if o.id == id {
+			return s.organization(o), nil
+		}
+	}
+	return nil, notFoundError(fmt.Sprintf("Could not resolve to a node with the global id of '%s'", id))
+}
+
+func (s *Server) user(login string) *object {
+	c := s.backend
+	return &object{typename: "User", interfaces: []string{"Actor", "Node", "ProjectV2Owner", "RepositoryOwner"}, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "id":
+			return c.users[login], nil
+		case "login":
+			return login, nil
+		case "name":
+			return login, nil
+		case "projectsV2":
+			return s.projectConnection(login, "User", args)
+		case "organizations":
+			var orgs []*object
+			for _, name := range sortedKeys(c.orgs) {
+				for _, member := range c.orgs[name].members {
+					if member == login {
+						orgs = append(orgs, s.organization(c.orgs[name]))
+					}
+				}
+			}
+			return connection("OrganizationConnection", orgs, args)
+		case "repositories":
+			return s.repositoryConnection(login, args)
+		}
+		return nil, errUnknownField
+	}}
+}
+
+func (s *Server) organization(o *org) *object {
+	return &object{typename: "Organization", interfaces: []string{"Actor", "Node", "ProjectV2Owner", "RepositoryOwner"}, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "id":
+			return o.id, nil
+		case "login":
+			return o.login, nil
+		case "name":
+			return o.login, nil
+		case "projectsV2":
+			return s.projectConnection(o.login, "Organization", args)
+		case "membersWithRole":
+			members := make([]*object, len(o.members))
+			for i, member := range o.members {
+				members[i] = s.user(member)
+			}
+			return connection("OrganizationMemberConnection", members, args)
+		case "teams":
+			var teams []*object
+			for _, team := range o.teams {
+				if contains(team.Slug, stringArg(args, "query")) || contains(team.Name, stringArg(args, "query")) {
+					teams = append(teams, s.team(o.login, team))
+				}
+			}
+			return connection("TeamConnection", teams, args)
+		case "repositories":
+			return s.repositoryConnection(o.login, args)
+		}
+		return nil, errUnknownField
+	}}
+}
+
+func (s *Server) team(orgLogin string, team models.Team) *object {
+	return payload("Team", map[string]interface{}{
+		"id":           team.ID,
+		"slug":         team.Slug,
+		"name":         team.Name,
+		"combinedSlug": orgLogin + "/" + team.Slug,
+	})
+}
+
+func (s *Server) projectConnection(owner, ownerType string, args map[string]interface{}) (*object, error) {
+	var projects []*object
+	for _, p := range s.backend.projects {
+		if p.Owner.Login == owner && p.Owner.Type == ownerType {
+			projects = append(projects, s.project(p))
+		}
+	}
+	return connection("ProjectV2Connection", projects, args)
+}
+
+func (s *Server) repositoryConnection(owner string, args map[string]interface{}) (*object, error) {
+	var repositories []*object
+	for _, r := range s.backend.repositories {
+		if r.Owner == owner {
+			repositories = append(repositories, s.repository(r))
+		}
+	}
+	return connection("RepositoryConnection", repositories, args)
+}
+
+func (s *Server) project(p *project) *object {
+	return &object{typename: "ProjectV2", interfaces: []string{"Node"}, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "id":
+			return p.ID, nil
+		case "number":
+			return p.Number, nil
+		case "title":
+			return p.Title, nil
+		case "shortDescription":
+			return p.ShortDescription, nil
+		case "public":
+			return p.Public, nil
+		case "closed":
+			return p.Closed, nil
+		case "template":
+			return p.Template, nil
+		case "url":
+			return p.URL, nil
+		case "createdAt":
+			return p.CreatedAt, nil
+		case "updatedAt":
+			return p.UpdatedAt, nil
+		case "owner":
+			if p.Owner.Type == "Organization" {
+				return s.organization(s.backend.orgs[p.Owner.Login]), nil
+			}
+			return s.user(p.Owner.Login), nil
+		case "items":
+			items := make([]*object, len(p.items))
+			for i, it := range p.items {
+				items[i] = s.item(it)
+			}
+			return connection("ProjectV2ItemConnection", items, args)
+		case "fields":
+			fields := make([]*object, len(p.fields))
+			for i, f := range p.fields {
+				fields[i] = s.field(f)
+			}
+			return connection("ProjectV2FieldConfigurationConnection", fields, args)
+		}
+		return nil, errUnknownField
+	}}
+}
+
+func (s *Server) item(it *item) *object {
+	c := s.backend
+	return &object{typename: "ProjectV2Item", interfaces: []string{"Node"}, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		ct := c.contents[it.contentID]
+		switch field {
+		case "id":
+			return it.id, nil
+		case "type":
+			switch ct.typeName {
+			case "DraftIssue":
+				return "DRAFT_ISSUE", nil
+			case "PullRequest":
+				return "PULL_REQUEST", nil
+			}
+			return "ISSUE", nil
+		case "content":
+			return s.content(ct), nil
+		case "createdAt":
+			return ct.createdAt, nil
+		case "updatedAt":
+			return ct.updatedAt, nil
+		case "isArchived":
+			return false, nil
+		}
+		return nil, errUnknownField
+	}}
+}
+
+// content is an Issue, PullRequest or DraftIssue
+func (s *Server) content(ct *content) *object {
+	interfaces := []string{"Node", "Assignable"}
+	if ct.typeName != "DraftIssue" {
+		interfaces = append(interfaces, "Comment", "Closable", "Labelable", "UniformResourceLocatable")
+	}
+	return &object{typename: ct.typeName, interfaces: interfaces, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "id":
+			return ct.id, nil
+		case "title":
+			return ct.title, nil
+		case "body":
+			return ct.body, nil
+		case "createdAt":
+			return ct.createdAt, nil
+		case "updatedAt":
+			return ct.updatedAt, nil
+		case "assignees":
+			assignees := make([]*object, len(ct.assignees))
+			for i, login := range ct.assignees {
+				assignees[i] = s.user(login)
+			}
+			return connection("UserConnection", assignees, args)
+		}
+		if ct.typeName == "DraftIssue" {
+			return nil, errUnknownField
+		}
+
+		switch field {
+		case "number":
+			return ct.number, nil
+		case "state":
+			return ct.state, nil
+		case "parent", "subIssues", "subIssuesSummary":
+			if ct.typeName != "Issue" {
+				return nil, errUnknownField
+			}
+			return s.subIssueField(ct, field, args)
+		case "closedByPullRequestsReferences", "linkedBranches":
+			if ct.typeName != "Issue" {
+				return nil, errUnknownField
+			}
+			return s.developmentField(ct, field, args)
+		case "blockedBy", "blocking", "issueDependenciesSummary":
+			if ct.typeName != "Issue" {
+				return nil, errUnknownField
+			}
+			return s.dependencyField(ct, field, args)
+		case "stateReason":
+			if ct.typeName != "Issue" {
+				return nil, errUnknownField
+			}
+			if ct.stateReason == "" {
+				return nil, nil
+			}
+			return ct.stateReason, nil
+		case "url":
+			return ct.url, nil
+		case "comments":
+			comments := make([]*object, len(ct.comments))
+			for i, comment := range ct.comments {
+				comments[i] = s.comment(comment)
+			}
+			return connection("IssueCommentConnection", comments, args)
+		case "timelineItems":
+			events := timelineOf(ct, stringsArg(args, "itemTypes"))
+			nodes := make([]*object, len(events))
+			for i, event := range events {
+				nodes[i] = s.timelineEvent(event)
+			}
+			return connection(ct.typeName+"TimelineItemsConnection", nodes, args)
+		case "repository":
+			return s.repository(s.backend.repository(ct.repositoryID)), nil
+		case "labels":
+			r := s.backend.repository(ct.repositoryID)
+			labels := make([]*object, len(ct.labels))
+			for i, id := range ct.labels {
+				labels[i] = s.label(*r.label(id))
+			}
+			return connection("LabelConnection", labels, args)
+		case "milestone":
+			if milestone := s.backend.repository(ct.repositoryID).milestone(ct.milestoneID); milestone != nil {
+				return s.milestone(*milestone), nil
+			}
+			return nil, nil
+		}
+		if ct.typeName == "PullRequest" {
+			return s.pullRequestField(ct, field, args)
+		}
+		return nil, errUnknownField
+	}}
+}
+
+// subIssueField resolves an issue's place in the sub-issue hierarchy
+func (s *Server) subIssueField(ct *content, field string, args map[string]interface{}) (interface{}, error) {
+	c := s.backend
+	switch field {
+	case "parent":
+		if parent := c.contents[ct.parentID]; parent != nil {
+			return s.content(parent), nil
+		}
+		return nil, nil
+	case "subIssues":
+		subIssues := make([]*object, len(ct.subIssues))
+		for i, id := range ct.subIssues {
+			subIssues[i] = s.content(c.contents[id])
+		}
+		return connection("IssueConnection", subIssues, args)
+	}
+	summary := c.subIssuesSummary(ct)
+	percent := 0
+	if summary.Total > 0 {
+		percent = summary.Completed * 100 / summary.Total
+	}
+	return payload("SubIssuesSummary", map[string]interface{}{
+		"total":            summary.Total,
+		"completed":        summary.Completed,
+		"percentCompleted": percent,
+	}), nil
+}
+
+// developmentField resolves the pull requests and branches linked to an issue
+func (s *Server) developmentField(ct *content, field string, args map[string]interface{}) (interface{}, error) {
+	c := s.backend
+	if field == "linkedBranches" {
+		branches := make([]*object, len(ct.branches))
+		for i, branch := range ct.branches {
+			ref := payload("Ref", map[string]interface{}{
+				"name":       branch.name,
+				"repository": s.repository(c.repository(branch.repositoryID)),
+			})
+			branches[i] = payload("LinkedBranch", map[string]interface{}{"ref": ref})
+		}
+		return connection("LinkedBranchConnection", branches, args)
+	}
+
+	var prs []*object
+	for _, id := range ct.closedBy {
+		pr := c.contents[id]
+		if pr.state != "OPEN" && !boolArg(args, "includeClosedPrs") {
+			continue
+		}
+		prs = append(prs, s.content(pr))
+	}
+	return connection("PullRequestConnection", prs, args)
+}
+
+// dependencyField resolves the issues an issue is blocked by and blocking
+func (s *Server) dependencyField(ct *content, field string, args map[string]interface{}) (interface{}, error) {
+	c := s.backend
+	ids := ct.blockedBy
+	switch field {
+	case "blocking":
+		ids = ct.blocking
+	case "issueDependenciesSummary":
+		summary := c.dependencySummary(ct)
+		blocking := 0
+		for _, id := range ct.blocking {
+			if c.contents[id].state == "OPEN" {
+				blocking++
+			}
+		}
+		return payload("IssueDependenciesSummary", map[string]interface{}{
+			"blockedBy":      summary.OpenBlockers,
+			"totalBlockedBy": summary.TotalBlockedBy,
+			"blocking":       blocking,
+			"totalBlocking":  summary.TotalBlocking,
+		}), nil
+	}
+
+	issues := make([]*object, len(ids))
+	for i, id := range ids {
+		issues[i] = s.content(c.contents[id])
+	}
+	return connection("IssueConnection", issues, args)
+}
+
+// pullRequestField resolves the fields only pull requests have
+func (s *Server) pullRequestField(ct *content, field string, args map[string]interface{}) (interface{}, error) {
+	status := ct.pullRequest
+	switch field {
+	case "isDraft":
+		return status.IsDraft, nil
+	case "reviewDecision":
+		if status.ReviewDecision == "" {
+			return nil, nil
+		}
+		return status.ReviewDecision, nil
+	case "mergeable":
+		return status.Mergeable, nil
+	case "headRefName":
+		return status.HeadRef, nil
+	case "baseRefName":
+		return status.BaseRef, nil
+	case "reviewRequests":
+		requests := make([]*object, len(status.Reviewers))
+		for i, reviewer := range status.Reviewers {
+			requests[i] = payload("ReviewRequest", map[string]interface{}{
+				"requestedReviewer": s.reviewer(reviewer),
+			})
+		}
+		return connection("ReviewRequestConnection", requests, args)
+	case "commits":
+		// Only the head commit is modelled, with the status rollup
+		var rollup interface{}
+		if status.Checks != "" {
+			rollup = payload("StatusCheckRollup", map[string]interface{}{"state": status.Checks})
+		}
+		commit := payload("PullRequestCommit", map[string]interface{}{
+			"commit": payload("Commit", map[string]interface{}{"statusCheckRollup": rollup}),
+		})
+		return connection("PullRequestCommitConnection", []*object{commit}, args)
+	}
+	return nil, errUnknownField
+}
+
+// reviewer is a requested reviewer: a team when given as "org/slug", otherwise a user
+func (s *Server) reviewer(reviewer string) *object {
+	orgLogin, slug, ok := strings.Cut(reviewer, "/")
+	if !ok {
+		return s.user(reviewer)
+	}
+	team := models.Team{Slug: slug}
+	if o, ok := s.backend.orgs[orgLogin]; ok {
+		for _, t := range o.teams {
+			if t.Slug == slug {
+				team = t
+			}
+		}
+	}
+	return s.team(orgLogin, team)
+}
+
+func (s *Server) comment(comment models.Comment) *object {
+	return &object{typename: "IssueComment", interfaces: []string{"Node", "Comment"}, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "id":
+			return comment.ID, nil
+		case "author":
+			return s.user(comment.Author), nil
+		case "body":
+			return comment.Body, nil
+		case "createdAt":
+			return comment.CreatedAt, nil
+		case "viewerCanUpdate":
+			return comment.CanUpdate, nil
+		case "viewerCanDelete":
+			return comment.CanDelete, nil
+		}
+		return nil, errUnknownField
+	}}
+}
+
+// timelineFields lists the fields of each timeline event type beyond actor
+// and createdAt
+var timelineFields = map[string][]string{
+	"LabeledEvent":                    {"label"},
+	"UnlabeledEvent":                  {"label"},
+	"AssignedEvent":                   {"assignee"},
+	"UnassignedEvent":                 {"assignee"},
+	"CrossReferencedEvent":            {"source"},
+	"ClosedEvent":                     {"stateReason"},
+	"RenamedTitleEvent":               {"previousTitle", "currentTitle"},
+	"MilestonedEvent":                 {"milestoneTitle"},
+	"DemilestonedEvent":               {"milestoneTitle"},
+	"AddedToProjectV2Event":           {"project"},
+	"RemovedFromProjectV2Event":       {"project"},
+	"ProjectV2ItemStatusChangedEvent": {"project", "previousStatus", "status"},
+}
+
+func (s *Server) timelineEvent(event timelineEvent) *object {
+	return &object{typename: event.typeName, interfaces: []string{"Node"}, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "actor":
+			return s.user(event.actor), nil
+		case "createdAt":
+			return event.createdAt, nil
+		}
+		if !slices.Contains(timelineFields[event.typeName], field) {
+			return nil, errUnknownField
+		}
+		switch field {
+		case "label":
+			return payload("Label", map[string]interface{}{"name": event.label}), nil
+		case "assignee":
+			return s.user(event.assignee), nil
+		case "source":
+			return s.content(event.source), nil
+		case "stateReason":
+			return event.stateReason, nil
+		case "previousTitle":
+			return event.previousTitle, nil
+		case "currentTitle":
+			return event.currentTitle, nil
+		case "milestoneTitle":
+			return event.milestone, nil
+		case "project":
+			return payload("ProjectV2", map[string]interface{}{"title": event.project}), nil
+		case "previousStatus":
+			return event.previousStatus, nil
+		case "status":
+			return event.status, nil
+		}
+		return nil, errUnknownField
+	}}
+}
+
+func (s *Server) field(f models.ProjectField) *object {
+	typename := "ProjectV2Field"
+	switch f.DataType {
+	case "SINGLE_SELECT":
+		typename = "ProjectV2SingleSelectField"
+	case "ITERATION":
+		typename = "ProjectV2IterationField"
+	}
+	return &object{typename: typename, interfaces: []string{"Node", "ProjectV2FieldCommon"}, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "id":
+			return f.ID, nil
+		case "name":
+			return f.Name, nil
+		case "dataType":
+			return f.DataType, nil
+		case "options":
+			if typename != "ProjectV2SingleSelectField" {
+				return nil, errUnknownField
+			}
+			options := make([]*object, len(f.Options))
+			for i, option := range f.Options {
+				options[i] = payload("ProjectV2SingleSelectFieldOption", map[string]interface{}{
+					"id":    option.ID,
+					"name":  option.Name,
+					"color": option.Color,
+				})
+			}
+			return options, nil
+		}
+		return nil, errUnknownField
+	}}
+}
+
+func (s *Server) repository(r *repository) *object {
+	return &object{typename: "Repository", interfaces: []string{"Node"}, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		switch field {
+		case "id":
+			return r.ID, nil
+		case "name":
+			return r.Name, nil
+		case "nameWithOwner":
+			return r.Owner + "/" + r.Name, nil
+		case "description":
+			return r.Description, nil
+		case "isPrivate":
+			return r.IsPrivate, nil
+		case "url":
+			return "https://github.com/" + r.Owner + "/" + r.Name, nil
+		case "owner":
+			if o, ok := s.backend.orgs[r.Owner]; ok {
+				return s.organization(o), nil
+			}
+			return s.user(r.Owner), nil
+		case "labels":
+			// Always sorted by name, whatever orderBy asks for
+			var labels []*object
+			for _, label := range r.sortedLabels() {
+				labels = append(labels, s.label(label))
+			}
+			return connection("LabelConnection", labels, args)
+		case "issue":
+			number, _ := intArg(args, "number")
+			if ct := s.backend.issue(r.ID, number); ct != nil {
+				return s.content(ct), nil
+			}
+			return nil, notFoundError(fmt.Sprintf("Could not resolve to an Issue with the number of %d.", number))
+		case "milestones":
+			// All milestones are open and sorted by due date
+			var milestones []*object
+			for _, milestone := range r.sortedMilestones() {
+				milestones = append(milestones, s.milestone(milestone))
+			}
+			return connection("MilestoneConnection", milestones, args)
+		}
+		return nil, errUnknownField
+	}}
+}
+
+func (s *Server) label(label models.Label) *object {
+	return payload("Label", map[string]interface{}{
+		"id":    label.ID,
+		"name":  label.Name,
+		"color": label.Color,
+	})
+}
+
+func (s *Server) milestone(milestone models.Milestone) *object {
+	return payload("Milestone", map[string]interface{}{
+		"id":     milestone.ID,
+		"title":  milestone.Title,
+		"number": milestone.Number,
+		"dueOn":  milestone.DueOn,
+	})
+}
+
+// search supports user searches; the first word of the query is matched
+// against logins and qualifiers such as in:login are ignored
+func (s *Server) search(args map[string]interface{}) (*object, error) {
+	if stringArg(args, "type") != "USER" {
+		return nil, fmt.Errorf("Only USER searches are supported by the fake server")
+	}
+
+	term := ""
+	if words := strings.Fields(stringArg(args, "query")); len(words) > 0 {
+		term = words[0]
+	}
+	logins := make([]string, 0, len(s.backend.users))
+	for login := range s.backend.users {
+		logins = append(logins, login)
+	}
+
+	var users []*object
+	for _, login := range matching(logins, term, -1) {
+		users = append(users, s.user(login))
+	}
+	results, err := connection("SearchResultItemConnection", users, args)
+	if err != nil {
+		return nil, err
+	}
+	return &object{typename: results.typename, resolve: func(field string, args map[string]interface{}) (interface{}, error) {
+		if field == "userCount" {
+			return len(users), nil
+		}
+		return results.resolve(field, args)
+	}}, nil
+}
+
+func (s *Server) rateLimit() *object {
+	rl := s.backend.rateLimit
+	return payload("RateLimit", map[string]interface{}{
+		"limit":     rl.Limit,
+		"cost":      rl.Cost,
+		"remaining": rl.Remaining,
+		"used":      rl.Used,
+		"resetAt":   rl.ResetAt,
+		"nodeCount": 0,
+	})
+}
+
+// notFoundError is GitHub's error for a lookup that matched nothing
+func notFoundError(message string) error {
+	err := apierrors.ValidationError(message, nil)
+	err.GraphQLType = "NOT_FOUND"
+	return err
+}
+
+func sortedKeys(orgs map[string]*org) []string {
+	keys := make([]string, 0, len(orgs))
+	for key := range orgs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// ListProjectFields retrieves a project's fields, including the options of single-select fields
+func (c *Client) ListProjectFields(ctx context.Context, projectID string) ([]models.ProjectField, error) {
+	query := `query($id: ID!) {
+		node(id: $id) {
+			... on ProjectV2 {
+				fields(first: 50) {
+					nodes {
+						... on ProjectV2FieldCommon {
+							id
+							name
+							dataType
+						}
+						... on ProjectV2SingleSelectField {
+							options {
+								id
+								name
+								color
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id": projectID,
+	}
+
+	var response struct {
+		Node struct {
+			Fields struct {
+				Nodes []struct {
+					ID       string `json:"id"`
+					Name     string `json:"name"`
+					DataType string `json:"dataType"`
+					Options  []struct {
+						ID    string `json:"id"`
+						Name  string `json:"name"`
+						Color string `json:"color"`
+					} `json:"options"`
+				} `json:"nodes"`
+			} `json:"fields"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project fields: %w", err)
+	}
+
+	fields := make([]models.ProjectField, 0, len(response.Node.Fields.Nodes))
+	for _, node := range response.Node.Fields.Nodes {
+		options := make([]models.ProjectFieldOption, len(node.Options))
+		for i, option := range node.Options {
+			options[i] = models.ProjectFieldOption{
+				ID:    option.ID,
+				Name:  option.Name,
+				Color: option.Color,
+			}
+		}
+		fields = append(fields, models.ProjectField{
+			ID:       node.ID,
+			Name:     node.Name,
+			DataType: node.DataType,
+			Options:  options,
+		})
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// Interface is the set of GitHub operations the UI relies on. *Client talks
+// to GitHub; package fake provides an in-memory implementation for tests.
+type Interface interface {
+	// RateLimit returns the most recently observed rate limit budget
+	RateLimit() RateLimit
+
+	// Users and organizations
+	GetViewer(ctx context.Context) (string, error)
+	GetUserOrganizations(ctx context.Context, username string) ([]string, error)
+	GetUserNodeID(ctx context.Context, username string) (string, error)
+	GetOrgNodeID(ctx context.Context, org string) (string, error)
+	SearchUsers(ctx context.Context, query string, limit int) ([]string, error)
+	GetOrgMembers(ctx context.Context, org string, limit int) ([]string, error)
+	SearchOrgMembers(ctx context.Context, org string, query string, limit int) ([]string, error)
+	SearchOrgTeams(ctx context.Context, org string, query string, limit int) ([]models.Team, error)
+
+	// Projects
+	ListUserProjects(ctx context.Context, login string, first int) ([]models.Project, error)
+	ListOrgProjects(ctx context.Context, org string, first int) ([]models.Project, error)
+	CreateProject(ctx context.Context, input models.CreateProjectInput) (*models.Project, error)
+	UpdateProject(ctx context.Context, input models.UpdateProjectInput) error
+	CopyProject(ctx context.Context, input models.CopyProjectInput) (*models.Project, error)
+	SetProjectTemplate(ctx context.Context, projectID string, template bool) error
+	ListProjectCollaborators(ctx context.Context, projectID string, first int) ([]models.ProjectCollaborator, error)
+	UpdateProjectCollaborators(ctx context.Context, projectID string, collaborators []models.ProjectCollaborator) error
+	ListStatusUpdates(ctx context.Context, projectID string, first int) ([]models.StatusUpdate, error)
+	CreateStatusUpdate(ctx context.Context, input models.CreateStatusUpdateInput) (*models.StatusUpdate, error)
+
+	// Items
+	ListProjectItems(ctx context.Context, projectID string, first int) ([]models.ProjectItem, error)
+	AddProjectItem(ctx context.Context, input models.CreateItemInput) (*models.ProjectItem, error)
+	CreateDraftIssue(ctx context.Context, input models.CreateItemInput) (*models.ProjectItem, error)
+	UpdateDraftIssue(ctx context.Context, itemID, title, body string, assigneeIDs []string) (*models.ProjectItem, error)
+	DeleteProjectItem(ctx context.Context, projectID, itemID string) error
+	ConvertDraftIssueToIssue(ctx context.Context, projectItemID, repositoryID string) (*models.ProjectItem, error)
+	GetItemDetails(ctx context.Context, contentID string) (*models.ItemDetails, error)
+	GetItemUpdatedAt(ctx context.Context, contentID string) (time.Time, error)
+	GetItemContent(ctx context.Context, contentID string) (*models.ItemContent, error)
+	UpdateIssue(ctx context.Context, issueID, title, body string) (*models.ItemContent, error)
+	UpdatePullRequest(ctx context.Context, pullRequestID, title, body string) (*models.ItemContent, error)
+	AddAssignees(ctx context.Context, assignableID string, userIDs []string) error
+	RemoveAssignees(ctx context.Context, assignableID string, userIDs []string) error
+	CloseIssue(ctx context.Context, issueID, reason, duplicateOfID string) error
+	ReopenIssue(ctx context.Context, issueID string) error
+	GetIssueID(ctx context.Context, repositoryID string, number int) (string, error)
+	AddSubIssue(ctx context.Context, issueID, subIssueID string, replaceParent bool) error
+	RemoveSubIssue(ctx context.Context, issueID, subIssueID string) error
+	AddBlockedBy(ctx context.Context, issueID, blockingIssueID string) error
+	RemoveBlockedBy(ctx context.Context, issueID, blockingIssueID string) error
+
+	// Pull requests
+	GetPullRequestStatus(ctx context.Context, pullRequestID string) (string, *models.PullRequestStatus, error)
+	RequestReviews(ctx context.Context, pullRequestID string, userIDs, teamIDs []string) error
+	SubmitReview(ctx context.Context, pullRequestID, event, body string) error
+	MarkReadyForReview(ctx context.Context, pullRequestID string) error
+	MergePullRequest(ctx context.Context, pullRequestID, method string) error
+
+	// Labels and milestones
+	ListRepositoryLabels(ctx context.Context, repositoryID string) ([]models.Label, error)
+	ListRepositoryMilestones(ctx context.Context, repositoryID string) ([]models.Milestone, error)
+	AddLabels(ctx context.Context, labelableID string, labelIDs []string) error
+	RemoveLabels(ctx context.Context, labelableID string, labelIDs []string) error
+	SetIssueMilestone(ctx context.Context, issueID, milestoneID string) error
+	SetPullRequestMilestone(ctx context.Context, pullRequestID, milestoneID string) error
+
+	// Fields
+	ListProjectFields(ctx context.Context, projectID string) ([]models.ProjectField, error)
+
+	// Comments
+	ListComments(ctx context.Context, contentID string, first int, after string) ([]models.Comment, models.PageInfo, error)
+	ListTimeline(ctx context.Context, contentID string, itemTypes []string, first int, after string) ([]models.TimelineEvent, models.PageInfo, error)
+	AddComment(ctx context.Context, subjectID, body string) (*models.Comment, error)
+	UpdateComment(ctx context.Context, commentID, body string) (*models.Comment, error)
+	DeleteComment(ctx context.Context, commentID string) error
+
+	// Repositories
+	ListRepositories(ctx context.Context, owner string, isUser bool) ([]models.Repository, error)
+	GetRepositoryNodeID(ctx context.Context, owner, name string) (string, error)
+}
+
+var _ Interface = (*Client)(nil)
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// UpdateIssue sets the title and body of an issue. Unlike drafts, an empty
+// body clears it. Setting the same values again is harmless, so it is retried.
+func (c *Client) UpdateIssue(ctx context.Context, issueID, title, body string) (*models.ItemContent, error) {
+	mutation := `mutation($input: UpdateIssueInput!) {
+		updateIssue(input: $input) {
+			issue {
+				title
+				body
+				updatedAt
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"id":    issueID,
+			"title": title,
+			"body":  body,
+		},
+	}
+
+	var response struct {
+		UpdateIssue struct {
+			Issue contentNode `json:"issue"`
+		} `json:"updateIssue"`
+	}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	return response.UpdateIssue.Issue.toModel(), nil
+}
+
+// UpdatePullRequest sets the title and body of a pull request
+func (c *Client) UpdatePullRequest(ctx context.Context, pullRequestID, title, body string) (*models.ItemContent, error) {
+	mutation := `mutation($input: UpdatePullRequestInput!) {
+		updatePullRequest(input: $input) {
+			pullRequest {
+				title
+				body
+				updatedAt
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId": pullRequestID,
+			"title":         title,
+			"body":          body,
+		},
+	}
+
+	var response struct {
+		UpdatePullRequest struct {
+			PullRequest contentNode `json:"pullRequest"`
+		} `json:"updatePullRequest"`
+	}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	return response.UpdatePullRequest.PullRequest.toModel(), nil
+}
+
+// AddAssignees assigns users to an issue or pull request, keeping its other assignees
+func (c *Client) AddAssignees(ctx context.Context, assignableID string, userIDs []string) error {
+	mutation := `mutation($input: AddAssigneesToAssignableInput!) {
+		addAssigneesToAssignable(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"assignableId": assignableID,
+			"assigneeIds":  userIDs,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to add assignees: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveAssignees unassigns users from an issue or pull request
+func (c *Client) RemoveAssignees(ctx context.Context, assignableID string, userIDs []string) error {
+	mutation := `mutation($input: RemoveAssigneesFromAssignableInput!) {
+		removeAssigneesFromAssignable(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"assignableId": assignableID,
+			"assigneeIds":  userIDs,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to remove assignees: %w", err)
+	}
+
+	return nil
+}
+
+// contentNode is the GraphQL shape of an issue's or pull request's editable content
+type contentNode struct {
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (n contentNode) toModel() *models.ItemContent {
+	return &models.ItemContent{
+		Title:     n.Title,
+		Body:      n.Body,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
+
+// CloseIssue closes an issue with a reason: "COMPLETED", "NOT_PLANNED" or
+// "DUPLICATE". duplicateOfID is the issue it duplicates, for DUPLICATE only.
+// Closing again just updates the reason, so it is retried.
+func (c *Client) CloseIssue(ctx context.Context, issueID, reason, duplicateOfID string) error {
+	mutation := `mutation($input: CloseIssueInput!) {
+		closeIssue(input: $input) {
+			issue {
+				state
+				stateReason
+			}
+		}
+	}`
+
+	input := map[string]interface{}{
+		"issueId":     issueID,
+		"stateReason": reason,
+	}
+	if duplicateOfID != "" {
+		input["duplicateIssueId"] = duplicateOfID
+	}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+
+	return nil
+}
+
+// ReopenIssue reopens a closed issue
+func (c *Client) ReopenIssue(ctx context.Context, issueID string) error {
+	mutation := `mutation($input: ReopenIssueInput!) {
+		reopenIssue(input: $input) {
+			issue {
+				state
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"issueId": issueID,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to reopen issue: %w", err)
+	}
+
+	return nil
+}
+
+// GetIssueID looks up the node ID of an issue by its number in a repository
+func (c *Client) GetIssueID(ctx context.Context, repositoryID string, number int) (string, error) {
+	query := `query($id: ID!, $number: Int!) {
+		node(id: $id) {
+			... on Repository {
+				issue(number: $number) {
+					id
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id":     repositoryID,
+		"number": number,
+	}
+
+	var response struct {
+		Node struct {
+			Issue *struct {
+				ID string `json:"id"`
+			} `json:"issue"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	if response.Node.Issue == nil {
+		return "", apierrors.ValidationError(fmt.Sprintf("Issue #%d doesn't exist", number), nil)
+	}
+
+	return response.Node.Issue.ID, nil
+}
@@ -1,20 +1,30 @@
 package api
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"log/slog"
 	"time"
 
 	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
 	"github.com/thomaskoefod/githubProjectTUI/internal/models"
 )
 
-// ListProjectItems retrieves items from a project
-func (c *Client) ListProjectItems(projectID string, first int) ([]models.ProjectItem, error) {
-	query := `query($id: ID!, $first: Int!) {
+// maxPageSize is the most nodes GitHub returns from a connection at once
+const maxPageSize = 100
+
+// ListProjectItems retrieves the row-level data of up to first of a project's
+// items, following the cursor when there are more than fit in one page. Body,
+// comments and timeline are fetched per item with GetItemDetails.
+func (c *Client) ListProjectItems(ctx context.Context, projectID string, first int) ([]models.ProjectItem, error) {
+	query := `query($id: ID!, $first: Int!, $after: String) {
 		node(id: $id) {
 			... on ProjectV2 {
-				items(first: $first) {
+				items(first: $first, after: $after) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
 					nodes {
 						id
 						type
@@ -23,10 +33,12 @@ func (c *Client) ListProjectItems(projectID string, first int) ([]models.Project
 							... on Issue {
 								id
 								title
-								body
 								number
 								state
+								stateReason
 								url
+								` + subIssueFields + `
+								` + dependencyFields + `
 								createdAt
 								updatedAt
 								assignees(first: 10) {
@@ -34,23 +46,30 @@ func (c *Client) ListProjectItems(projectID string, first int) ([]models.Project
 										login
 									}
 								}
-								comments(first: 50) {
+								labels(first: 20) {
 									nodes {
-										author {
-											login
-										}
-										body
-										createdAt
+										id
+										name
+										color
 									}
 								}
+								milestone {
+									id
+									title
+									number
+									dueOn
+								}
+								repository {
+									id
+								}
 							}
 							... on PullRequest {
 								id
 								title
-								body
 								number
 								state
 								url
+								` + pullRequestStatusFields + `
 								createdAt
 								updatedAt
 								assignees(first: 10) {
@@ -58,20 +77,26 @@ func (c *Client) ListProjectItems(projectID string, first int) ([]models.Project
 										login
 									}
 								}
-								comments(first: 50) {
+								labels(first: 20) {
 									nodes {
-										author {
-											login
-										}
-										body
-										createdAt
+										id
+										name
+										color
 									}
 								}
+								milestone {
+									id
+									title
+									number
+									dueOn
+								}
+								repository {
+									id
+								}
 							}
 							... on DraftIssue {
 								id
 								title
-								body
 								createdAt
 								updatedAt
 								assignees(first: 10) {
@@ -87,14 +112,13 @@ func (c *Client) ListProjectItems(projectID string, first int) ([]models.Project
 		}
 	}`
 
-	variables := map[string]interface{}{
-		"id":    projectID,
-		"first": first,
-	}
-
 	var response struct {
 		Node struct {
 			Items struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
 				Nodes []struct {
 					ID      string `json:"id"`
 					Type    string `json:"type"`
@@ -102,9 +126,9 @@ func (c *Client) ListProjectItems(projectID string, first int) ([]models.Project
 						TypeName  string    `json:"__typename"`
 						ID        string    `json:"id"`
 						Title     string    `json:"title"`
-						Body      string    `json:"body"`
 						Number    int       `json:"number,omitempty"`
 						State     string    `json:"state,omitempty"`
+						StateReason string  `json:"stateReason,omitempty"`
 						URL       string    `json:"url,omitempty"`
 						CreatedAt time.Time `json:"createdAt"`
 						UpdatedAt time.Time `json:"updatedAt"`
@@ -113,65 +137,96 @@ func (c *Client) ListProjectItems(projectID string, first int) ([]models.Project
 								Login string `json:"login"`
 							} `json:"nodes"`
 						} `json:"assignees,omitempty"`
-						Comments struct {
-							Nodes []struct {
-								Author struct {
-									Login string `json:"login"`
-								} `json:"author"`
-								Body      string    `json:"body"`
-								CreatedAt time.Time `json:"createdAt"`
-							} `json:"nodes"`
-						} `json:"comments,omitempty"`
+						Labels struct {
+							Nodes []labelNode `json:"nodes"`
+						} `json:"labels"`
+						Milestone  *milestoneNode `json:"milestone"`
+						Repository struct {
+							ID string `json:"id"`
+						} `json:"repository"`
+
+						// Set on issues only
+						subIssuesNode
+						dependenciesNode
+
+						// Set on pull requests only
+						pullRequestStatusNode
 					} `json:"content"`
 				} `json:"nodes"`
 			} `json:"items"`
 		} `json:"node"`
 	}
 
-	err := c.client.Do(query, variables, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list project items: %w", err)
-	}
-
 	items := make([]models.ProjectItem, 0)
-	for _, node := range response.Node.Items.Nodes {
-		assignees := make([]string, len(node.Content.Assignees.Nodes))
-		for i, assignee := range node.Content.Assignees.Nodes {
-			assignees[i] = assignee.Login
+	after := ""
+	for len(items) < first {
+		variables := map[string]interface{}{
+			"id":    projectID,
+			"first": min(first-len(items), maxPageSize),
+		}
+		if after != "" {
+			variables["after"] = after
 		}
 
-		comments := make([]models.Comment, len(node.Content.Comments.Nodes))
-		for i, comment := range node.Content.Comments.Nodes {
-			comments[i] = models.Comment{
-				Author:    comment.Author.Login,
-				Body:      comment.Body,
-				CreatedAt: comment.CreatedAt,
+		// Decode each page into fresh nodes so fields one item leaves out
+		// aren't kept from the item before it
+		response.Node.Items.Nodes = nil
+		err := c.query(ctx, query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list project items: %w", err)
+		}
+
+		for _, node := range response.Node.Items.Nodes {
+			assignees := make([]string, len(node.Content.Assignees.Nodes))
+			for i, assignee := range node.Content.Assignees.Nodes {
+				assignees[i] = assignee.Login
+			}
+
+			item := models.ProjectItem{
+				ID:        node.ID,
+				ContentID: node.Content.ID,
+				Type:      node.Content.TypeName,
+				Title:     node.Content.Title,
+				Number:    node.Content.Number,
+				State:     node.Content.State,
+				StateReason: node.Content.StateReason,
+				URL:       node.Content.URL,
+				CreatedAt: node.Content.CreatedAt,
+				UpdatedAt: node.Content.UpdatedAt,
+				Assignees: assignees,
+				Fields:    make(map[string]interface{}),
+
+				RepositoryID: node.Content.Repository.ID,
+			}
+			for _, label := range node.Content.Labels.Nodes {
+				item.Labels = append(item.Labels, label.toModel())
+			}
+			if node.Content.Milestone != nil {
+				milestone := node.Content.Milestone.toModel()
+				item.Milestone = &milestone
+			}
+			if item.Type == "Issue" {
+				node.Content.subIssuesNode.apply(&item)
+				node.Content.dependenciesNode.apply(&item)
+			}
+			if item.Type == "PullRequest" {
+				item.PullRequest = node.Content.pullRequestStatusNode.toModel()
 			}
+			items = append(items, item)
 		}
 
-		item := models.ProjectItem{
-			ID:        node.ID,
-			ContentID: node.Content.ID,
-			Type:      node.Content.TypeName,
-			Title:     node.Content.Title,
-			Body:      node.Content.Body,
-			Number:    node.Content.Number,
-			State:     node.Content.State,
-			URL:       node.Content.URL,
-			CreatedAt: node.Content.CreatedAt,
-			UpdatedAt: node.Content.UpdatedAt,
-			Assignees: assignees,
-			Comments:  comments,
-			Fields:    make(map[string]interface{}),
+		page := response.Node.Items.PageInfo
+		if !page.HasNextPage {
+			break
 		}
-		items = append(items, item)
+		after = page.EndCursor
 	}
 
 	return items, nil
 }
 
 // AddProjectItem adds an item to a project
-func (c *Client) AddProjectItem(input models.CreateItemInput) (*models.ProjectItem, error) {
+func (c *Client) AddProjectItem(ctx context.Context, input models.CreateItemInput) (*models.ProjectItem, error) {
 	mutation := `mutation($input: AddProjectV2ItemByIdInput!) {
 		addProjectV2ItemById(input: $input) {
 			item {
@@ -195,7 +250,7 @@ func (c *Client) AddProjectItem(input models.CreateItemInput) (*models.ProjectIt
 		} `json:"addProjectV2ItemById"`
 	}
 
-	err := c.client.Do(mutation, variables, &response)
+	err := c.mutate(ctx, mutation, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add project item: %w", err)
 	}
@@ -209,15 +264,13 @@ func (c *Client) AddProjectItem(input models.CreateItemInput) (*models.ProjectIt
 
 // CreateDraftIssue creates a draft issue in a project with retry logic
 // Note: assignees cannot be set during creation, use UpdateDraftIssue afterward
-func (c *Client) CreateDraftIssue(input models.CreateItemInput) (*models.ProjectItem, error) {
-	fmt.Fprintf(os.Stderr, "\n>>> CreateDraftIssue called\n")
-	fmt.Fprintf(os.Stderr, "ProjectID: %s\n", input.ProjectID)
-	fmt.Fprintf(os.Stderr, "Title: %s\n", input.Title)
-	
+func (c *Client) CreateDraftIssue(ctx context.Context, input models.CreateItemInput) (*models.ProjectItem, error) {
+	slog.Debug("creating draft issue", "project", input.ProjectID, "title", input.Title)
+
 	var result *models.ProjectItem
 	
 	// Retry wrapper
-	err := apierrors.Retry(func() error {
+	err := apierrors.RetryWithContext(ctx, func() error {
 		mutation := `mutation($input: AddProjectV2DraftIssueInput!) {
 			addProjectV2DraftIssue(input: $input) {
 				projectItem {
@@ -258,11 +311,9 @@ func (c *Client) CreateDraftIssue(input models.CreateItemInput) (*models.Project
 			} `json:"addProjectV2DraftIssue"`
 		}
 
-		if err := c.client.Do(mutation, variables, &response); err != nil {
-			fmt.Fprintf(os.Stderr, "CreateDraftIssue GraphQL error: %v\n", err)
-			// Classify the error
-			classified := apierrors.ClassifyError(err, 0)
-			return classified
+		if err := c.mutate(ctx, mutation, variables, &response); err != nil {
+			slog.Warn("create draft issue failed", "project", input.ProjectID, "err", err)
+			return err
 		}
 
 		result = &models.ProjectItem{
@@ -275,7 +326,7 @@ func (c *Client) CreateDraftIssue(input models.CreateItemInput) (*models.Project
 			Assignees: []string{}, // Will be empty on creation
 		}
 
-		fmt.Fprintf(os.Stderr, "CreateDraftIssue success - ProjectItem.ID: %s, Content.ID: %s\n", result.ID, result.ContentID)
+		slog.Debug("created draft issue", "item", result.ID, "content", result.ContentID)
 		return nil
 	}, apierrors.DefaultRetryConfig())
 
@@ -286,17 +337,14 @@ func (c *Client) CreateDraftIssue(input models.CreateItemInput) (*models.Project
 	return result, nil
 }
 
-// UpdateDraftIssue updates a draft issue with retry logic
-func (c *Client) UpdateDraftIssue(itemID, title, body string, assigneeIDs []string) (*models.ProjectItem, error) {
-	fmt.Fprintf(os.Stderr, "\n>>> UpdateDraftIssue called\n")
-	fmt.Fprintf(os.Stderr, "DraftIssueID: %s\n", itemID)
-	fmt.Fprintf(os.Stderr, "Title: %s\n", title)
-	fmt.Fprintf(os.Stderr, "Body length: %d\n", len(body))
-	fmt.Fprintf(os.Stderr, "AssigneeIDs: %v\n", assigneeIDs)
-	
+// UpdateDraftIssue updates a draft issue with retry logic. Nil assigneeIDs
+// leave its assignees as they are, an empty slice unassigns everyone.
+func (c *Client) UpdateDraftIssue(ctx context.Context, itemID, title, body string, assigneeIDs []string) (*models.ProjectItem, error) {
+	slog.Debug("updating draft issue", "content", itemID, "title", title, "body", body, "assignees", len(assigneeIDs))
+
 	var result *models.ProjectItem
 	
-	err := apierrors.Retry(func() error {
+	err := apierrors.RetryWithContext(ctx, func() error {
 		mutation := `mutation($input: UpdateProjectV2DraftIssueInput!) {
 			updateProjectV2DraftIssue(input: $input) {
 				draftIssue {
@@ -323,11 +371,11 @@ func (c *Client) UpdateDraftIssue(itemID, title, body string, assigneeIDs []stri
 		if body != "" {
 			mutationInput["body"] = body
 		}
-		if len(assigneeIDs) > 0 {
+		// nil leaves the assignees alone, an empty list clears them
+		if assigneeIDs != nil {
 			mutationInput["assigneeIds"] = assigneeIDs
 		}
-		
-		fmt.Fprintf(os.Stderr, "Mutation input: %+v\n", mutationInput)
+
 
 		variables := map[string]interface{}{
 			"input": mutationInput,
@@ -349,9 +397,9 @@ func (c *Client) UpdateDraftIssue(itemID, title, body string, assigneeIDs []stri
 			} `json:"updateProjectV2DraftIssue"`
 		}
 
-		if err := c.client.Do(mutation, variables, &response); err != nil {
-			fmt.Fprintf(os.Stderr, "UpdateDraftIssue GraphQL error: %v\n", err)
-			return apierrors.ClassifyError(err, 0)
+		if err := c.mutate(ctx, mutation, variables, &response); err != nil {
+			slog.Warn("update draft issue failed", "content", itemID, "err", err)
+			return err
 		}
 
 		assignees := make([]string, len(response.UpdateProjectV2DraftIssue.DraftIssue.Assignees.Nodes))
@@ -368,7 +416,7 @@ func (c *Client) UpdateDraftIssue(itemID, title, body string, assigneeIDs []stri
 			Assignees: assignees,
 		}
 
-		fmt.Fprintf(os.Stderr, "UpdateDraftIssue success - ID: %s, Assignees: %v\n", result.ID, result.Assignees)
+		slog.Debug("updated draft issue", "content", result.ID, "assignees", result.Assignees)
 		return nil
 	}, apierrors.DefaultRetryConfig())
 
@@ -380,7 +428,7 @@ func (c *Client) UpdateDraftIssue(itemID, title, body string, assigneeIDs []stri
 }
 
 // DeleteProjectItem removes an item from a project
-func (c *Client) DeleteProjectItem(projectID, itemID string) error {
+func (c *Client) DeleteProjectItem(ctx context.Context, projectID, itemID string) error {
 	mutation := `mutation($input: DeleteProjectV2ItemInput!) {
 		deleteProjectV2Item(input: $input) {
 			deletedItemId
@@ -396,7 +444,7 @@ func (c *Client) DeleteProjectItem(projectID, itemID string) error {
 
 	var response map[string]interface{}
 
-	err := c.client.Do(mutation, variables, &response)
+	err := c.mutate(ctx, mutation, variables, &response)
 	if err != nil {
 		return fmt.Errorf("failed to delete project item: %w", err)
 	}
@@ -405,14 +453,12 @@ func (c *Client) DeleteProjectItem(projectID, itemID string) error {
 }
 
 // ConvertDraftIssueToIssue converts a draft issue to a real GitHub issue with retry logic
-func (c *Client) ConvertDraftIssueToIssue(projectItemID, repositoryID string) (*models.ProjectItem, error) {
-	fmt.Fprintf(os.Stderr, "\n>>> ConvertDraftIssueToIssue called\n")
-	fmt.Fprintf(os.Stderr, "ProjectItemID: %s\n", projectItemID)
-	fmt.Fprintf(os.Stderr, "RepositoryID: %s\n", repositoryID)
-	
+func (c *Client) ConvertDraftIssueToIssue(ctx context.Context, projectItemID, repositoryID string) (*models.ProjectItem, error) {
+	slog.Debug("converting draft issue", "item", projectItemID, "repository", repositoryID)
+
 	var result *models.ProjectItem
 	
-	err := apierrors.Retry(func() error {
+	err := apierrors.RetryWithContext(ctx, func() error {
 		mutation := `mutation($input: ConvertProjectV2DraftIssueItemToIssueInput!) {
 			convertProjectV2DraftIssueItemToIssue(input: $input) {
 				projectV2Item {
@@ -448,10 +494,9 @@ func (c *Client) ConvertDraftIssueToIssue(projectItemID, repositoryID string) (*
 			} `json:"convertProjectV2DraftIssueItemToIssue"`
 		}
 
-		if err := c.client.Do(mutation, variables, &response); err != nil {
-			fmt.Fprintf(os.Stderr, "ConvertDraftIssueToIssue GraphQL error: %v\n", err)
-			fmt.Fprintf(os.Stderr, "Variables sent: %+v\n", variables)
-			return apierrors.ClassifyError(err, 0)
+		if err := c.mutate(ctx, mutation, variables, &response); err != nil {
+			slog.Warn("convert draft issue failed", "item", projectItemID, "repository", repositoryID, "err", err)
+			return err
 		}
 
 		result = &models.ProjectItem{
@@ -462,7 +507,7 @@ func (c *Client) ConvertDraftIssueToIssue(projectItemID, repositoryID string) (*
 			URL:    response.ConvertProjectV2DraftIssueItemToIssue.NewIssue.URL,
 		}
 
-		fmt.Fprintf(os.Stderr, "ConvertDraftIssueToIssue success - Issue #%d: %s\n", result.Number, result.URL)
+		slog.Debug("converted draft issue", "number", result.Number, "url", result.URL)
 		return nil
 	}, apierrors.DefaultRetryConfig())
 
@@ -0,0 +1,204 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// newClient serves backend's data and returns a real client talking to it
+func newClient(t *testing.T, backend *fake.Client) (*api.Client, *fake.Server) {
+	t.Helper()
+	srv := fake.NewServer(backend)
+	t.Cleanup(srv.Close)
+	client, err := srv.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, srv
+}
+
+// countRequests counts the requests for a top-level field, e.g. "query node"
+func countRequests(srv *fake.Server, request string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r == request {
+			n++
+		}
+	}
+	return n
+}
+
+// apiErrorType returns the type of the APIError wrapped in err
+func apiErrorType(t *testing.T, err error) apierrors.ErrorType {
+	t.Helper()
+	var apiErr *apierrors.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error %v is not an APIError", err)
+	}
+	return apiErr.Type
+}
+
+func TestListProjectItemsPages(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	r := backend.AddRepository("octocat", "api")
+	bug := backend.AddLabel(r.ID, "bug", "d73a4a")
+	for i := 0; i < 99; i++ {
+		backend.AddDraft(p.ID, fmt.Sprintf("Draft %d", i), "")
+	}
+	// The last item of the first page has labels, the first of the second none
+	issue := backend.AddIssue(p.ID, r.ID, "Crash on start", "")
+	if err := backend.AddLabels(context.Background(), issue.ContentID, []string{bug.ID}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 99; i < 150; i++ {
+		backend.AddDraft(p.ID, fmt.Sprintf("Draft %d", i), "")
+	}
+	client, srv := newClient(t, backend)
+
+	items, err := client.ListProjectItems(context.Background(), p.ID, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 151 {
+		t.Fatalf("got %d items, want 151", len(items))
+	}
+	if items[99].ID != issue.ID || len(items[99].Labels) != 1 || items[99].Labels[0].Name != "bug" {
+		t.Errorf("items[99] = %+v, want the labelled issue", items[99])
+	}
+	if items[100].Title != "Draft 99" || len(items[100].Labels) != 0 {
+		t.Errorf("items[100] = %+v, want Draft 99 without labels", items[100])
+	}
+	if items[150].Title != "Draft 149" {
+		t.Errorf("last item = %q, want Draft 149", items[150].Title)
+	}
+	if n := countRequests(srv, "query node"); n != 2 {
+		t.Errorf("made %d requests, want 2 pages", n)
+	}
+}
+
+func TestListProjectItemsStopsAtFirst(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	for i := 0; i < 150; i++ {
+		backend.AddDraft(p.ID, fmt.Sprintf("Draft %d", i), "")
+	}
+	client, srv := newClient(t, backend)
+
+	items, err := client.ListProjectItems(context.Background(), p.ID, 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 120 || items[119].Title != "Draft 119" {
+		t.Errorf("got %d items ending %q, want 120 ending Draft 119", len(items), items[len(items)-1].Title)
+	}
+	if n := countRequests(srv, "query node"); n != 2 {
+		t.Errorf("made %d requests, want 2 pages", n)
+	}
+}
+
+func TestListProjectItemsRetriesServerErrors(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	backend.AddDraft(p.ID, "Write docs", "")
+	client, srv := newClient(t, backend)
+	srv.FailNext("node", fake.Failure{Status: 502})
+
+	items, err := client.ListProjectItems(context.Background(), p.ID, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Errorf("got %d items, want 1", len(items))
+	}
+	if n := countRequests(srv, "query node"); n != 2 {
+		t.Errorf("made %d requests, want the failed one retried once", n)
+	}
+}
+
+func TestListProjectItemsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure fake.Failure
+		want    apierrors.ErrorType
+	}{
+		{"forbidden", fake.Failure{Type: "FORBIDDEN", Message: "Resource not accessible by integration"}, apierrors.ErrorTypePermission},
+		{"not found", fake.Failure{Type: "NOT_FOUND", Message: "Could not resolve to a node"}, apierrors.ErrorTypeValidation},
+		{"unauthorized", fake.Failure{Status: 401, Message: "Bad credentials"}, apierrors.ErrorTypePermission},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := fake.New("octocat")
+			p := backend.AddProject("octocat", "Roadmap")
+			client, srv := newClient(t, backend)
+			srv.FailNext("node", tt.failure)
+
+			_, err := client.ListProjectItems(context.Background(), p.ID, 100)
+
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			if got := apiErrorType(t, err); got != tt.want {
+				t.Errorf("type = %v, want %v", got, tt.want)
+			}
+			if n := countRequests(srv, "query node"); n != 1 {
+				t.Errorf("made %d requests, want no retry", n)
+			}
+		})
+	}
+}
+
+func TestDeleteProjectItemIsNotRetried(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	draft := backend.AddDraft(p.ID, "Write docs", "")
+	client, srv := newClient(t, backend)
+	srv.FailNext("deleteProjectV2Item", fake.Failure{Status: 502})
+
+	err := client.DeleteProjectItem(context.Background(), p.ID, draft.ID)
+
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if got := apiErrorType(t, err); got != apierrors.ErrorTypeRetryable {
+		t.Errorf("type = %v, want retryable", got)
+	}
+	if n := countRequests(srv, "mutation deleteProjectV2Item"); n != 1 {
+		t.Errorf("made %d requests, want a single attempt", n)
+	}
+	if items := backend.Items(p.ID); len(items) != 1 {
+		t.Errorf("items = %+v, want the draft kept", items)
+	}
+}
+
+func TestCreateDraftIssueValidation(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	client, srv := newClient(t, backend)
+	srv.FailNext("addProjectV2DraftIssue", fake.Failure{Type: "UNPROCESSABLE", Message: "Title is invalid"})
+
+	_, err := client.CreateDraftIssue(context.Background(), models.CreateItemInput{ProjectID: p.ID, Title: "Write docs"})
+
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if got := apiErrorType(t, err); got != apierrors.ErrorTypeValidation {
+		t.Errorf("type = %v, want validation", got)
+	}
+	if n := countRequests(srv, "mutation addProjectV2DraftIssue"); n != 1 {
+		t.Errorf("made %d requests, want a single attempt", n)
+	}
+	if items := backend.Items(p.ID); len(items) != 0 {
+		t.Errorf("items = %+v, want none", items)
+	}
+}
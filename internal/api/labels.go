@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// labelNode is the GraphQL shape of a Label
+type labelNode struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func (n labelNode) toModel() models.Label {
+	return models.Label{ID: n.ID, Name: n.Name, Color: n.Color}
+}
+
+// milestoneNode is the GraphQL shape of a Milestone
+type milestoneNode struct {
+	ID     string    `json:"id"`
+	Title  string    `json:"title"`
+	Number int       `json:"number"`
+	DueOn  time.Time `json:"dueOn"`
+}
+
+func (n milestoneNode) toModel() models.Milestone {
+	return models.Milestone{ID: n.ID, Title: n.Title, Number: n.Number, DueOn: n.DueOn}
+}
+
+// ListRepositoryLabels retrieves a repository's labels, sorted by name
+func (c *Client) ListRepositoryLabels(ctx context.Context, repositoryID string) ([]models.Label, error) {
+	query := `query($id: ID!) {
+		node(id: $id) {
+			... on Repository {
+				labels(first: 100, orderBy: {field: NAME, direction: ASC}) {
+					nodes {
+						id
+						name
+						color
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id": repositoryID,
+	}
+
+	var response struct {
+		Node struct {
+			Labels struct {
+				Nodes []labelNode `json:"nodes"`
+			} `json:"labels"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	labels := make([]models.Label, len(response.Node.Labels.Nodes))
+	for i, node := range response.Node.Labels.Nodes {
+		labels[i] = node.toModel()
+	}
+	return labels, nil
+}
+
+// ListRepositoryMilestones retrieves a repository's open milestones, soonest due first
+func (c *Client) ListRepositoryMilestones(ctx context.Context, repositoryID string) ([]models.Milestone, error) {
+	query := `query($id: ID!) {
+		node(id: $id) {
+			... on Repository {
+				milestones(first: 100, states: [OPEN], orderBy: {field: DUE_DATE, direction: ASC}) {
+					nodes {
+						id
+						title
+						number
+						dueOn
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id": repositoryID,
+	}
+
+	var response struct {
+		Node struct {
+			Milestones struct {
+				Nodes []milestoneNode `json:"nodes"`
+			} `json:"milestones"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	milestones := make([]models.Milestone, len(response.Node.Milestones.Nodes))
+	for i, node := range response.Node.Milestones.Nodes {
+		milestones[i] = node.toModel()
+	}
+	return milestones, nil
+}
+
+// AddLabels adds labels to an issue or pull request, keeping its other labels
+func (c *Client) AddLabels(ctx context.Context, labelableID string, labelIDs []string) error {
+	mutation := `mutation($input: AddLabelsToLabelableInput!) {
+		addLabelsToLabelable(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"labelableId": labelableID,
+			"labelIds":    labelIDs,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveLabels removes labels from an issue or pull request
+func (c *Client) RemoveLabels(ctx context.Context, labelableID string, labelIDs []string) error {
+	mutation := `mutation($input: RemoveLabelsFromLabelableInput!) {
+		removeLabelsFromLabelable(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"labelableId": labelableID,
+			"labelIds":    labelIDs,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to remove labels: %w", err)
+	}
+
+	return nil
+}
+
+// SetIssueMilestone puts an issue in a milestone. An empty milestoneID
+// removes it from its milestone.
+func (c *Client) SetIssueMilestone(ctx context.Context, issueID, milestoneID string) error {
+	mutation := `mutation($input: UpdateIssueInput!) {
+		updateIssue(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"id":          issueID,
+			"milestoneId": nullIfEmpty(milestoneID),
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to set milestone: %w", err)
+	}
+
+	return nil
+}
+
+// SetPullRequestMilestone puts a pull request in a milestone. An empty
+// milestoneID removes it from its milestone.
+func (c *Client) SetPullRequestMilestone(ctx context.Context, pullRequestID, milestoneID string) error {
+	mutation := `mutation($input: UpdatePullRequestInput!) {
+		updatePullRequest(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId": pullRequestID,
+			"milestoneId":   nullIfEmpty(milestoneID),
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to set milestone: %w", err)
+	}
+
+	return nil
+}
+
+// nullIfEmpty turns an empty ID into a GraphQL null, which clears the value
+func nullIfEmpty(id string) interface{} {
+	if id == "" {
+		return nil
+	}
+	return id
+}
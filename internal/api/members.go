@@ -1,12 +1,13 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 // GetOrgMembers retrieves all members of an organization
-func (c *Client) GetOrgMembers(org string, limit int) ([]string, error) {
+func (c *Client) GetOrgMembers(ctx context.Context, org string, limit int) ([]string, error) {
 	query := `query($org: String!, $first: Int!) {
 		organization(login: $org) {
 			membersWithRole(first: $first) {
@@ -32,7 +33,7 @@ func (c *Client) GetOrgMembers(org string, limit int) ([]string, error) {
 		} `json:"organization"`
 	}
 
-	err := c.client.Do(query, variables, &response)
+	err := c.query(ctx, query, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get org members: %w", err)
 	}
@@ -46,13 +47,13 @@ func (c *Client) GetOrgMembers(org string, limit int) ([]string, error) {
 }
 
 // SearchOrgMembers searches for organization members by username
-func (c *Client) SearchOrgMembers(org string, query string, limit int) ([]string, error) {
+func (c *Client) SearchOrgMembers(ctx context.Context, org string, query string, limit int) ([]string, error) {
 	if query == "" {
 		return []string{}, nil
 	}
 
 	// Get all org members (up to 100)
-	members, err := c.GetOrgMembers(org, 100)
+	members, err := c.GetOrgMembers(ctx, org, 100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get org members: %w", err)
 	}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// UpdateProject updates a project's settings
+func (c *Client) UpdateProject(ctx context.Context, input models.UpdateProjectInput) error {
+	mutation := `mutation($input: UpdateProjectV2Input!) {
+		updateProjectV2(input: $input) {
+			projectV2 {
+				id
+			}
+		}
+	}`
+
+	mutationInput := map[string]interface{}{
+		"projectId": input.ProjectID,
+	}
+
+	if input.Title != nil {
+		mutationInput["title"] = *input.Title
+	}
+	if input.ShortDescription != nil {
+		mutationInput["shortDescription"] = *input.ShortDescription
+	}
+	if input.Public != nil {
+		mutationInput["public"] = *input.Public
+	}
+	if input.Closed != nil {
+		mutationInput["closed"] = *input.Closed
+	}
+
+	variables := map[string]interface{}{
+		"input": mutationInput,
+	}
+
+	var response map[string]interface{}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
+
+// CopyProject creates a new project from an existing one, keeping its fields and views
+func (c *Client) CopyProject(ctx context.Context, input models.CopyProjectInput) (*models.Project, error) {
+	mutation := `mutation($input: CopyProjectV2Input!) {
+		copyProjectV2(input: $input) {
+			projectV2 {
+				id
+				number
+				title
+				shortDescription
+				public
+				url
+				createdAt
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"projectId":          input.SourceProjectID,
+			"ownerId":            input.OwnerID,
+			"title":              input.Title,
+			"includeDraftIssues": input.IncludeDraftIssues,
+		},
+	}
+
+	var response struct {
+		CopyProjectV2 struct {
+			ProjectV2 struct {
+				ID               string    `json:"id"`
+				Number           int       `json:"number"`
+				Title            string    `json:"title"`
+				ShortDescription string    `json:"shortDescription"`
+				Public           bool      `json:"public"`
+				URL              string    `json:"url"`
+				CreatedAt        time.Time `json:"createdAt"`
+			} `json:"projectV2"`
+		} `json:"copyProjectV2"`
+	}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy project: %w", err)
+	}
+
+	project := &models.Project{
+		ID:               response.CopyProjectV2.ProjectV2.ID,
+		Number:           response.CopyProjectV2.ProjectV2.Number,
+		Title:            response.CopyProjectV2.ProjectV2.Title,
+		ShortDescription: response.CopyProjectV2.ProjectV2.ShortDescription,
+		Public:           response.CopyProjectV2.ProjectV2.Public,
+		URL:              response.CopyProjectV2.ProjectV2.URL,
+		CreatedAt:        response.CopyProjectV2.ProjectV2.CreatedAt,
+	}
+
+	return project, nil
+}
+
+// SetProjectTemplate marks or unmarks a project as a template for its owner.
+// GitHub only supports templates for organization projects.
+func (c *Client) SetProjectTemplate(ctx context.Context, projectID string, template bool) error {
+	mutation := `mutation($input: MarkProjectV2AsTemplateInput!) {
+		markProjectV2AsTemplate(input: $input) {
+			projectV2 {
+				id
+			}
+		}
+	}`
+	if !template {
+		mutation = `mutation($input: UnmarkProjectV2AsTemplateInput!) {
+			unmarkProjectV2AsTemplate(input: $input) {
+				projectV2 {
+					id
+				}
+			}
+		}`
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"projectId": projectID,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to update project template status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// pullRequestStatusFields selects what a PullRequest's status is made of
+const pullRequestStatusFields = `isDraft
+								reviewDecision
+								mergeable
+								headRefName
+								baseRefName
+								reviewRequests(first: 10) {
+									nodes {
+										requestedReviewer {
+											... on User {
+												login
+											}
+											... on Team {
+												combinedSlug
+											}
+										}
+									}
+								}
+								commits(last: 1) {
+									nodes {
+										commit {
+											statusCheckRollup {
+												state
+											}
+										}
+									}
+								}`
+
+// pullRequestStatusNode is the GraphQL shape of pullRequestStatusFields
+type pullRequestStatusNode struct {
+	IsDraft        bool   `json:"isDraft"`
+	ReviewDecision string `json:"reviewDecision"`
+	Mergeable      string `json:"mergeable"`
+	HeadRefName    string `json:"headRefName"`
+	BaseRefName    string `json:"baseRefName"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Login        string `json:"login"`
+				CombinedSlug string `json:"combinedSlug"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+func (n pullRequestStatusNode) toModel() *models.PullRequestStatus {
+	status := &models.PullRequestStatus{
+		IsDraft:        n.IsDraft,
+		ReviewDecision: n.ReviewDecision,
+		Mergeable:      n.Mergeable,
+		HeadRef:        n.HeadRefName,
+		BaseRef:        n.BaseRefName,
+	}
+	for _, request := range n.ReviewRequests.Nodes {
+		if reviewer := request.RequestedReviewer; reviewer.Login != "" {
+			status.Reviewers = append(status.Reviewers, reviewer.Login)
+		} else if reviewer.CombinedSlug != "" {
+			status.Reviewers = append(status.Reviewers, reviewer.CombinedSlug)
+		}
+	}
+	// The rollup of the head commit is the pull request's CI status
+	for _, commit := range n.Commits.Nodes {
+		if rollup := commit.Commit.StatusCheckRollup; rollup != nil {
+			status.Checks = rollup.State
+		}
+	}
+	return status
+}
+
+// GetPullRequestStatus retrieves a pull request's state and its review and CI status
+func (c *Client) GetPullRequestStatus(ctx context.Context, pullRequestID string) (string, *models.PullRequestStatus, error) {
+	query := `query($id: ID!) {
+		node(id: $id) {
+			... on PullRequest {
+				state
+				` + pullRequestStatusFields + `
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id": pullRequestID,
+	}
+
+	var response struct {
+		Node struct {
+			State string `json:"state"`
+			pullRequestStatusNode
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get pull request status: %w", err)
+	}
+
+	return response.Node.State, response.Node.pullRequestStatusNode.toModel(), nil
+}
+
+// RequestReviews asks users and teams to review a pull request, keeping the
+// reviewers already requested
+func (c *Client) RequestReviews(ctx context.Context, pullRequestID string, userIDs, teamIDs []string) error {
+	mutation := `mutation($input: RequestReviewsInput!) {
+		requestReviews(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId": pullRequestID,
+			"userIds":       userIDs,
+			"teamIds":       teamIDs,
+			"union":         true,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to request reviews: %w", err)
+	}
+
+	return nil
+}
+
+// SubmitReview reviews a pull request. Event is "APPROVE", "COMMENT" or
+// "REQUEST_CHANGES"; the latter two need a body.
+func (c *Client) SubmitReview(ctx context.Context, pullRequestID, event, body string) error {
+	mutation := `mutation($input: AddPullRequestReviewInput!) {
+		addPullRequestReview(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	input := map[string]interface{}{
+		"pullRequestId": pullRequestID,
+		"event":         event,
+	}
+	if body != "" {
+		input["body"] = body
+	}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response map[string]interface{}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to submit review: %w", err)
+	}
+
+	return nil
+}
+
+// MarkReadyForReview takes a pull request out of draft
+func (c *Client) MarkReadyForReview(ctx context.Context, pullRequestID string) error {
+	mutation := `mutation($input: MarkPullRequestReadyForReviewInput!) {
+		markPullRequestReadyForReview(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId": pullRequestID,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to mark ready for review: %w", err)
+	}
+
+	return nil
+}
+
+// MergePullRequest merges a pull request. Method is "MERGE", "SQUASH" or
+// "REBASE". Merges the branch's protection rules refuse fail with an
+// APIError of type ErrorTypeBranchProtection.
+func (c *Client) MergePullRequest(ctx context.Context, pullRequestID, method string) error {
+	mutation := `mutation($input: MergePullRequestInput!) {
+		mergePullRequest(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId": pullRequestID,
+			"mergeMethod":   method,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to merge pull request: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+func TestMergePullRequest(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	r := backend.AddRepository("octocat", "api")
+	pr := backend.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+	backend.SetPullRequestStatus(pr.ContentID, models.PullRequestStatus{ReviewDecision: "APPROVED", Checks: "SUCCESS", Mergeable: "MERGEABLE"})
+	client, _ := newClient(t, backend)
+
+	if err := client.MergePullRequest(context.Background(), pr.ContentID, "SQUASH"); err != nil {
+		t.Fatal(err)
+	}
+
+	if items := backend.Items(p.ID); items[0].State != "MERGED" {
+		t.Errorf("state = %s, want MERGED", items[0].State)
+	}
+}
+
+func TestMergePullRequestBranchProtection(t *testing.T) {
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	r := backend.AddRepository("octocat", "api")
+	pr := backend.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+	backend.SetPullRequestStatus(pr.ContentID, models.PullRequestStatus{Checks: "FAILURE", Mergeable: "MERGEABLE"})
+	client, _ := newClient(t, backend)
+
+	err := client.MergePullRequest(context.Background(), pr.ContentID, "MERGE")
+
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if got := apiErrorType(t, err); got != apierrors.ErrorTypeBranchProtection {
+		t.Errorf("type = %v, want branch protection", got)
+	}
+}
+
+// A merge or review GitHub accepted before the connection dropped must not
+// be sent again, so neither is retried
+func TestPullRequestMutationsAreNotRetried(t *testing.T) {
+	tests := []struct {
+		field string
+		send  func(client *api.Client, pullRequestID string) error
+	}{
+		{"mergePullRequest", func(client *api.Client, pullRequestID string) error {
+			return client.MergePullRequest(context.Background(), pullRequestID, "MERGE")
+		}},
+		{"addPullRequestReview", func(client *api.Client, pullRequestID string) error {
+			return client.SubmitReview(context.Background(), pullRequestID, "APPROVE", "")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			backend := fake.New("octocat")
+			p := backend.AddProject("octocat", "Roadmap")
+			r := backend.AddRepository("octocat", "api")
+			pr := backend.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+			client, srv := newClient(t, backend)
+			srv.FailNext(tt.field, fake.Failure{Status: 502})
+
+			err := tt.send(client, pr.ContentID)
+
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			if got := apiErrorType(t, err); got != apierrors.ErrorTypeRetryable {
+				t.Errorf("type = %v, want retryable", got)
+			}
+			if n := countRequests(srv, "mutation "+tt.field); n != 1 {
+				t.Errorf("made %d requests, want a single attempt", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+)
+
+const (
+	// backgroundReserve is the share of the hourly budget kept for interactive
+	// requests; background work waits for the reset once the budget drops below it
+	backgroundReserve = 0.2
+
+	// secondaryBackoff is how long to back off after a secondary rate limit
+	// that came without a Retry-After header. It doubles on repeated hits.
+	secondaryBackoff    = time.Minute
+	maxSecondaryBackoff = 15 * time.Minute
+)
+
+// rateLimitSelection is added to every query so GitHub reports its point cost
+const rateLimitSelection = "\n\trateLimit {\n\t\tlimit\n\t\tcost\n\t\tremaining\n\t\tused\n\t\tresetAt\n\t}\n"
+
+// RateLimit is the most recently observed GraphQL rate limit budget
+type RateLimit struct {
+	Limit        int
+	Remaining    int
+	Used         int
+	Cost         int // Point cost of the last query
+	ResetAt      time.Time
+	BackoffUntil time.Time // Set while backing off from a secondary rate limit
+	Known        bool      // False until the first response has been seen
+}
+
+// Low reports whether the budget has dropped into the share reserved for interactive use
+func (r RateLimit) Low() bool {
+	return r.Known && r.Limit > 0 && float64(r.Remaining) < float64(r.Limit)*backgroundReserve
+}
+
+// Priority tells the rate limiter how urgently a request is needed
+type Priority int
+
+const (
+	// PriorityInteractive requests were triggered by the user and go out immediately
+	PriorityInteractive Priority = iota
+	// PriorityBackground requests (auto-refresh, bulk operations) wait while the budget is low
+	PriorityBackground
+)
+
+type priorityKey struct{}
+
+// WithPriority marks every request made with ctx as having the given priority
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+func priorityOf(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityInteractive
+}
+
+// rateLimiter records the budget reported by each response and holds back
+// requests when it runs out
+type rateLimiter struct {
+	mu            sync.Mutex
+	status        RateLimit
+	secondaryHits int
+}
+
+// Status returns a snapshot of the current budget
+func (r *rateLimiter) Status() RateLimit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// wait blocks until a request of the given priority may be sent. Interactive
+// requests only wait out a secondary limit backoff and fail fast when the
+// hourly budget is exhausted; background requests also wait while it is low.
+func (r *rateLimiter) wait(ctx context.Context, priority Priority) error {
+	for {
+		r.mu.Lock()
+		status := r.status
+		r.mu.Unlock()
+
+		now := time.Now()
+		var until time.Time
+		if status.BackoffUntil.After(now) {
+			until = status.BackoffUntil
+		}
+		if status.Known && status.ResetAt.After(now) {
+			exhausted := status.Remaining <= 0
+			if exhausted && priority == PriorityInteractive {
+				err := apierrors.RateLimitError("GitHub API rate limit exceeded", status.ResetAt.Sub(now))
+				// Retrying before the reset would only fail again
+				err.Retryable = false
+				return err
+			}
+			if (exhausted || status.Low()) && priority == PriorityBackground && status.ResetAt.After(until) {
+				until = status.ResetAt
+			}
+		}
+		if until.IsZero() {
+			return nil
+		}
+
+		timer := time.NewTimer(time.Until(until))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// observe updates the budget from a response's headers and, for successful
+// queries, the rateLimit object in its body
+func (r *rateLimiter) observe(resp *http.Response, body []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+		r.status.Limit = limit
+		r.status.Known = true
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		r.status.Remaining = remaining
+	}
+	if used, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Used")); err == nil {
+		r.status.Used = used
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		r.status.ResetAt = time.Unix(reset, 0)
+	}
+
+	if isSecondaryRateLimit(resp, body) {
+		backoff := retryAfterFromHeaders(resp.Header)
+		if backoff <= 0 {
+			backoff = secondaryBackoff << r.secondaryHits
+			if backoff > maxSecondaryBackoff {
+				backoff = maxSecondaryBackoff
+			}
+		}
+		r.secondaryHits++
+		r.status.BackoffUntil = time.Now().Add(backoff)
+		slog.Warn("hit secondary rate limit, backing off", "backoff", backoff, "hits", r.secondaryHits)
+		return
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+	r.secondaryHits = 0
+
+	var payload struct {
+		Data struct {
+			RateLimit *struct {
+				Limit     int       `json:"limit"`
+				Cost      int       `json:"cost"`
+				Remaining int       `json:"remaining"`
+				Used      int       `json:"used"`
+				ResetAt   time.Time `json:"resetAt"`
+			} `json:"rateLimit"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Data.RateLimit == nil {
+		return
+	}
+	rl := payload.Data.RateLimit
+	r.status.Limit = rl.Limit
+	r.status.Cost = rl.Cost
+	r.status.Remaining = rl.Remaining
+	r.status.Used = rl.Used
+	r.status.ResetAt = rl.ResetAt
+	r.status.Known = true
+}
+
+// isSecondaryRateLimit detects GitHub's abuse-prevention limits, which come
+// back as a 403 or 429 while the hourly budget still has points left
+func isSecondaryRateLimit(resp *http.Response, body []byte) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	if resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
+
+// rateLimitTransport feeds every response through the rate limiter
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context(), priorityOf(req.Context())); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		slog.Debug("github request failed", "err", err, "duration", time.Since(start))
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.limiter.observe(resp, body)
+	slog.Debug("github request", "status", resp.StatusCode, "bytes", len(body), "duration", time.Since(start).Round(time.Millisecond))
+	return resp, nil
+}
+
+// withRateLimit adds the rateLimit selection to a query document
+func withRateLimit(query string) string {
+	end := strings.LastIndex(query, "}")
+	if end < 0 {
+		return query
+	}
+	return query[:end] + rateLimitSelection + query[end:]
+}
@@ -1,13 +1,14 @@
 package api
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/thomaskoefod/githubProjectTUI/internal/models"
 )
 
 // GetRepositoryNodeID retrieves the node ID for a repository
-func (c *Client) GetRepositoryNodeID(owner, name string) (string, error) {
+func (c *Client) GetRepositoryNodeID(ctx context.Context, owner, name string) (string, error) {
 	query := `query($owner: String!, $name: String!) {
 		repository(owner: $owner, name: $name) {
 			id
@@ -25,7 +26,7 @@ func (c *Client) GetRepositoryNodeID(owner, name string) (string, error) {
 		} `json:"repository"`
 	}
 
-	err := c.client.Do(query, variables, &response)
+	err := c.query(ctx, query, variables, &response)
 	if err != nil {
 		return "", fmt.Errorf("failed to get repository node ID: %w", err)
 	}
@@ -34,7 +35,7 @@ func (c *Client) GetRepositoryNodeID(owner, name string) (string, error) {
 }
 
 // ListRepositories retrieves repositories accessible to the user or organization
-func (c *Client) ListRepositories(owner string, isUser bool) ([]models.Repository, error) {
+func (c *Client) ListRepositories(ctx context.Context, owner string, isUser bool) ([]models.Repository, error) {
 	var query string
 	
 	if isUser {
@@ -104,7 +105,7 @@ func (c *Client) ListRepositories(owner string, isUser bool) ([]models.Repositor
 		} `json:"organization,omitempty"`
 	}
 
-	err := c.client.Do(query, variables, &response)
+	err := c.query(ctx, query, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list repositories: %w", err)
 	}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+)
+
+// query runs a read-only GraphQL request. Queries are safe to repeat, so
+// retryable failures (network errors, 5xx, rate limits) are retried. The
+// query also selects rateLimit so the client can track each query's cost.
+func (c *Client) query(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	return apierrors.RetryWithContext(ctx, func() error {
+		return c.do(ctx, withRateLimit(query), variables, response)
+	}, apierrors.DefaultRetryConfig())
+}
+
+// mutate runs a GraphQL mutation once. Mutations are not idempotent, so
+// callers that know a retry is safe wrap the call in RetryWithContext themselves.
+func (c *Client) mutate(ctx context.Context, mutation string, variables map[string]interface{}, response interface{}) error {
+	return c.do(ctx, mutation, variables, response)
+}
+
+// do sends a GraphQL request and converts any failure into a classified *APIError.
+// Context cancellation is passed through untouched so callers can recognise it.
+func (c *Client) do(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	err := c.client.DoWithContext(ctx, query, variables, response)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	classified := classifyResponseError(err)
+	slog.Debug("graphql request failed", "type", classified.Type, "status", classified.HTTPStatus, "graphql_type", classified.GraphQLType, "err", err)
+	return classified
+}
+
+// classifyResponseError extracts the HTTP status and GraphQL error type from a
+// go-gh error and classifies it
+func classifyResponseError(err error) *apierrors.APIError {
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		classified := apierrors.ClassifyError(err, httpErr.StatusCode)
+		classified.HTTPStatus = httpErr.StatusCode
+		// GitHub reports an exhausted primary rate limit as a 403 with no requests remaining
+		if httpErr.Headers.Get("X-RateLimit-Remaining") == "0" {
+			classified = apierrors.RateLimitError("GitHub API rate limit exceeded", 0)
+			classified.OriginalErr = err
+			classified.HTTPStatus = httpErr.StatusCode
+		}
+		if classified.Type == apierrors.ErrorTypeRateLimit {
+			if retryAfter := retryAfterFromHeaders(httpErr.Headers); retryAfter > 0 {
+				classified.RetryAfter = retryAfter
+			}
+		}
+		return classified
+	}
+
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) && len(gqlErr.Errors) > 0 {
+		first := gqlErr.Errors[0]
+		classified := apierrors.ClassifyGraphQLError(apierrors.GraphQLError{
+			Type:       first.Type,
+			Message:    first.Message,
+			Path:       first.Path,
+			Extensions: first.Extensions,
+		})
+		classified.GraphQLType = first.Type
+		if classified.OriginalErr == nil {
+			classified.OriginalErr = err
+		}
+		return classified
+	}
+
+	return apierrors.ClassifyError(err, 0)
+}
+
+// retryAfterFromHeaders reads how long to back off from the Retry-After or
+// X-RateLimit-Reset response headers
+func retryAfterFromHeaders(headers http.Header) time.Duration {
+	if seconds, err := strconv.Atoi(headers.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
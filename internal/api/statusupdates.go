@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// ListStatusUpdates retrieves a project's status updates, newest first
+func (c *Client) ListStatusUpdates(ctx context.Context, projectID string, first int) ([]models.StatusUpdate, error) {
+	query := `query($id: ID!, $first: Int!) {
+		node(id: $id) {
+			... on ProjectV2 {
+				statusUpdates(first: $first, orderBy: {field: CREATED_AT, direction: DESC}) {
+					nodes {
+						id
+						status
+						body
+						startDate
+						targetDate
+						createdAt
+						creator {
+							login
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id":    projectID,
+		"first": first,
+	}
+
+	var response struct {
+		Node struct {
+			StatusUpdates struct {
+				Nodes []statusUpdateNode `json:"nodes"`
+			} `json:"statusUpdates"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status updates: %w", err)
+	}
+
+	updates := make([]models.StatusUpdate, len(response.Node.StatusUpdates.Nodes))
+	for i, node := range response.Node.StatusUpdates.Nodes {
+		updates[i] = node.toModel()
+	}
+
+	return updates, nil
+}
+
+// CreateStatusUpdate posts a new status update to a project
+func (c *Client) CreateStatusUpdate(ctx context.Context, input models.CreateStatusUpdateInput) (*models.StatusUpdate, error) {
+	mutation := `mutation($input: CreateProjectV2StatusUpdateInput!) {
+		createProjectV2StatusUpdate(input: $input) {
+			statusUpdate {
+				id
+				status
+				body
+				startDate
+				targetDate
+				createdAt
+				creator {
+					login
+				}
+			}
+		}
+	}`
+
+	mutationInput := map[string]interface{}{
+		"projectId": input.ProjectID,
+		"status":    input.Status,
+	}
+
+	if input.Body != "" {
+		mutationInput["body"] = input.Body
+	}
+	if input.StartDate != "" {
+		mutationInput["startDate"] = input.StartDate
+	}
+	if input.TargetDate != "" {
+		mutationInput["targetDate"] = input.TargetDate
+	}
+
+	variables := map[string]interface{}{
+		"input": mutationInput,
+	}
+
+	var response struct {
+		CreateProjectV2StatusUpdate struct {
+			StatusUpdate statusUpdateNode `json:"statusUpdate"`
+		} `json:"createProjectV2StatusUpdate"`
+	}
+
+	err := c.mutate(ctx, mutation, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create status update: %w", err)
+	}
+
+	update := response.CreateProjectV2StatusUpdate.StatusUpdate.toModel()
+	return &update, nil
+}
+
+// statusUpdateNode is the GraphQL shape of a ProjectV2StatusUpdate
+type statusUpdateNode struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	Body       string    `json:"body"`
+	StartDate  string    `json:"startDate"`
+	TargetDate string    `json:"targetDate"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Creator    struct {
+		Login string `json:"login"`
+	} `json:"creator"`
+}
+
+func (n statusUpdateNode) toModel() models.StatusUpdate {
+	return models.StatusUpdate{
+		ID:         n.ID,
+		Status:     n.Status,
+		Body:       n.Body,
+		StartDate:  n.StartDate,
+		TargetDate: n.TargetDate,
+		Author:     n.Creator.Login,
+		CreatedAt:  n.CreatedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// issueRefFields selects what an IssueRef is made of
+const issueRefFields = `id
+									number
+									title
+									state
+									url
+									repository {
+										nameWithOwner
+									}`
+
+// subIssueFields selects an Issue's parent, sub-issues and their summary
+const subIssueFields = `parent {
+									` + issueRefFields + `
+								}
+								subIssues(first: 50) {
+									nodes {
+										` + issueRefFields + `
+									}
+								}
+								subIssuesSummary {
+									total
+									completed
+								}`
+
+// issueRefNode is the GraphQL shape of issueRefFields
+type issueRefNode struct {
+	ID         string `json:"id"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	URL        string `json:"url"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+}
+
+func (n issueRefNode) toModel() models.IssueRef {
+	return models.IssueRef{
+		ID:         n.ID,
+		Number:     n.Number,
+		Title:      n.Title,
+		State:      n.State,
+		Repository: n.Repository.NameWithOwner,
+		URL:        n.URL,
+	}
+}
+
+// subIssuesNode is the GraphQL shape of subIssueFields
+type subIssuesNode struct {
+	Parent    *issueRefNode `json:"parent"`
+	SubIssues struct {
+		Nodes []issueRefNode `json:"nodes"`
+	} `json:"subIssues"`
+	SubIssuesSummary struct {
+		Total     int `json:"total"`
+		Completed int `json:"completed"`
+	} `json:"subIssuesSummary"`
+}
+
+// apply sets an item's parent, sub-issues and summary
+func (n subIssuesNode) apply(item *models.ProjectItem) {
+	if n.Parent != nil {
+		parent := n.Parent.toModel()
+		item.Parent = &parent
+	}
+	for _, node := range n.SubIssues.Nodes {
+		item.SubIssues = append(item.SubIssues, node.toModel())
+	}
+	item.SubIssuesSummary = models.SubIssuesSummary{
+		Total:     n.SubIssuesSummary.Total,
+		Completed: n.SubIssuesSummary.Completed,
+	}
+}
+
+// AddSubIssue makes an issue a sub-issue of another. With replaceParent set
+// an issue that already has a parent is moved; otherwise that fails.
+func (c *Client) AddSubIssue(ctx context.Context, issueID, subIssueID string, replaceParent bool) error {
+	mutation := `mutation($input: AddSubIssueInput!) {
+		addSubIssue(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"issueId":       issueID,
+			"subIssueId":    subIssueID,
+			"replaceParent": replaceParent,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to add sub-issue: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveSubIssue makes a sub-issue a top-level issue again
+func (c *Client) RemoveSubIssue(ctx context.Context, issueID, subIssueID string) error {
+	mutation := `mutation($input: RemoveSubIssueInput!) {
+		removeSubIssue(input: $input) {
+			clientMutationId
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"issueId":    issueID,
+			"subIssueId": subIssueID,
+		},
+	}
+
+	var response map[string]interface{}
+
+	err := apierrors.RetryWithContext(ctx, func() error {
+		return c.mutate(ctx, mutation, variables, &response)
+	}, apierrors.DefaultRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to remove sub-issue: %w", err)
+	}
+
+	return nil
+}
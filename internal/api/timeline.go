@@ -0,0 +1,272 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// timelineItemFields selects the timeline events shown in the item detail view.
+// It is shared by the Issue and PullRequest fragments of every timeline query.
+const timelineItemFields = `
+	__typename
+	... on LabeledEvent {
+		actor { login }
+		createdAt
+		label { name }
+	}
+	... on UnlabeledEvent {
+		actor { login }
+		createdAt
+		label { name }
+	}
+	... on AssignedEvent {
+		actor { login }
+		createdAt
+		assignee { ... on User { login } }
+	}
+	... on UnassignedEvent {
+		actor { login }
+		createdAt
+		assignee { ... on User { login } }
+	}
+	... on CrossReferencedEvent {
+		actor { login }
+		createdAt
+		source {
+			__typename
+			... on Issue { number title }
+			... on PullRequest { number title }
+		}
+	}
+	... on ClosedEvent {
+		actor { login }
+		createdAt
+		stateReason
+	}
+	... on ReopenedEvent {
+		actor { login }
+		createdAt
+	}
+	... on RenamedTitleEvent {
+		actor { login }
+		createdAt
+		previousTitle
+		currentTitle
+	}
+	... on MergedEvent {
+		actor { login }
+		createdAt
+	}
+	... on MilestonedEvent {
+		actor { login }
+		createdAt
+		milestoneTitle
+	}
+	... on DemilestonedEvent {
+		actor { login }
+		createdAt
+		milestoneTitle
+	}
+	... on AddedToProjectV2Event {
+		actor { login }
+		createdAt
+		project { title }
+	}
+	... on RemovedFromProjectV2Event {
+		actor { login }
+		createdAt
+		project { title }
+	}
+	... on ProjectV2ItemStatusChangedEvent {
+		actor { login }
+		createdAt
+		project { title }
+		previousStatus
+		status
+	}
+`
+
+// timelineItemTypes are the item types of the events timelineItemFields
+// selects. Timelines are filtered by them so comments and other events don't
+// take up the page.
+var timelineItemTypes = []string{
+	"LABELED_EVENT", "UNLABELED_EVENT",
+	"ASSIGNED_EVENT", "UNASSIGNED_EVENT",
+	"CROSS_REFERENCED_EVENT",
+	"CLOSED_EVENT", "REOPENED_EVENT", "MERGED_EVENT",
+	"RENAMED_TITLE_EVENT",
+	"MILESTONED_EVENT", "DEMILESTONED_EVENT",
+	"ADDED_TO_PROJECT_V2_EVENT", "REMOVED_FROM_PROJECT_V2_EVENT", "PROJECT_V2_ITEM_STATUS_CHANGED_EVENT",
+}
+
+// issueTimelineItemTypes drops the merged events from item types, as issues
+// have none and the issue enum rejects them
+func issueTimelineItemTypes(itemTypes []string) []string {
+	var issueTypes []string
+	for _, itemType := range itemTypes {
+		if itemType != "MERGED_EVENT" {
+			issueTypes = append(issueTypes, itemType)
+		}
+	}
+	return issueTypes
+}
+
+// timelineNode is the GraphQL shape of the timeline events selected by timelineItemFields
+type timelineNode struct {
+	TypeName string `json:"__typename"`
+	Actor    struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+	CreatedAt time.Time `json:"createdAt"`
+	Label     struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	Assignee struct {
+		Login string `json:"login"`
+	} `json:"assignee"`
+	Source struct {
+		TypeName string `json:"__typename"`
+		Number   int    `json:"number"`
+		Title    string `json:"title"`
+	} `json:"source"`
+	StateReason    string `json:"stateReason"`
+	PreviousTitle  string `json:"previousTitle"`
+	CurrentTitle   string `json:"currentTitle"`
+	MilestoneTitle string `json:"milestoneTitle"`
+	Project        struct {
+		Title string `json:"title"`
+	} `json:"project"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+}
+
+func (n timelineNode) toModel() models.TimelineEvent {
+	var summary string
+	switch n.TypeName {
+	case "LabeledEvent":
+		summary = fmt.Sprintf("added label %q", n.Label.Name)
+	case "UnlabeledEvent":
+		summary = fmt.Sprintf("removed label %q", n.Label.Name)
+	case "AssignedEvent":
+		summary = "assigned @" + n.Assignee.Login
+	case "UnassignedEvent":
+		summary = "unassigned @" + n.Assignee.Login
+	case "CrossReferencedEvent":
+		summary = fmt.Sprintf("referenced this from #%d %s", n.Source.Number, n.Source.Title)
+	case "ClosedEvent":
+		summary = "closed this"
+		if n.StateReason != "" {
+			summary += " as " + n.StateReason
+		}
+	case "ReopenedEvent":
+		summary = "reopened this"
+	case "RenamedTitleEvent":
+		summary = fmt.Sprintf("changed the title from %q to %q", n.PreviousTitle, n.CurrentTitle)
+	case "MergedEvent":
+		summary = "merged this"
+	case "MilestonedEvent":
+		summary = fmt.Sprintf("added this to the %s milestone", n.MilestoneTitle)
+	case "DemilestonedEvent":
+		summary = fmt.Sprintf("removed this from the %s milestone", n.MilestoneTitle)
+	case "AddedToProjectV2Event":
+		summary = fmt.Sprintf("added this to %s", n.Project.Title)
+	case "RemovedFromProjectV2Event":
+		summary = fmt.Sprintf("removed this from %s", n.Project.Title)
+	case "ProjectV2ItemStatusChangedEvent":
+		summary = fmt.Sprintf("moved this from %s to %s in %s", statusName(n.PreviousStatus), statusName(n.Status), n.Project.Title)
+	default:
+		summary = n.TypeName
+	}
+
+	return models.TimelineEvent{
+		Type:      n.TypeName,
+		Actor:     n.Actor.Login,
+		CreatedAt: n.CreatedAt,
+		Summary:   summary,
+	}
+}
+
+// timelineNodesToModel converts timeline nodes, dropping event types we don't select
+func timelineNodesToModel(nodes []timelineNode) []models.TimelineEvent {
+	events := make([]models.TimelineEvent, 0, len(nodes))
+	for _, node := range nodes {
+		if node.CreatedAt.IsZero() {
+			continue
+		}
+		events = append(events, node.toModel())
+	}
+	return events
+}
+
+// statusName is a project status as shown in an event, which is empty when
+// the item had none
+func statusName(status string) string {
+	if status == "" {
+		return "No Status"
+	}
+	return status
+}
+
+// ListTimeline retrieves a page of an issue's or pull request's timeline,
+// oldest first, with only events of the given item types, e.g. "LABELED_EVENT"
+func (c *Client) ListTimeline(ctx context.Context, contentID string, itemTypes []string, first int, after string) ([]models.TimelineEvent, models.PageInfo, error) {
+	// The item types are names in two enums, one for each fragment
+	query := `query($id: ID!, $first: Int!, $after: String, $issueTypes: [IssueTimelineItemsItemType!], $pullRequestTypes: [PullRequestTimelineItemsItemType!]) {
+		node(id: $id) {
+			... on Issue {
+				timelineItems(first: $first, after: $after, itemTypes: $issueTypes) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {` + timelineItemFields + `}
+				}
+			}
+			... on PullRequest {
+				timelineItems(first: $first, after: $after, itemTypes: $pullRequestTypes) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {` + timelineItemFields + `}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"id":               contentID,
+		"first":            first,
+		"issueTypes":       issueTimelineItemTypes(itemTypes),
+		"pullRequestTypes": itemTypes,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Node struct {
+			TimelineItems struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []timelineNode `json:"nodes"`
+			} `json:"timelineItems"`
+		} `json:"node"`
+	}
+
+	err := c.query(ctx, query, variables, &response)
+	if err != nil {
+		return nil, models.PageInfo{}, fmt.Errorf("failed to list timeline: %w", err)
+	}
+
+	items := response.Node.TimelineItems
+	return timelineNodesToModel(items.Nodes), models.PageInfo{
+		HasNextPage: items.PageInfo.HasNextPage,
+		EndCursor:   items.PageInfo.EndCursor,
+	}, nil
+}
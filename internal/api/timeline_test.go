@@ -0,0 +1,59 @@
+package api_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+)
+
+func TestListTimelinePagesFilteredEvents(t *testing.T) {
+	ctx := context.Background()
+	backend := fake.New("octocat")
+	p := backend.AddProject("octocat", "Roadmap")
+	r := backend.AddRepository("octocat", "api")
+	bug := backend.AddLabel(r.ID, "bug", "d73a4a")
+	issue := backend.AddIssue(p.ID, r.ID, "Crash on start", "")
+	for i := 0; i < 3; i++ {
+		if err := backend.AddLabels(ctx, issue.ContentID, []string{bug.ID}); err != nil {
+			t.Fatal(err)
+		}
+		if err := backend.RemoveLabels(ctx, issue.ContentID, []string{bug.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := backend.CloseIssue(ctx, issue.ContentID, "COMPLETED", ""); err != nil {
+		t.Fatal(err)
+	}
+	client, _ := newClient(t, backend)
+
+	// Merged events are asked for too, which the issue enum doesn't have
+	itemTypes := []string{"LABELED_EVENT", "CLOSED_EVENT", "MERGED_EVENT"}
+	var summaries []string
+	after := ""
+	for pages := 1; ; pages++ {
+		events, page, err := client.ListTimeline(ctx, issue.ContentID, itemTypes, 2, after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, event := range events {
+			summaries = append(summaries, event.Summary)
+		}
+		if !page.HasNextPage {
+			if pages != 2 {
+				t.Errorf("got %d pages, want 2", pages)
+			}
+			break
+		}
+		if pages == 2 {
+			t.Fatal("still more events after 2 pages")
+		}
+		after = page.EndCursor
+	}
+
+	want := []string{`added label "bug"`, `added label "bug"`, `added label "bug"`, "closed this as COMPLETED"}
+	if !slices.Equal(summaries, want) {
+		t.Errorf("summaries = %q, want %q", summaries, want)
+	}
+}
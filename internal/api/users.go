@@ -1,12 +1,13 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 // SearchUsers searches for GitHub users by username
-func (c *Client) SearchUsers(query string, limit int) ([]string, error) {
+func (c *Client) SearchUsers(ctx context.Context, query string, limit int) ([]string, error) {
 	if query == "" {
 		return []string{}, nil
 	}
@@ -37,7 +38,7 @@ func (c *Client) SearchUsers(query string, limit int) ([]string, error) {
 		} `json:"search"`
 	}
 
-	err := c.client.Do(gqlQuery, variables, &response)
+	err := c.query(ctx, gqlQuery, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search users: %w", err)
 	}
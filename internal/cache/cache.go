@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/config"
+)
+
+// Cache keeps the last API response for each view on disk, so reopening a
+// view can render immediately while fresh data loads in the background.
+// A nil *Cache is valid and caches nothing.
+type Cache struct {
+	dir string
+}
+
+// entry is the on-disk format of a cached value
+type entry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Open returns the cache stored in the config directory
+func Open() (*Cache, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(configDir, "cache")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &Cache{dir: dir}, nil
+}
+
+// ProjectsKey is the key of an owner's project list
+func ProjectsKey(owner string) string {
+	return owner + "/projects"
+}
+
+// ItemsKey is the key of a project's items
+func ItemsKey(owner string, projectNumber int) string {
+	return owner + "/" + strconv.Itoa(projectNumber) + "/items"
+}
+
+// FieldsKey is the key of a project's fields
+func FieldsKey(owner string, projectNumber int) string {
+	return owner + "/" + strconv.Itoa(projectNumber) + "/fields"
+}
+
+// Load decodes the cached value for key into v and reports when it was saved.
+// Missing or unreadable entries are treated as a miss.
+func (c *Cache) Load(key string, v interface{}) (time.Time, bool) {
+	if c == nil {
+		return time.Time{}, false
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return time.Time{}, false
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return time.Time{}, false
+	}
+
+	return e.SavedAt, true
+}
+
+// Store saves v under key, replacing any earlier value
+func (c *Cache) Store(key string, v interface{}) error {
+	if c == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	data, err := json.Marshal(entry{SavedAt: time.Now(), Data: payload})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Clear deletes every cached entry
+func (c *Cache) Clear() error {
+	if c == nil {
+		return nil
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(c.dir, e.Name())); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// path maps a key to a file, escaping each segment so owner names can't escape the cache directory
+func (c *Cache) path(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segment = url.PathEscape(segment)
+		if segment == "." || segment == ".." {
+			segment = strings.ReplaceAll(segment, ".", "%2E")
+		}
+		segments[i] = segment
+	}
+	return filepath.Join(c.dir, filepath.Join(segments...)+".json")
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 )
@@ -11,6 +12,10 @@ import (
 type Config struct {
 	// ProjectRepositories maps project ID to default repository ID
 	ProjectRepositories map[string]string `json:"project_repositories"`
+
+	// AutoRefreshSeconds reloads the open project's items in the background
+	// this often. Zero disables auto-refresh.
+	AutoRefreshSeconds int `json:"auto_refresh_seconds,omitempty"`
 }
 
 // New creates a new empty config
@@ -20,8 +25,8 @@ func New() *Config {
 	}
 }
 
-// GetConfigPath returns the path to the config file
-func GetConfigPath() (string, error) {
+// GetConfigDir returns the application's config directory, creating it if needed
+func GetConfigDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
@@ -34,6 +39,16 @@ func GetConfigPath() (string, error) {
 		return "", fmt.Errorf("failed to create config directory: %w", err)
 	}
 	
+	return configDir, nil
+}
+
+// GetConfigPath returns the path to the config file
+func GetConfigPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	
 	return filepath.Join(configDir, "config.json"), nil
 }
 
@@ -43,7 +58,7 @@ func Load() (*Config, error) {
 	configPath, err := GetConfigPath()
 	if err != nil {
 		// Can't determine config path, return empty config
-		fmt.Fprintf(os.Stderr, "Warning: unable to determine config path: %v\n", err)
+		slog.Warn("unable to determine config path", "err", err)
 		return New(), nil
 	}
 	
@@ -55,14 +70,14 @@ func Load() (*Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		// Can't read config file, return empty config
-		fmt.Fprintf(os.Stderr, "Warning: unable to read config file: %v\n", err)
+		slog.Warn("unable to read config file", "err", err)
 		return New(), nil
 	}
 	
 	var cfg Config
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		// Invalid JSON, return empty config
-		fmt.Fprintf(os.Stderr, "Warning: config file is corrupted, using defaults: %v\n", err)
+		slog.Warn("config file is corrupted, using defaults", "err", err)
 		return New(), nil
 	}
 	
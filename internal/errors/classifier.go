@@ -2,6 +2,7 @@ package errors
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -26,6 +27,12 @@ func ClassifyError(err error, httpStatus int) *APIError {
 		return nil
 	}
 
+	// Already classified further down the stack
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
 	errMsg := err.Error()
 	errLower := strings.ToLower(errMsg)
 
@@ -88,6 +95,12 @@ func ClassifyGraphQLError(gqlErr GraphQLError) *APIError {
 	errMsg := gqlErr.Message
 	errLower := strings.ToLower(errMsg)
 
+	// Merges refused by branch protection mention reviewers with write
+	// access, so check for them before the permission errors
+	if isBranchProtection(errLower) {
+		return BranchProtectionError(errMsg)
+	}
+
 	// Check GraphQL error type
 	switch errType {
 	case "RATE_LIMITED":
@@ -129,6 +142,17 @@ func ClassifyGraphQLError(gqlErr GraphQLError) *APIError {
 	}
 }
 
+// isBranchProtection reports whether a lowercased error message is one of
+// the ways GitHub refuses a merge the branch's protection rules don't allow
+func isBranchProtection(errLower string) bool {
+	return strings.Contains(errLower, "protected branch") ||
+		strings.Contains(errLower, "branch protection") ||
+		strings.Contains(errLower, "repository rule violations") ||
+		strings.Contains(errLower, "approving review is required") ||
+		strings.Contains(errLower, "required status check") ||
+		strings.Contains(errLower, "changes must be made through a pull request")
+}
+
 // extractRetryAfter attempts to extract retry-after duration from error message
 func extractRetryAfter(errMsg string) time.Duration {
 	// Try to parse common formats like "retry after 60 seconds"
@@ -210,7 +234,8 @@ func ParseGraphQLErrors(data []byte) *APIError {
 
 // IsRetryableError checks if an error is retryable
 func IsRetryableError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
 		return apiErr.IsRetryable()
 	}
 	return false
@@ -218,7 +243,8 @@ func IsRetryableError(err error) bool {
 
 // GetRetryAfter returns the retry-after duration if available
 func GetRetryAfter(err error) time.Duration {
-	if apiErr, ok := err.(*APIError); ok {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
 		return apiErr.RetryAfter
 	}
 	return 0
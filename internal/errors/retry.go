@@ -1,10 +1,11 @@
 package errors
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"math"
 	"math/rand"
-	"os"
 	"time"
 )
 
@@ -25,8 +26,7 @@ func DefaultRetryConfig() *RetryConfig {
 		MaxDelay:    16 * time.Second,
 		Jitter:      true,
 		OnRetry: func(attempt int, err error, delay time.Duration) {
-			fmt.Fprintf(os.Stderr, "[Retry] Attempt %d failed: %v. Retrying in %v...\n", 
-				attempt, err, delay.Round(time.Millisecond))
+			slog.Debug("retrying after error", "attempt", attempt, "err", err, "delay", delay.Round(time.Millisecond))
 		},
 	}
 }
@@ -49,7 +49,7 @@ func Retry(fn RetryFunc, config *RetryConfig) error {
 		// Success!
 		if err == nil {
 			if attempt > 1 {
-				fmt.Fprintf(os.Stderr, "[Retry] Succeeded on attempt %d\n", attempt)
+				slog.Debug("retry succeeded", "attempt", attempt)
 			}
 			return nil
 		}
@@ -58,13 +58,13 @@ func Retry(fn RetryFunc, config *RetryConfig) error {
 
 		// Check if error is retryable
 		if !IsRetryableError(err) {
-			fmt.Fprintf(os.Stderr, "[Retry] Non-retryable error: %v\n", err)
+			slog.Debug("not retrying error", "err", err)
 			return err
 		}
 
 		// Last attempt, don't delay
 		if attempt == config.MaxAttempts {
-			fmt.Fprintf(os.Stderr, "[Retry] All %d attempts exhausted\n", config.MaxAttempts)
+			slog.Warn("giving up after retries", "attempts", config.MaxAttempts, "err", err)
 			return err
 		}
 
@@ -83,9 +83,9 @@ func Retry(fn RetryFunc, config *RetryConfig) error {
 	return lastErr
 }
 
-// RetryWithContext executes a function with retry logic and context for cancellation
-// Returns (success bool, error)
-func RetryWithContext(fn RetryFunc, config *RetryConfig, cancel <-chan bool) error {
+// RetryWithContext executes a function with retry logic, giving up as soon as ctx is done.
+// A cancelled context is returned as ctx.Err() so callers can tell it apart from API errors.
+func RetryWithContext(ctx context.Context, fn RetryFunc, config *RetryConfig) error {
 	if config == nil {
 		config = DefaultRetryConfig()
 	}
@@ -94,11 +94,9 @@ func RetryWithContext(fn RetryFunc, config *RetryConfig, cancel <-chan bool) err
 
 	for attempt := 1; attempt <= config.MaxAttempts; attempt++ {
 		// Check for cancellation
-		select {
-		case <-cancel:
-			fmt.Fprintf(os.Stderr, "[Retry] Operation cancelled by user\n")
-			return fmt.Errorf("operation cancelled")
-		default:
+		if err := ctx.Err(); err != nil {
+			slog.Debug("retry cancelled")
+			return err
 		}
 
 		// Execute the function
@@ -106,13 +104,18 @@ func RetryWithContext(fn RetryFunc, config *RetryConfig, cancel <-chan bool) err
 		
 		if err == nil {
 			if attempt > 1 {
-				fmt.Fprintf(os.Stderr, "[Retry] Succeeded on attempt %d\n", attempt)
+				slog.Debug("retry succeeded", "attempt", attempt)
 			}
 			return nil
 		}
 
 		lastErr = err
 
+		// Errors caused by cancellation are not worth retrying
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if !IsRetryableError(err) {
 			return err
 		}
@@ -128,12 +131,14 @@ func RetryWithContext(fn RetryFunc, config *RetryConfig, cancel <-chan bool) err
 		}
 
 		// Wait with cancellation support
+		timer := time.NewTimer(delay)
 		select {
-		case <-time.After(delay):
+		case <-timer.C:
 			// Continue to next retry
-		case <-cancel:
-			fmt.Fprintf(os.Stderr, "[Retry] Operation cancelled during wait\n")
-			return fmt.Errorf("operation cancelled")
+		case <-ctx.Done():
+			timer.Stop()
+			slog.Debug("retry cancelled while waiting")
+			return ctx.Err()
 		}
 	}
 
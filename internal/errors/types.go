@@ -16,6 +16,7 @@ const (
 	ErrorTypeValidation
 	ErrorTypeRateLimit
 	ErrorTypeConflict
+	ErrorTypeBranchProtection
 )
 
 func (t ErrorType) String() string {
@@ -30,6 +31,8 @@ func (t ErrorType) String() string {
 		return "rate_limit"
 	case ErrorTypeConflict:
 		return "conflict"
+	case ErrorTypeBranchProtection:
+		return "branch_protection"
 	default:
 		return "unknown"
 	}
@@ -112,6 +115,17 @@ func ConflictError(message string, err error) *APIError {
 	}
 }
 
+// BranchProtectionError creates an error for a change the branch's
+// protection rules refused, such as merging without a required review
+func BranchProtectionError(message string) *APIError {
+	return &APIError{
+		Type:        ErrorTypeBranchProtection,
+		Message:     message,
+		OriginalErr: nil,
+		Retryable:   false,
+	}
+}
+
 // GetUserFriendlyMessage returns a user-friendly error message
 func (e *APIError) GetUserFriendlyMessage() string {
 	switch e.Type {
@@ -142,6 +156,9 @@ func (e *APIError) GetUserFriendlyMessage() string {
 	
 	case ErrorTypeConflict:
 		return fmt.Sprintf("Conflict: %s. The item may have been modified by someone else.", e.Message)
+
+	case ErrorTypeBranchProtection:
+		return fmt.Sprintf("Blocked by branch protection: %s", e.Message)
 	
 	default:
 		return e.Message
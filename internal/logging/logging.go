@@ -0,0 +1,224 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/config"
+)
+
+// ringSize is how many recent entries are kept for the in-app log pane
+const ringSize = 500
+
+// Entry is a log record as shown in the in-app log pane, already redacted
+type Entry struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   string // key=value pairs
+}
+
+// String formats the entry as a single line
+func (e Entry) String() string {
+	line := fmt.Sprintf("%s %-5s %s", e.Time.Format("15:04:05.000"), e.Level, e.Message)
+	if e.Attrs != "" {
+		line += " " + e.Attrs
+	}
+	return line
+}
+
+var ring = &entryRing{}
+
+// Setup installs the default slog logger. Records at level and above go,
+// redacted, to a rotating file in the config directory and to the ring of
+// recent entries read by Recent. Nothing is ever written to the terminal,
+// which belongs to the TUI. If the log file can't be opened, logging
+// continues in memory only and the error is returned.
+func Setup(level slog.Level) (string, error) {
+	var handlers []slog.Handler
+	handlers = append(handlers, &ringHandler{ring: ring, level: level})
+
+	path, err := logPath()
+	if err == nil {
+		var file *rotatingFile
+		file, err = openRotatingFile(path)
+		if err == nil {
+			handlers = append(handlers, slog.NewTextHandler(file, &slog.HandlerOptions{Level: level}))
+		}
+	}
+
+	slog.SetDefault(slog.New(&redactingHandler{next: fanoutHandler(handlers)}))
+	if err != nil {
+		return "", fmt.Errorf("failed to open log file: %w", err)
+	}
+	return path, nil
+}
+
+// ParseLevel converts a --log-level value to a slog level
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q, want debug, info, warn or error", name)
+	}
+}
+
+// Recent returns up to n of the most recent log entries, oldest first
+func Recent(n int) []Entry {
+	return ring.recent(n)
+}
+
+// logPath returns where the log file is written
+func logPath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "ghptui.log"), nil
+}
+
+// entryRing is a fixed-size buffer of the latest entries
+type entryRing struct {
+	mu      sync.Mutex
+	entries [ringSize]Entry
+	next    int
+	full    bool
+}
+
+func (r *entryRing) add(e Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % ringSize
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *entryRing) recent(n int) []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := r.next
+	if r.full {
+		count = ringSize
+	}
+	if n > count {
+		n = count
+	}
+
+	out := make([]Entry, n)
+	for i := 0; i < n; i++ {
+		out[i] = r.entries[(r.next-n+i+ringSize)%ringSize]
+	}
+	return out
+}
+
+// ringHandler records entries in the ring for the log pane
+type ringHandler struct {
+	ring   *entryRing
+	level  slog.Level
+	attrs  []string // Preformatted attributes added with WithAttrs
+	prefix string   // Group prefix for attribute keys
+}
+
+func (h *ringHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *ringHandler) Handle(_ context.Context, record slog.Record) error {
+	attrs := append([]string(nil), h.attrs...)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = appendAttr(attrs, h.prefix, attr)
+		return true
+	})
+
+	h.ring.add(Entry{
+		Time:    record.Time,
+		Level:   record.Level,
+		Message: record.Message,
+		Attrs:   strings.Join(attrs, " "),
+	})
+	return nil
+}
+
+func (h *ringHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]string(nil), h.attrs...)
+	for _, attr := range attrs {
+		clone.attrs = appendAttr(clone.attrs, h.prefix, attr)
+	}
+	return &clone
+}
+
+func (h *ringHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+// appendAttr formats attr as key=value, flattening groups
+func appendAttr(out []string, prefix string, attr slog.Attr) []string {
+	attr.Value = attr.Value.Resolve()
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, member := range attr.Value.Group() {
+			out = appendAttr(out, prefix+attr.Key+".", member)
+		}
+		return out
+	}
+	return append(out, fmt.Sprintf("%s%s=%v", prefix, attr.Key, attr.Value))
+}
+
+// fanoutHandler sends each record to every handler that accepts its level
+type fanoutHandler []slog.Handler
+
+func (f fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fanoutHandler) Handle(ctx context.Context, record slog.Record) error {
+	var firstErr error
+	for _, h := range f {
+		if !h.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, record.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (f fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(fanoutHandler, len(f))
+	for i, h := range f {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (f fanoutHandler) WithGroup(name string) slog.Handler {
+	out := make(fanoutHandler, len(f))
+	for i, h := range f {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// secretKeys mark attributes whose value is a credential, matched as a substring of the key
+var secretKeys = []string{"token", "authorization", "password", "secret", "cookie"}
+
+// contentKeys mark attributes holding user content, which only has its size logged
+var contentKeys = map[string]bool{
+	"body":        true,
+	"title":       true,
+	"text":        true,
+	"comment":     true,
+	"description": true,
+	"input":       true,
+	"variables":   true,
+}
+
+// tokenPattern matches GitHub tokens and bearer credentials wherever they appear in text
+var tokenPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b|(?i)\b(bearer|token)\s+[A-Za-z0-9_\-.]{20,}`)
+
+// redactingHandler scrubs credentials and user content from records before
+// passing them on
+type redactingHandler struct {
+	next slog.Handler
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	clean := slog.NewRecord(record.Time, record.Level, Scrub(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		clean.AddAttrs(redactAttr(attr))
+		return true
+	})
+	return h.next.Handle(ctx, clean)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clean := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		clean[i] = redactAttr(attr)
+	}
+	return &redactingHandler{next: h.next.WithAttrs(clean)}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name)}
+}
+
+// redactAttr replaces sensitive values in an attribute
+func redactAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+	key := strings.ToLower(attr.Key)
+
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return slog.String(attr.Key, "[REDACTED]")
+		}
+	}
+
+	if contentKeys[key] {
+		if attr.Value.Kind() == slog.KindString {
+			return slog.String(attr.Key, fmt.Sprintf("[redacted, %d chars]", len(attr.Value.String())))
+		}
+		return slog.String(attr.Key, "[redacted]")
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		members := attr.Value.Group()
+		clean := make([]any, len(members))
+		for i, member := range members {
+			clean[i] = redactAttr(member)
+		}
+		return slog.Group(attr.Key, clean...)
+	case slog.KindString:
+		return slog.String(attr.Key, Scrub(attr.Value.String()))
+	case slog.KindAny:
+		// Errors and other values are logged by their text, which may quote a token
+		if err, ok := attr.Value.Any().(error); ok {
+			return slog.String(attr.Key, Scrub(err.Error()))
+		}
+		return slog.String(attr.Key, Scrub(fmt.Sprint(attr.Value.Any())))
+	default:
+		return attr
+	}
+}
+
+// Scrub removes anything that looks like a credential from text
+func Scrub(text string) string {
+	return tokenPattern.ReplaceAllString(text, "[REDACTED]")
+}
@@ -10,6 +10,7 @@ type Project struct {
 	ShortDescription string
 	Public      bool
 	Closed      bool
+	Template    bool // Marked as a template for the owner
 	URL         string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
@@ -32,19 +33,153 @@ type ProjectItem struct {
 	Body      string
 	Number    int      // For issues/PRs
 	State     string   // For issues/PRs
+	StateReason string // For closed issues: "COMPLETED", "NOT_PLANNED" or "DUPLICATE"
 	URL       string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Assignees []string // Assignee logins
 	Comments  []Comment
 	Fields    map[string]interface{}
+
+	// Labels and milestone are set on issues and PRs only, and come from
+	// the repository the content lives in
+	RepositoryID string
+	Labels       []Label
+	Milestone    *Milestone // nil when there is none
+
+	PullRequest *PullRequestStatus // Review and CI status, PRs only
+
+	// Sub-issue hierarchy, issues only
+	Parent           *IssueRef // nil for top-level issues
+	SubIssues        []IssueRef
+	SubIssuesSummary SubIssuesSummary
+
+	// Dependencies, issues only
+	BlockedBy    []IssueRef // Issues that must be done before this one
+	Blocking     []IssueRef // Issues waiting on this one
+	Dependencies DependencySummary
+
+	CommentCount int      // Total comments on the issue/PR, may exceed len(Comments)
+	CommentsPage PageInfo // Where the loaded comments end
+
+	// Body, comments and timeline are loaded on demand, see ItemDetails
+	DetailsLoaded bool
+	Timeline      []TimelineEvent
+
+	// Development, issues only, loaded on demand along with the body
+	LinkedPullRequests []IssueRef // Pull requests that close the issue when merged
+	LinkedBranches     []LinkedBranch
+}
+
+// ItemDetails holds the per-item data that is too expensive to fetch for every table row
+type ItemDetails struct {
+	Body         string
+	Comments     []Comment
+	CommentCount int
+	CommentsPage PageInfo
+	Timeline     []TimelineEvent // Most recent events, oldest first
+	UpdatedAt    time.Time       // Content UpdatedAt when the details were fetched
+
+	LinkedPullRequests []IssueRef
+	LinkedBranches     []LinkedBranch
+}
+
+// PullRequestStatus is where a pull request stands in review and CI
+type PullRequestStatus struct {
+	IsDraft        bool
+	ReviewDecision string   // "APPROVED", "CHANGES_REQUESTED", "REVIEW_REQUIRED", or empty when no review is required
+	Reviewers      []string // Requested reviewers: user logins, and teams as "org/slug"
+	Checks         string   // CI status rollup: "SUCCESS", "FAILURE", "ERROR", "PENDING" or "EXPECTED", empty without checks
+	Mergeable      string   // "MERGEABLE", "CONFLICTING" or "UNKNOWN"
+	HeadRef        string
+	BaseRef        string
+}
+
+// IssueRef is a short reference to an issue or pull request related to an item
+type IssueRef struct {
+	ID         string
+	Number     int
+	Title      string
+	State      string
+	Repository string // "owner/name"
+	URL        string
+}
+
+// LinkedBranch is a branch created for an issue
+type LinkedBranch struct {
+	Name       string
+	Repository string // "owner/name"
+}
+
+// SubIssuesSummary counts an issue's sub-issues and how many are closed
+type SubIssuesSummary struct {
+	Total     int
+	Completed int
+}
+
+// DependencySummary counts an issue's dependencies, including any beyond
+// those listed
+type DependencySummary struct {
+	OpenBlockers   int // Open issues this one is blocked by
+	TotalBlockedBy int
+	TotalBlocking  int
+}
+
+// ItemContent is the editable text of an item as it currently is on GitHub
+type ItemContent struct {
+	Title     string
+	Body      string
+	UpdatedAt time.Time
+}
+
+// Label represents a repository label
+type Label struct {
+	ID    string
+	Name  string
+	Color string // Hex color without the leading '#', e.g. "d73a4a"
+}
+
+// Milestone represents a repository milestone
+type Milestone struct {
+	ID     string
+	Title  string
+	Number int
+	DueOn  time.Time // Zero when it has no due date
+}
+
+// TimelineEvent represents an entry in an issue or pull request timeline
+type TimelineEvent struct {
+	Type      string // GraphQL typename, e.g. "LabeledEvent"
+	Actor     string
+	CreatedAt time.Time
+	Summary   string // Human readable description of the event
 }
 
 // Comment represents a comment on an item
 type Comment struct {
+	ID        string
 	Author    string
 	Body      string
 	CreatedAt time.Time
+	CanUpdate bool // Viewer may edit this comment
+	CanDelete bool // Viewer may delete this comment
+}
+
+// PageInfo describes where a paginated list left off
+type PageInfo struct {
+	HasNextPage bool
+	EndCursor   string
+}
+
+// StatusUpdate represents a project status update
+type StatusUpdate struct {
+	ID         string
+	Status     string // "ON_TRACK", "AT_RISK", "OFF_TRACK", "COMPLETE", "INACTIVE"
+	Body       string
+	StartDate  string // YYYY-MM-DD, optional
+	TargetDate string // YYYY-MM-DD, optional
+	Author     string
+	CreatedAt  time.Time
 }
 
 // Repository represents a GitHub repository
@@ -56,6 +191,22 @@ type Repository struct {
 	IsPrivate   bool
 }
 
+// ProjectCollaborator represents a user or team with a role on a project
+type ProjectCollaborator struct {
+	ID    string // User or team node ID
+	Login string // User login or team slug
+	Name  string
+	Type  string // "User" or "Team"
+	Role  string // "READER", "WRITER", "ADMIN" ("NONE" removes access)
+}
+
+// Team represents an organization team
+type Team struct {
+	ID   string
+	Slug string
+	Name string
+}
+
 // ProjectField represents a custom field in a project
 type ProjectField struct {
 	ID       string
@@ -79,6 +230,23 @@ type CreateProjectInput struct {
 	Public           bool
 }
 
+// CopyProjectInput represents input for copying an existing project
+type CopyProjectInput struct {
+	SourceProjectID    string
+	OwnerID            string
+	Title              string
+	IncludeDraftIssues bool
+}
+
+// CreateStatusUpdateInput represents input for posting a project status update
+type CreateStatusUpdateInput struct {
+	ProjectID  string
+	Status     string
+	Body       string
+	StartDate  string // Optional: YYYY-MM-DD
+	TargetDate string // Optional: YYYY-MM-DD
+}
+
 // UpdateProjectInput represents input for updating a project
 type UpdateProjectInput struct {
 	ProjectID        string
@@ -0,0 +1,280 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/config"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+)
+
+// Kind identifies the mutation a queued entry performs
+type Kind string
+
+const (
+	KindCreateDraft Kind = "create_draft"
+	KindUpdateDraft Kind = "update_draft"
+	KindDeleteItem  Kind = "delete_item"
+)
+
+// Mutation is a change made while GitHub was unreachable, waiting to be sent
+type Mutation struct {
+	ID        string `json:"id"`
+	Kind      Kind   `json:"kind"`
+	ProjectID string `json:"project_id"`
+	ItemID    string `json:"item_id,omitempty"`    // Project item ID
+	ContentID string `json:"content_id,omitempty"` // Draft issue, issue or pull request ID
+	Title     string `json:"title,omitempty"`
+	Body      string `json:"body,omitempty"`
+
+	// ContentType is "Issue" or "PullRequest" for updates of those, which are
+	// saved with different mutations than drafts. Empty means a draft.
+	ContentType string `json:"content_type,omitempty"`
+	// Assignees are logins, resolved when the entry is sent. PreviousAssignees
+	// are the item's assignees when it was edited, to work out who to add and
+	// remove.
+	Assignees         []string `json:"assignees,omitempty"`
+	PreviousAssignees []string `json:"previous_assignees,omitempty"`
+	// Label and milestone IDs of issues and pull requests after the edit, and
+	// before it to work out what changed. An empty milestone means none.
+	LabelIDs            []string `json:"label_ids,omitempty"`
+	PreviousLabelIDs    []string `json:"previous_label_ids,omitempty"`
+	MilestoneID         string   `json:"milestone_id,omitempty"`
+	PreviousMilestoneID string   `json:"previous_milestone_id,omitempty"`
+
+	// BaseUpdatedAt is when the item was last updated before it was edited
+	// offline. A newer updatedAt on GitHub means someone else changed it.
+	BaseUpdatedAt time.Time `json:"base_updated_at,omitempty"`
+	Force         bool      `json:"force,omitempty"` // Overwrite even if the item changed
+
+	QueuedAt  time.Time `json:"queued_at"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	Conflict  string    `json:"conflict,omitempty"` // Why the entry is held for manual resolution
+}
+
+// Target returns the project item the mutation affects, empty for creates
+func (m Mutation) Target() string {
+	if m.ItemID != "" {
+		return m.ItemID
+	}
+	return m.ContentID
+}
+
+// Describe returns a one-line summary of the mutation
+func (m Mutation) Describe() string {
+	switch m.Kind {
+	case KindCreateDraft:
+		return fmt.Sprintf("Create draft %q", m.Title)
+	case KindUpdateDraft:
+		return fmt.Sprintf("Update %q", m.Title)
+	case KindDeleteItem:
+		return fmt.Sprintf("Delete %q", m.Title)
+	default:
+		return string(m.Kind)
+	}
+}
+
+// ApplyFunc sends one mutation to GitHub. It may record progress on the entry,
+// e.g. turn a create whose follow-up step failed into an update, and the
+// change is kept even when it returns an error.
+type ApplyFunc func(ctx context.Context, m *Mutation) error
+
+// Outbox is a durable, ordered queue of mutations stored in the config directory
+type Outbox struct {
+	mu      sync.Mutex
+	path    string
+	entries []Mutation
+}
+
+// Open loads the outbox from the config directory
+func Open() (*Outbox, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Outbox{path: filepath.Join(configDir, "outbox.json")}
+
+	data, err := os.ReadFile(o.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return o, nil
+		}
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	if err := json.Unmarshal(data, &o.entries); err != nil {
+		return nil, fmt.Errorf("outbox file is corrupted: %w", err)
+	}
+
+	return o, nil
+}
+
+// Enqueue appends a mutation and saves the outbox
+func (o *Outbox) Enqueue(m Mutation) (Mutation, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	m.QueuedAt = time.Now()
+	m.ID = strconv.FormatInt(m.QueuedAt.UnixNano(), 36)
+	o.entries = append(o.entries, m)
+	if err := o.save(); err != nil {
+		o.entries = o.entries[:len(o.entries)-1]
+		return Mutation{}, err
+	}
+	return m, nil
+}
+
+// Pending returns a copy of the queued mutations in order
+func (o *Outbox) Pending() []Mutation {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]Mutation(nil), o.entries...)
+}
+
+// Discard drops a mutation without sending it
+func (o *Outbox) Discard(id string) error {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i, m := range o.entries {
+		if m.ID == id {
+			o.entries = append(o.entries[:i], o.entries[i+1:]...)
+			return o.save()
+		}
+	}
+	return nil
+}
+
+// Force clears a mutation's conflict and sends it on the next replay even
+// though the item changed on GitHub
+func (o *Outbox) Force(id string) error {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.entries {
+		if o.entries[i].ID == id {
+			o.entries[i].Conflict = ""
+			o.entries[i].Force = true
+			return o.save()
+		}
+	}
+	return nil
+}
+
+// Replay sends queued mutations in order. Each is retried with backoff; if it
+// still fails with a retryable error GitHub is taken to be unreachable and
+// replay stops, keeping the rest queued. Mutations that conflict or are
+// rejected are held for manual resolution, along with any later mutation of
+// the same item. It returns how many mutations were sent.
+func (o *Outbox) Replay(ctx context.Context, apply ApplyFunc) (int, error) {
+	if o == nil {
+		return 0, nil
+	}
+
+	sent := 0
+	held := make(map[string]bool) // Items with a conflicted mutation ahead in the queue
+
+	for _, m := range o.Pending() {
+		if m.Conflict != "" || held[m.Target()] {
+			if m.Target() != "" {
+				held[m.Target()] = true
+			}
+			continue
+		}
+
+		err := apierrors.RetryWithContext(ctx, func() error {
+			return apply(ctx, &m)
+		}, apierrors.DefaultRetryConfig())
+
+		if err == nil {
+			sent++
+			if err := o.Discard(m.ID); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		m.Attempts++
+		m.LastError = err.Error()
+		if apierrors.IsRetryableError(err) {
+			o.update(m)
+			return sent, err
+		}
+
+		m.Conflict = conflictReason(err)
+		if m.Target() != "" {
+			held[m.Target()] = true
+		}
+		o.update(m)
+	}
+
+	return sent, nil
+}
+
+// update replaces a stored mutation with a modified copy
+func (o *Outbox) update(m Mutation) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.entries {
+		if o.entries[i].ID == m.ID {
+			o.entries[i] = m
+			// Keeping going matters more than persisting the attempt count
+			_ = o.save()
+			return
+		}
+	}
+}
+
+// save writes the outbox to disk. Callers must hold o.mu.
+func (o *Outbox) save() error {
+	data, err := json.MarshalIndent(o.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox: %w", err)
+	}
+
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	if err := os.Rename(tmp, o.path); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	return nil
+}
+
+// conflictReason explains why a mutation was held back
+func conflictReason(err error) string {
+	var apiErr *apierrors.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Type == apierrors.ErrorTypeConflict {
+			return apiErr.Message
+		}
+		return apiErr.GetUserFriendlyMessage()
+	}
+	return err.Error()
+}
@@ -0,0 +1,337 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+var (
+	collaboratorsTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#7D56F4")).
+				MarginLeft(2).
+				MarginTop(1)
+
+	collaboratorsLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#888888")).
+				MarginLeft(2)
+
+	collaboratorsRowStyle = lipgloss.NewStyle().
+				MarginLeft(4)
+
+	collaboratorsSelectedStyle = lipgloss.NewStyle().
+					Foreground(lipgloss.Color("229")).
+					Background(lipgloss.Color("57")).
+					MarginLeft(2)
+
+	collaboratorsHelpStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#626262")).
+				MarginLeft(2).
+				MarginTop(1)
+)
+
+// CollaboratorsModel lists project collaborators and lets the user change their roles
+type CollaboratorsModel struct {
+	project            models.Project
+	owner              string // Owner login (user or org)
+	isOrgProject       bool   // True if project belongs to org
+	collaborators      []models.ProjectCollaborator
+	cursor             int
+	adding             bool // True while searching for a user/team to add
+	searchInput        textinput.Model
+	suggestions        []models.ProjectCollaborator
+	selectedSuggestion int
+	width              int
+	height             int
+}
+
+func NewCollaboratorsModel(project models.Project, owner string, isOrgProject bool, collaborators []models.ProjectCollaborator) CollaboratorsModel {
+	ti := textinput.New()
+	ti.Placeholder = "Search users"
+	if isOrgProject {
+		ti.Placeholder = "Search members or teams"
+	}
+	ti.CharLimit = 100
+	ti.Width = 40
+
+	return CollaboratorsModel{
+		project:       project,
+		owner:         owner,
+		isOrgProject:  isOrgProject,
+		collaborators: collaborators,
+		searchInput:   ti,
+	}
+}
+
+func (m CollaboratorsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m CollaboratorsModel) Update(msg tea.Msg) (CollaboratorsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		inputWidth := msg.Width - 10
+		if inputWidth < 40 {
+			inputWidth = 40
+		}
+		m.searchInput.Width = inputWidth
+		return m, nil
+
+	case CollaboratorSuggestionsMsg:
+		m.suggestions = msg.Candidates
+		m.selectedSuggestion = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.adding {
+			return m.updateAdding(msg)
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.collaborators)-1 {
+				m.cursor++
+			}
+		case "a":
+			m.adding = true
+			m.searchInput.SetValue("")
+			m.suggestions = nil
+			m.searchInput.Focus()
+			return m, textinput.Blink
+		case "r":
+			// Cycle role of the selected collaborator
+			if m.cursor < len(m.collaborators) {
+				collaborator := m.collaborators[m.cursor]
+				collaborator.Role = nextRole(collaborator.Role)
+				return m, UpdateCollaboratorCmd(m.project, collaborator)
+			}
+		case "x":
+			// Remove the selected collaborator
+			if m.cursor < len(m.collaborators) {
+				collaborator := m.collaborators[m.cursor]
+				collaborator.Role = api.RoleNone
+				return m, UpdateCollaboratorCmd(m.project, collaborator)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m CollaboratorsModel) updateAdding(msg tea.KeyMsg) (CollaboratorsModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.adding = false
+		m.suggestions = nil
+		m.searchInput.Blur()
+		return m, nil
+	case "down", "ctrl+n":
+		if len(m.suggestions) > 0 {
+			m.selectedSuggestion = (m.selectedSuggestion + 1) % len(m.suggestions)
+		}
+		return m, nil
+	case "up", "ctrl+p":
+		if len(m.suggestions) > 0 {
+			m.selectedSuggestion--
+			if m.selectedSuggestion < 0 {
+				m.selectedSuggestion = len(m.suggestions) - 1
+			}
+		}
+		return m, nil
+	case "enter":
+		if m.selectedSuggestion < len(m.suggestions) {
+			collaborator := m.suggestions[m.selectedSuggestion]
+			collaborator.Role = api.RoleWriter
+			m.adding = false
+			m.suggestions = nil
+			m.searchInput.Blur()
+			return m, UpdateCollaboratorCmd(m.project, collaborator)
+		}
+		return m, nil
+	}
+
+	oldValue := m.searchInput.Value()
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+
+	newValue := m.searchInput.Value()
+	if newValue != oldValue && len(newValue) >= 2 {
+		return m, tea.Batch(cmd, searchCollaboratorsCmd(newValue, m.owner, m.isOrgProject))
+	} else if newValue == "" {
+		m.suggestions = nil
+	}
+
+	return m, cmd
+}
+
+func (m CollaboratorsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(collaboratorsTitleStyle.Render("Collaborators"))
+	b.WriteString("\n")
+	b.WriteString(collaboratorsLabelStyle.Render("Project: " + m.project.Title))
+	b.WriteString("\n\n")
+
+	if len(m.collaborators) == 0 {
+		b.WriteString(collaboratorsLabelStyle.Render("No collaborators yet"))
+		b.WriteString("\n")
+	}
+
+	for i, collaborator := range m.collaborators {
+		row := fmt.Sprintf("%-8s %s", strings.ToLower(collaborator.Role), formatCollaborator(collaborator))
+		if i == m.cursor && !m.adding {
+			b.WriteString(collaboratorsSelectedStyle.Render("▸ " + row))
+		} else {
+			b.WriteString(collaboratorsRowStyle.Render(row))
+		}
+		b.WriteString("\n")
+	}
+
+	if m.adding {
+		b.WriteString("\n")
+		b.WriteString(collaboratorsLabelStyle.Render("Add collaborator (as writer):"))
+		b.WriteString("\n")
+		b.WriteString("  " + m.searchInput.View())
+		b.WriteString("\n")
+
+		for i, candidate := range m.suggestions {
+			if i == m.selectedSuggestion {
+				b.WriteString(collaboratorsSelectedStyle.Render("▸ " + formatCollaborator(candidate)))
+			} else {
+				b.WriteString(collaboratorsRowStyle.Render(formatCollaborator(candidate)))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	helpText := "↑/↓: navigate • a: add • r: cycle role • x: remove • esc: back"
+	if m.adding {
+		helpText = "↑/↓: navigate suggestions • enter: add • esc: cancel"
+	}
+	b.WriteString(collaboratorsHelpStyle.Render(helpText))
+
+	return b.String()
+}
+
+// formatCollaborator renders a collaborator as @login or a team name
+func formatCollaborator(collaborator models.ProjectCollaborator) string {
+	if collaborator.Type == "Team" {
+		name := collaborator.Name
+		if name == "" {
+			name = collaborator.Login
+		}
+		return "👥 " + name + " (team)"
+	}
+	return "@" + collaborator.Login
+}
+
+// nextRole cycles through the assignable collaborator roles
+func nextRole(role string) string {
+	switch role {
+	case api.RoleReader:
+		return api.RoleWriter
+	case api.RoleWriter:
+		return api.RoleAdmin
+	default:
+		return api.RoleReader
+	}
+}
+
+// ManageCollaboratorsCmd signals opening the collaborators screen
+func ManageCollaboratorsCmd(project models.Project) tea.Cmd {
+	return func() tea.Msg {
+		return ManageCollaboratorsMsg{Project: project}
+	}
+}
+
+// UpdateCollaboratorCmd signals a role change for a collaborator
+func UpdateCollaboratorCmd(project models.Project, collaborator models.ProjectCollaborator) tea.Cmd {
+	return func() tea.Msg {
+		return UpdateCollaboratorMsg{Project: project, Collaborator: collaborator}
+	}
+}
+
+// searchCollaboratorsCmd signals a search for users and teams to add
+func searchCollaboratorsCmd(query string, owner string, isOrgProject bool) tea.Cmd {
+	return func() tea.Msg {
+		return SearchCollaboratorsMsg{Query: query, Owner: owner, IsOrgProject: isOrgProject}
+	}
+}
+
+func searchCollaborators(ctx context.Context, client api.Interface, msg SearchCollaboratorsMsg) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		var candidates []models.ProjectCollaborator
+
+		var users []string
+		var err error
+		if msg.IsOrgProject {
+			users, err = client.SearchOrgMembers(ctx, msg.Owner, msg.Query, 5)
+		} else {
+			users, err = client.SearchUsers(ctx, msg.Query, 5)
+		}
+		if err == nil {
+			for _, user := range users {
+				candidates = append(candidates, models.ProjectCollaborator{Login: user, Type: "User"})
+			}
+		}
+
+		if msg.IsOrgProject {
+			teams, err := client.SearchOrgTeams(ctx, msg.Owner, msg.Query, 5)
+			if err == nil {
+				for _, team := range teams {
+					candidates = append(candidates, models.ProjectCollaborator{
+						ID:    team.ID,
+						Login: team.Slug,
+						Name:  team.Name,
+						Type:  "Team",
+					})
+				}
+			}
+		}
+
+		// Silently ignore search failures - don't interrupt typing
+		return CollaboratorSuggestionsMsg{Candidates: candidates}
+	})
+}
+
+// ManageCollaboratorsMsg is sent to open the collaborators screen
+type ManageCollaboratorsMsg struct {
+	Project models.Project
+}
+
+// UpdateCollaboratorMsg is sent to add, change or remove a collaborator
+type UpdateCollaboratorMsg struct {
+	Project      models.Project
+	Collaborator models.ProjectCollaborator
+}
+
+// CollaboratorsLoadedMsg is sent when a project's collaborators are loaded
+type CollaboratorsLoadedMsg struct {
+	Project       models.Project
+	Collaborators []models.ProjectCollaborator
+}
+
+// SearchCollaboratorsMsg is sent to look up users and teams matching a query
+type SearchCollaboratorsMsg struct {
+	Query        string
+	Owner        string
+	IsOrgProject bool
+}
+
+// CollaboratorSuggestionsMsg contains user and team search results
+type CollaboratorSuggestionsMsg struct {
+	Candidates []models.ProjectCollaborator
+}
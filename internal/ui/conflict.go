@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+var (
+	conflictHeaderStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#FFA500")).
+				MarginLeft(2).
+				MarginTop(1)
+
+	conflictLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#888888")).
+				MarginLeft(2)
+
+	conflictLineStyle = lipgloss.NewStyle().
+				MarginLeft(4)
+
+	conflictAddedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#04B575")).
+				MarginLeft(4)
+
+	conflictRemovedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FF5F87")).
+				MarginLeft(4)
+)
+
+// Conflict markers written into the body where both sides changed the same lines
+const (
+	conflictMarkerOurs   = "<<<<<<< yours"
+	conflictMarkerSplit  = "======="
+	conflictMarkerTheirs = ">>>>>>> theirs"
+)
+
+// editConflict is a save that was stopped because the item changed on GitHub
+// after the editor opened
+type editConflict struct {
+	theirs models.ItemContent
+	err    *apierrors.APIError
+}
+
+// findConflict reports whether the item was edited on GitHub since base was
+// captured. Updates that leave the title and body alone, e.g. a new assignee,
+// are not conflicts.
+func findConflict(ctx context.Context, client api.Interface, contentID string, base models.ItemContent) (*ItemConflictMsg, error) {
+	theirs, err := client.GetItemContent(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if !theirs.UpdatedAt.After(base.UpdatedAt) {
+		return nil, nil
+	}
+	if theirs.Title == base.Title && theirs.Body == base.Body {
+		return nil, nil
+	}
+
+	return &ItemConflictMsg{
+		Theirs: *theirs,
+		Err: apierrors.ConflictError(fmt.Sprintf("%q was changed on GitHub at %s while you were editing it",
+			theirs.Title, theirs.UpdatedAt.Local().Format("Jan 2 15:04")), nil),
+	}, nil
+}
+
+// renderConflict shows the three versions of the title and body: the base the
+// edit started from, and what each side changed relative to it
+func renderConflict(base models.ItemContent, ours models.ItemContent, conflict *editConflict, maxLines int) string {
+	var b strings.Builder
+
+	b.WriteString(conflictHeaderStyle.Render("⚠ " + conflict.err.Message))
+	b.WriteString("\n\n")
+
+	b.WriteString(conflictLabelStyle.Render("Title:"))
+	b.WriteString("\n")
+	if ours.Title == base.Title && conflict.theirs.Title == base.Title {
+		b.WriteString(conflictLineStyle.Render(base.Title))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(conflictLineStyle.Render("base:   " + base.Title))
+		b.WriteString("\n")
+		b.WriteString(conflictLineStyle.Render("yours:  " + ours.Title))
+		b.WriteString("\n")
+		b.WriteString(conflictLineStyle.Render("theirs: " + conflict.theirs.Title))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(conflictLabelStyle.Render("Your changes to the description:"))
+	b.WriteString("\n")
+	b.WriteString(renderDiff(diffLines(splitLines(base.Body), splitLines(ours.Body)), maxLines))
+
+	b.WriteString("\n")
+	b.WriteString(conflictLabelStyle.Render("Their changes to the description:"))
+	b.WriteString("\n")
+	b.WriteString(renderDiff(diffLines(splitLines(base.Body), splitLines(conflict.theirs.Body)), maxLines))
+
+	return b.String()
+}
+
+// renderDiff renders changed lines with a little context, at most maxLines of them
+func renderDiff(diff []diffLine, maxLines int) string {
+	var lines []string
+	for i, line := range diff {
+		switch line.op {
+		case diffInsert:
+			lines = append(lines, conflictAddedStyle.Render("+ "+line.text))
+		case diffDelete:
+			lines = append(lines, conflictRemovedStyle.Render("- "+line.text))
+		default:
+			// Unchanged lines are only shown next to a change
+			if (i > 0 && diff[i-1].op != diffEqual) || (i < len(diff)-1 && diff[i+1].op != diffEqual) {
+				lines = append(lines, conflictLineStyle.Render("  "+line.text))
+			}
+		}
+	}
+
+	if len(lines) == 0 {
+		return conflictLineStyle.Render("(unchanged)") + "\n"
+	}
+	if maxLines > 0 && len(lines) > maxLines {
+		more := len(lines) - maxLines
+		lines = append(lines[:maxLines], conflictLabelStyle.Render(fmt.Sprintf("  … %d more lines", more)))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffInsert
+	diffDelete
+)
+
+// diffLine is one line of a line-based diff
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// splitLines splits text into lines, treating empty text as no lines
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// matchLines pairs up the lines of a longest common subsequence of a and b.
+// match[i] is the index in b of line i of a, or -1 if it isn't in the subsequence.
+func matchLines(a, b []string) []int {
+	// lengths[i][j] is the LCS length of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	match := make([]int, len(a))
+	i, j := 0, 0
+	for i < len(a) {
+		switch {
+		case j < len(b) && a[i] == b[j]:
+			match[i] = j
+			i++
+			j++
+		case j < len(b) && lengths[i][j+1] > lengths[i+1][j]:
+			j++
+		default:
+			match[i] = -1
+			i++
+		}
+	}
+	return match
+}
+
+// diffLines returns the edits that turn a into b
+func diffLines(a, b []string) []diffLine {
+	var diff []diffLine
+	j := 0
+	for i, m := range matchLines(a, b) {
+		if m < 0 {
+			diff = append(diff, diffLine{op: diffDelete, text: a[i]})
+			continue
+		}
+		for ; j < m; j++ {
+			diff = append(diff, diffLine{op: diffInsert, text: b[j]})
+		}
+		diff = append(diff, diffLine{op: diffEqual, text: a[i]})
+		j++
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, diffLine{op: diffInsert, text: b[j]})
+	}
+	return diff
+}
+
+// mergeText combines two edits of base line by line. Where both sides changed
+// the same lines differently, both versions are kept between conflict markers
+// and the second result is true.
+func mergeText(base, ours, theirs string) (string, bool) {
+	baseLines := splitLines(base)
+	oursLines := splitLines(ours)
+	theirsLines := splitLines(theirs)
+	oursMatch := matchLines(baseLines, oursLines)
+	theirsMatch := matchLines(baseLines, theirsLines)
+
+	var merged []string
+	conflicted := false
+	b, o, t := 0, 0, 0
+
+	// Walk the base lines both sides kept; the stretches between them are
+	// where at least one side made changes
+	for i := 0; i <= len(baseLines); i++ {
+		stable := i == len(baseLines)
+		if !stable && (oursMatch[i] < 0 || theirsMatch[i] < 0) {
+			continue
+		}
+
+		oEnd, tEnd := len(oursLines), len(theirsLines)
+		if !stable {
+			oEnd, tEnd = oursMatch[i], theirsMatch[i]
+		}
+		baseChunk, oursChunk, theirsChunk := baseLines[b:i], oursLines[o:oEnd], theirsLines[t:tEnd]
+
+		switch {
+		case equalLines(oursChunk, baseChunk):
+			merged = append(merged, theirsChunk...)
+		case equalLines(theirsChunk, baseChunk), equalLines(oursChunk, theirsChunk):
+			merged = append(merged, oursChunk...)
+		default:
+			conflicted = true
+			merged = append(merged, conflictMarkerOurs)
+			merged = append(merged, oursChunk...)
+			merged = append(merged, conflictMarkerSplit)
+			merged = append(merged, theirsChunk...)
+			merged = append(merged, conflictMarkerTheirs)
+		}
+
+		if !stable {
+			merged = append(merged, baseLines[i])
+			b, o, t = i+1, oEnd+1, tEnd+1
+		}
+	}
+
+	return strings.Join(merged, "\n"), conflicted
+}
+
+// mergeTitle combines two edits of a single-line title, keeping ours when
+// both sides changed it
+func mergeTitle(base, ours, theirs string) (string, bool) {
+	switch {
+	case ours == base:
+		return theirs, false
+	case theirs == base, ours == theirs:
+		return ours, false
+	default:
+		return ours, true
+	}
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// DiscardEditCmd signals abandoning an edit in favour of the version on GitHub
+func DiscardEditCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DiscardEditMsg{}
+	}
+}
+
+// ItemConflictMsg is sent when a save was stopped because the item changed on GitHub
+type ItemConflictMsg struct {
+	Theirs models.ItemContent
+	Err    *apierrors.APIError // Of type ErrorTypeConflict
+}
+
+// DiscardEditMsg is sent when the user drops their edit after a conflict
+type DiscardEditMsg struct{}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMatchLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []int
+	}{
+		{"identical", "a\nb\nc", "a\nb\nc", []int{0, 1, 2}},
+		{"line removed", "a\nb\nc", "a\nc", []int{0, -1, 1}},
+		{"line added", "a\nc", "a\nb\nc", []int{0, 2}},
+		{"repeated line", "a\na", "a", []int{0, -1}},
+		{"all removed", "a\nb", "", []int{-1, -1}},
+		{"empty base", "", "a\nb", []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchLines(splitLines(tt.a), splitLines(tt.b))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("matchLines(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"unchanged", "a\nb", "a\nb", " a  b"},
+		{"line changed", "a\nb\nc", "a\nx\nc", " a -b +x  c"},
+		{"line added at end", "a", "a\nb", " a +b"},
+		{"line removed at start", "a\nb", "b", "-a  b"},
+		{"empty base", "", "a\nb", "+a +b"},
+		{"emptied body", "a\nb", "", "-a -b"},
+		{"both empty", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []string
+			for _, line := range diffLines(splitLines(tt.a), splitLines(tt.b)) {
+				ops = append(ops, map[diffOp]string{diffEqual: " ", diffInsert: "+", diffDelete: "-"}[line.op]+line.text)
+			}
+			if got := strings.Join(ops, " "); got != tt.want {
+				t.Errorf("diffLines(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeText(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs string
+		want               string
+		conflict           bool
+	}{
+		{
+			name: "separate lines changed",
+			base: "a\nb\nc\nd", ours: "A\nb\nc\nd", theirs: "a\nb\nc\nD",
+			want: "A\nb\nc\nD",
+		},
+		{
+			name: "lines added in different places",
+			base: "a\nb\nc", ours: "a\nx\nb\nc", theirs: "a\nb\nc\ny",
+			want: "a\nx\nb\nc\ny",
+		},
+		{
+			name: "same line changed differently",
+			base: "a\nb\nc", ours: "a\nyours\nc", theirs: "a\ntheirs\nc",
+			want:     "a\n<<<<<<< yours\nyours\n=======\ntheirs\n>>>>>>> theirs\nc",
+			conflict: true,
+		},
+		{
+			name: "adjacent lines changed",
+			base: "a\nb", ours: "A\nb", theirs: "a\nB",
+			want:     "<<<<<<< yours\nA\nb\n=======\na\nB\n>>>>>>> theirs",
+			conflict: true,
+		},
+		{
+			name: "same change on both sides",
+			base: "a\nb\nc", ours: "a\nB\nc", theirs: "a\nB\nc",
+			want: "a\nB\nc",
+		},
+		{
+			name: "only ours changed",
+			base: "a\nb", ours: "a\nb\nc", theirs: "a\nb",
+			want: "a\nb\nc",
+		},
+		{
+			name: "only theirs changed",
+			base: "a\nb", ours: "a\nb", theirs: "x\nb",
+			want: "x\nb",
+		},
+		{
+			name: "empty base, one side wrote a body",
+			base: "", ours: "", theirs: "a",
+			want: "a",
+		},
+		{
+			name: "empty base, both sides wrote a body",
+			base: "", ours: "mine", theirs: "other",
+			want:     "<<<<<<< yours\nmine\n=======\nother\n>>>>>>> theirs",
+			conflict: true,
+		},
+		{
+			name: "ours emptied an unchanged body",
+			base: "a\nb", ours: "", theirs: "a\nb",
+			want: "",
+		},
+		{
+			name: "ours emptied a body theirs changed",
+			base: "a\nb", ours: "", theirs: "a\nB",
+			want:     "<<<<<<< yours\n=======\na\nB\n>>>>>>> theirs",
+			conflict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflict := mergeText(tt.base, tt.ours, tt.theirs)
+			if got != tt.want || conflict != tt.conflict {
+				t.Errorf("mergeText(%q, %q, %q) = %q, %t, want %q, %t", tt.base, tt.ours, tt.theirs, got, conflict, tt.want, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestMergeTitle(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs string
+		want               string
+		conflict           bool
+	}{
+		{"neither changed", "Crash", "Crash", "Crash", "Crash", false},
+		{"only ours changed", "Crash", "Crash on start", "Crash", "Crash on start", false},
+		{"only theirs changed", "Crash", "Crash", "Crash on exit", "Crash on exit", false},
+		{"same change", "Crash", "Crash on start", "Crash on start", "Crash on start", false},
+		{"both changed", "Crash", "Crash on start", "Crash on exit", "Crash on start", true},
+		{"empty base", "", "Crash", "", "Crash", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflict := mergeTitle(tt.base, tt.ours, tt.theirs)
+			if got != tt.want || conflict != tt.conflict {
+				t.Errorf("mergeTitle(%q, %q, %q) = %q, %t, want %q, %t", tt.base, tt.ours, tt.theirs, got, conflict, tt.want, tt.conflict)
+			}
+		})
+	}
+}
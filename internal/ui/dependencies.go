@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// relatedIssue is an issue or pull request related to the item, and how it's related
+type relatedIssue struct {
+	ref      models.IssueRef
+	relation string // "parent", "sub-issue", "blocked by", "blocking" or "pull request"
+}
+
+// dependencies lists the issues an item is blocked by, then those it blocks
+func dependencies(item models.ProjectItem) []relatedIssue {
+	var deps []relatedIssue
+	for _, ref := range item.BlockedBy {
+		deps = append(deps, relatedIssue{ref: ref, relation: "blocked by"})
+	}
+	for _, ref := range item.Blocking {
+		deps = append(deps, relatedIssue{ref: ref, relation: "blocking"})
+	}
+	return deps
+}
+
+// dependencyForm asks which issue to add as a blocker or as blocked, which
+// dependency to remove, or which related issue or pull request to go to
+type dependencyForm struct {
+	kind        string // "blockedBy", "blocking", "remove" or "goto"
+	project     models.Project
+	item        models.ProjectItem
+	deps        []relatedIssue // Choices when removing or going to one
+	numberInput textinput.Model
+	choice      int
+	err         string
+}
+
+func newDependencyForm(kind string, project models.Project, item models.ProjectItem) (dependencyForm, tea.Cmd) {
+	ni := textinput.New()
+	ni.Placeholder = "Issue number"
+	ni.CharLimit = 10
+	ni.Width = 20
+
+	f := dependencyForm{
+		kind:        kind,
+		project:     project,
+		item:        item,
+		deps:        dependencies(item),
+		numberInput: ni,
+	}
+	if kind == "goto" {
+		f.deps = relatedIssues(item)
+	}
+	if kind == "remove" || kind == "goto" {
+		return f, nil
+	}
+	return f, f.numberInput.Focus()
+}
+
+func (f dependencyForm) update(msg tea.KeyMsg) (dependencyForm, tea.Cmd) {
+	f.err = ""
+	if f.kind == "remove" || f.kind == "goto" {
+		f.choice = moveChoice(f.choice, len(f.deps), msg.String())
+		return f, nil
+	}
+	var cmd tea.Cmd
+	f.numberInput, cmd = f.numberInput.Update(msg)
+	return f, cmd
+}
+
+// submit checks the form and returns the command for the change, or no
+// command when the form needs fixing
+func (f dependencyForm) submit() (dependencyForm, tea.Cmd) {
+	project, item := f.project, f.item
+	switch f.kind {
+	case "remove", "goto":
+		if f.choice >= len(f.deps) {
+			return f, nil
+		}
+		dep := f.deps[f.choice]
+		if f.kind == "goto" {
+			return f, func() tea.Msg { return OpenRelatedIssueMsg{Ref: dep.ref} }
+		}
+		blocking := dep.relation == "blocking"
+		return f, func() tea.Msg {
+			return RemoveDependencyMsg{Project: project, Item: item, Ref: dep.ref, Blocking: blocking}
+		}
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(f.numberInput.Value()), "#"))
+	if err != nil || number <= 0 {
+		f.err = "Enter an issue number"
+		return f, nil
+	}
+	if number == item.Number {
+		f.err = "An issue can't block itself"
+		return f, nil
+	}
+	blocking := f.kind == "blocking"
+	return f, func() tea.Msg {
+		return AddDependencyMsg{Project: project, Item: item, Number: number, Blocking: blocking}
+	}
+}
+
+func (f dependencyForm) view() string {
+	var b strings.Builder
+
+	switch f.kind {
+	case "blockedBy":
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("#%d is blocked by", f.item.Number)))
+		b.WriteString("\n")
+		b.WriteString(issueStateLabelStyle.Render("Issue #"))
+		b.WriteString(f.numberInput.View())
+		b.WriteString("\n")
+	case "blocking":
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("#%d is blocking", f.item.Number)))
+		b.WriteString("\n")
+		b.WriteString(issueStateLabelStyle.Render("Issue #"))
+		b.WriteString(f.numberInput.View())
+		b.WriteString("\n")
+	case "remove", "goto":
+		title := "Remove a dependency"
+		if f.kind == "goto" {
+			title = "Go to a related issue or pull request"
+		}
+		b.WriteString(itemDetailLabelStyle.Render(title))
+		b.WriteString("\n")
+		for i, dep := range f.deps {
+			b.WriteString(choiceLine(dep.relation+" "+issueRefText(dep.ref), i == f.choice, true))
+		}
+	}
+
+	if f.err != "" {
+		b.WriteString(issueStateErrorStyle.Render(f.err))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (f dependencyForm) helpText() string {
+	switch f.kind {
+	case "remove":
+		return "↑/↓: dependency • enter: remove • esc: cancel"
+	case "goto":
+		return "↑/↓: issue • enter: open • esc: cancel"
+	}
+	return "enter: save • esc: cancel"
+}
+
+// AddDependencyMsg is sent to make an item blocked by another issue, or
+// blocking it
+type AddDependencyMsg struct {
+	Project  models.Project
+	Item     models.ProjectItem
+	Number   int  // Number of the other issue, in the item's repository
+	Blocking bool // The item blocks the other issue
+}
+
+// RemoveDependencyMsg is sent to remove a dependency between an item and
+// another issue
+type RemoveDependencyMsg struct {
+	Project  models.Project
+	Item     models.ProjectItem
+	Ref      models.IssueRef
+	Blocking bool // The item blocks Ref
+}
+
+// OpenRelatedIssueMsg is sent to go to an issue or pull request related to
+// the one in view
+type OpenRelatedIssueMsg struct {
+	Ref models.IssueRef
+}
+
+func addDependency(ctx context.Context, client api.Interface, msg AddDependencyMsg) tea.Cmd {
+	return issueRelationAction(ctx, client, msg.Project, msg.Item, func() error {
+		otherID, err := client.GetIssueID(ctx, msg.Item.RepositoryID, msg.Number)
+		if err != nil {
+			return err
+		}
+		if msg.Blocking {
+			return client.AddBlockedBy(ctx, otherID, msg.Item.ContentID)
+		}
+		return client.AddBlockedBy(ctx, msg.Item.ContentID, otherID)
+	})
+}
+
+func removeDependency(ctx context.Context, client api.Interface, msg RemoveDependencyMsg) tea.Cmd {
+	return issueRelationAction(ctx, client, msg.Project, msg.Item, func() error {
+		if msg.Blocking {
+			return client.RemoveBlockedBy(ctx, msg.Ref.ID, msg.Item.ContentID)
+		}
+		return client.RemoveBlockedBy(ctx, msg.Item.ContentID, msg.Ref.ID)
+	})
+}
+
+// isBlocked reports whether an item waits on an open issue
+func isBlocked(item models.ProjectItem) bool {
+	return item.Dependencies.OpenBlockers > 0
+}
+
+// isReadyWork reports whether an item is open and waits on nothing. Drafts
+// have no state and are always open.
+func isReadyWork(item models.ProjectItem) bool {
+	open := item.Type == "DraftIssue" || item.State == "OPEN"
+	return open && !isBlocked(item)
+}
+
+// readyWork returns the items that are open and not blocked
+func readyWork(items []models.ProjectItem) []models.ProjectItem {
+	var ready []models.ProjectItem
+	for _, item := range items {
+		if isReadyWork(item) {
+			ready = append(ready, item)
+		}
+	}
+	return ready
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// titles lists the items' titles
+func titles(items []models.ProjectItem) string {
+	var list []string
+	for _, item := range items {
+		list = append(list, item.Title)
+	}
+	return fmt.Sprint(list)
+}
+
+func TestReadyWork(t *testing.T) {
+	ctx := context.Background()
+	c, p, r := newBoard()
+	build := c.AddIssue(p.ID, r.ID, "Build API", "")
+	schema := c.AddIssue(p.ID, r.ID, "Design schema", "")
+	docs := c.AddIssue(p.ID, r.ID, "Write docs", "")
+	c.AddDraft(p.ID, "Idea", "")
+	if err := c.CloseIssue(ctx, docs.ContentID, "COMPLETED", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// The schema blocks the API
+	msg := addDependency(ctx, c, AddDependencyMsg{Project: p, Item: schema, Number: build.Number, Blocking: true})()
+	changed, ok := msg.(IssueRelationsChangedMsg)
+	if !ok {
+		t.Fatalf("got %#v, want IssueRelationsChangedMsg", msg)
+	}
+	if got, want := titles(readyWork(changed.Items)), "[Design schema Idea]"; got != want {
+		t.Errorf("ready = %s, want %s", got, want)
+	}
+	if blocked := findItem(t, c, p.ID, build.ID); !isBlocked(blocked) || rowStatus(blocked) != "OPEN ⛔" {
+		t.Errorf("API blocked = %v, status %q, want blocked", isBlocked(blocked), rowStatus(blocked))
+	}
+
+	// Finishing the schema unblocks the API
+	if err := c.CloseIssue(ctx, schema.ContentID, "COMPLETED", ""); err != nil {
+		t.Fatal(err)
+	}
+	items, err := c.ListProjectItems(ctx, p.ID, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := titles(readyWork(items)), "[Build API Idea]"; got != want {
+		t.Errorf("ready = %s, want %s", got, want)
+	}
+}
+
+func TestRemoveDependency(t *testing.T) {
+	ctx := context.Background()
+	c, p, r := newBoard()
+	build := c.AddIssue(p.ID, r.ID, "Build API", "")
+	schema := c.AddIssue(p.ID, r.ID, "Design schema", "")
+	if err := c.AddBlockedBy(ctx, build.ContentID, schema.ContentID); err != nil {
+		t.Fatal(err)
+	}
+	build = findItem(t, c, p.ID, build.ID)
+	deps := dependencies(build)
+	if len(deps) != 1 {
+		t.Fatalf("dependencies = %+v, want the schema", deps)
+	}
+
+	msg := removeDependency(ctx, c, RemoveDependencyMsg{Project: p, Item: build, Ref: deps[0].ref})()
+
+	changed, ok := msg.(IssueRelationsChangedMsg)
+	if !ok {
+		t.Fatalf("got %#v, want IssueRelationsChangedMsg", msg)
+	}
+	if got, want := titles(readyWork(changed.Items)), "[Build API Design schema]"; got != want {
+		t.Errorf("ready = %s, want %s", got, want)
+	}
+}
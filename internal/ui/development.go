@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+var prMergedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A371F7"))
+
+// relatedIssues lists everything the item view can go to: the parent,
+// sub-issues, dependencies and the pull requests that close the issue
+func relatedIssues(item models.ProjectItem) []relatedIssue {
+	var related []relatedIssue
+	if item.Parent != nil {
+		related = append(related, relatedIssue{ref: *item.Parent, relation: "parent"})
+	}
+	for _, ref := range item.SubIssues {
+		related = append(related, relatedIssue{ref: ref, relation: "sub-issue"})
+	}
+	related = append(related, dependencies(item)...)
+	for _, ref := range item.LinkedPullRequests {
+		related = append(related, relatedIssue{ref: ref, relation: "pull request"})
+	}
+	return related
+}
+
+// linkedPullRequestText describes a pull request linked to an issue in one
+// line, led by its state
+func linkedPullRequestText(ref models.IssueRef) string {
+	state := prGoodStyle.Render(ref.State)
+	switch ref.State {
+	case "MERGED":
+		state = prMergedStyle.Render(ref.State)
+	case "CLOSED":
+		state = prBadStyle.Render(ref.State)
+	}
+	return fmt.Sprintf("%s %s#%d %s", state, ref.Repository, ref.Number, ref.Title)
+}
+
+// linkedBranchText describes a branch linked to an issue
+func linkedBranchText(branch models.LinkedBranch) string {
+	return branch.Repository + ":" + branch.Name
+}
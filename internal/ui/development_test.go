@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLinkedPullRequestsAndBranches(t *testing.T) {
+	c, p, r := newBoard()
+	issue := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+	pr := c.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+	c.LinkPullRequest(issue.ContentID, pr.ContentID)
+	c.LinkBranch(issue.ContentID, r.ID, "fix-crash")
+
+	msg := loadItemDetails(context.Background(), c, issue, false)().(cancellableMsg).msg
+	loaded, ok := msg.(ItemDetailsLoadedMsg)
+	if !ok {
+		t.Fatalf("got %#v, want ItemDetailsLoadedMsg", msg)
+	}
+	item := applyItemDetails(issue, loaded.Details)
+
+	related := relatedIssues(item)
+	if len(related) != 1 || related[0].relation != "pull request" || related[0].ref.ID != pr.ContentID {
+		t.Fatalf("related = %+v, want the pull request", related)
+	}
+	if text := linkedPullRequestText(related[0].ref); !strings.Contains(text, "OPEN") || !strings.Contains(text, "octocat/api#2 Fix crash") {
+		t.Errorf("pull request = %q", text)
+	}
+	if len(item.LinkedBranches) != 1 || linkedBranchText(item.LinkedBranches[0]) != "octocat/api:fix-crash" {
+		t.Errorf("branches = %+v, want octocat/api:fix-crash", item.LinkedBranches)
+	}
+
+	// Going to the pull request opens it in the item view, as it's on the board
+	m := NewModel(Options{Client: c})
+	m.projectDetail = NewProjectDetailModel(p, c.Items(p.ID))
+	msg = update(&m, OpenRelatedIssueMsg{Ref: related[0].ref})()
+	if view, ok := msg.(ViewItemMsg); !ok || view.Item.ID != pr.ID {
+		t.Errorf("got %#v, want ViewItemMsg for the pull request", msg)
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openEditorCmd suspends the TUI and lets the user edit text in $VISUAL/$EDITOR
+func openEditorCmd(initial string) tea.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	file, err := os.CreateTemp("", "ghptui-*.md")
+	if err != nil {
+		return func() tea.Msg {
+			return ExternalEditorMsg{Err: fmt.Errorf("failed to create temp file: %w", err)}
+		}
+	}
+	path := file.Name()
+	_, err = file.WriteString(initial)
+	file.Close()
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg {
+			return ExternalEditorMsg{Err: fmt.Errorf("failed to write temp file: %w", err)}
+		}
+	}
+
+	// EDITOR may carry arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return ExternalEditorMsg{Err: fmt.Errorf("editor exited with error: %w", err)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return ExternalEditorMsg{Err: fmt.Errorf("failed to read edited text: %w", err)}
+		}
+		return ExternalEditorMsg{Text: strings.TrimRight(string(data), "\n")}
+	})
+}
+
+// ExternalEditorMsg is sent when the external editor exits
+type ExternalEditorMsg struct {
+	Text string
+	Err  error
+}
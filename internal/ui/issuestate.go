@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// closeReasons are the reasons an issue can be closed for, in the order offered
+var closeReasons = []struct {
+	reason string
+	label  string
+}{
+	{"COMPLETED", "Completed"},
+	{"NOT_PLANNED", "Not planned"},
+	{"DUPLICATE", "Duplicate of..."},
+}
+
+var (
+	issueStateLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#888888")).
+				MarginLeft(2)
+
+	issueStateErrorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FF5F87")).
+				MarginLeft(2)
+)
+
+// issueStateForm asks how to close or reopen one or more issues: the reason
+// they're closed for, the issue they duplicate and an optional comment
+type issueStateForm struct {
+	project        models.Project
+	items          []models.ProjectItem
+	close          bool // Close the issues, or reopen them
+	reason         int  // Index into closeReasons
+	duplicateInput textinput.Model
+	commentInput   textarea.Model
+	focus          int // 0 reason, 1 duplicate number, 2 comment
+	err            string
+}
+
+func newIssueStateForm(project models.Project, items []models.ProjectItem, close bool, width int) issueStateForm {
+	di := textinput.New()
+	di.Placeholder = "Issue number"
+	di.CharLimit = 10
+	di.Width = 20
+
+	inputWidth := width - 10
+	if inputWidth < 40 {
+		inputWidth = 40
+	}
+	ta := textarea.New()
+	ta.Placeholder = "Comment (optional)"
+	ta.CharLimit = 65536
+	ta.SetWidth(inputWidth)
+	ta.SetHeight(4)
+
+	f := issueStateForm{
+		project:        project,
+		items:          items,
+		close:          close,
+		duplicateInput: di,
+		commentInput:   ta,
+	}
+	if !close {
+		// Reopening only takes a comment
+		f.focus = 2
+		f.commentInput.Focus()
+	}
+	return f
+}
+
+// fields returns the fields tab moves through
+func (f issueStateForm) fields() []int {
+	switch {
+	case !f.close:
+		return []int{2}
+	case closeReasons[f.reason].reason == "DUPLICATE":
+		return []int{0, 1, 2}
+	default:
+		return []int{0, 2}
+	}
+}
+
+func (f issueStateForm) update(msg tea.KeyMsg) (issueStateForm, tea.Cmd) {
+	f.err = ""
+	switch msg.String() {
+	case "tab", "shift+tab":
+		fields := f.fields()
+		index := 0
+		for i, field := range fields {
+			if field == f.focus {
+				index = i
+			}
+		}
+		if msg.String() == "tab" {
+			index = (index + 1) % len(fields)
+		} else {
+			index = (index + len(fields) - 1) % len(fields)
+		}
+		return f.focusField(fields[index]), nil
+	}
+
+	var cmd tea.Cmd
+	switch f.focus {
+	case 0:
+		switch msg.String() {
+		case "up", "k":
+			if f.reason > 0 {
+				f.reason--
+			}
+		case "down", "j":
+			if f.reason < len(closeReasons)-1 {
+				f.reason++
+			}
+		}
+	case 1:
+		f.duplicateInput, cmd = f.duplicateInput.Update(msg)
+	case 2:
+		f.commentInput, cmd = f.commentInput.Update(msg)
+	}
+	return f, cmd
+}
+
+func (f issueStateForm) focusField(field int) issueStateForm {
+	f.focus = field
+	f.duplicateInput.Blur()
+	f.commentInput.Blur()
+	switch field {
+	case 1:
+		f.duplicateInput.Focus()
+	case 2:
+		f.commentInput.Focus()
+	}
+	return f
+}
+
+// submit checks the form and returns the command that changes the issues'
+// state, or no command when the form needs fixing
+func (f issueStateForm) submit() (issueStateForm, tea.Cmd) {
+	msg := ChangeIssueStateMsg{
+		Project: f.project,
+		Items:   f.items,
+		Close:   f.close,
+		Comment: strings.TrimSpace(f.commentInput.Value()),
+	}
+	if f.close {
+		msg.Reason = closeReasons[f.reason].reason
+		if msg.Reason == "DUPLICATE" {
+			number, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(f.duplicateInput.Value()), "#"))
+			if err != nil || number <= 0 {
+				f.err = "Enter the number of the issue they duplicate"
+				return f.focusField(1), nil
+			}
+			msg.DuplicateOf = number
+		}
+	}
+	return f, func() tea.Msg { return msg }
+}
+
+func (f issueStateForm) view() string {
+	var b strings.Builder
+
+	action := "Reopen"
+	if f.close {
+		action = "Close"
+	}
+	subject := fmt.Sprintf("%d issues", len(f.items))
+	if len(f.items) == 1 {
+		subject = fmt.Sprintf("issue #%d", f.items[0].Number)
+	}
+	b.WriteString(itemDetailLabelStyle.Render(action + " " + subject))
+	b.WriteString("\n")
+
+	if f.close {
+		b.WriteString(issueStateLabelStyle.Render("Reason:"))
+		b.WriteString("\n")
+		for i, reason := range closeReasons {
+			b.WriteString(choiceLine(reason.label, i == f.reason, f.focus == 0))
+		}
+		if closeReasons[f.reason].reason == "DUPLICATE" {
+			b.WriteString(issueStateLabelStyle.Render("Duplicate of #"))
+			b.WriteString(f.duplicateInput.View())
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(issueStateLabelStyle.Render("Comment:"))
+	b.WriteString("\n")
+	b.WriteString("  " + f.commentInput.View())
+	b.WriteString("\n")
+
+	if f.err != "" {
+		b.WriteString(issueStateErrorStyle.Render(f.err))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (f issueStateForm) helpText() string {
+	if f.close {
+		return "↑/↓: reason • tab: next field • ctrl+s: close • esc: cancel"
+	}
+	return "ctrl+s: reopen • esc: cancel"
+}
+
+// ChangeIssueStateMsg is sent to close or reopen issues
+type ChangeIssueStateMsg struct {
+	Project     models.Project
+	Items       []models.ProjectItem
+	Close       bool
+	Reason      string // Close reason, see closeReasons
+	DuplicateOf int    // Number of the duplicated issue, in each issue's own repository
+	Comment     string // Posted before the state changes, if set
+}
+
+// IssueStateChangedMsg reports the issues whose state was changed
+type IssueStateChangedMsg struct {
+	Items    []models.ProjectItem      // Updated copies of the issues that changed
+	Comments map[string]models.Comment // Comments posted, by project item ID
+	Failed   int
+	Err      error // Why the first failed issue failed
+}
+
+// changeIssueState closes or reopens each issue in turn, posting the comment
+// first. An issue that fails doesn't stop the others.
+func changeIssueState(ctx context.Context, client api.Interface, msg ChangeIssueStateMsg) tea.Cmd {
+	return func() tea.Msg {
+		result := IssueStateChangedMsg{Comments: make(map[string]models.Comment)}
+		for _, item := range msg.Items {
+			comment, err := changeOneIssueState(ctx, client, msg, item)
+			if comment != nil {
+				result.Comments[item.ID] = *comment
+			}
+			if err != nil {
+				result.Failed++
+				if result.Err == nil {
+					result.Err = fmt.Errorf("failed to change #%d: %w", item.Number, err)
+				}
+				continue
+			}
+
+			if msg.Close {
+				item.State = "CLOSED"
+				item.StateReason = msg.Reason
+			} else {
+				item.State = "OPEN"
+				item.StateReason = "REOPENED"
+			}
+			result.Items = append(result.Items, item)
+		}
+		return result
+	}
+}
+
+func changeOneIssueState(ctx context.Context, client api.Interface, msg ChangeIssueStateMsg, item models.ProjectItem) (*models.Comment, error) {
+	var duplicateOfID string
+	if msg.DuplicateOf > 0 {
+		id, err := client.GetIssueID(ctx, item.RepositoryID, msg.DuplicateOf)
+		if err != nil {
+			return nil, err
+		}
+		// Checked before commenting, so a mistyped number leaves no comment behind
+		if id == item.ContentID {
+			return nil, fmt.Errorf("issue #%d can't be a duplicate of itself", item.Number)
+		}
+		duplicateOfID = id
+	}
+
+	var comment *models.Comment
+	if msg.Comment != "" {
+		added, err := client.AddComment(ctx, item.ContentID, msg.Comment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add comment: %w", err)
+		}
+		comment = added
+	}
+
+	if msg.Close {
+		return comment, client.CloseIssue(ctx, item.ContentID, msg.Reason, duplicateOfID)
+	}
+	return comment, client.ReopenIssue(ctx, item.ContentID)
+}
+
+// issuesInState returns the issues among items that are in the given state,
+// the ones that can be closed ("OPEN") or reopened ("CLOSED")
+func issuesInState(items []models.ProjectItem, state string) []models.ProjectItem {
+	var issues []models.ProjectItem
+	for _, item := range items {
+		if item.Type == "Issue" && item.State == state {
+			issues = append(issues, item)
+		}
+	}
+	return issues
+}
+
+// stateText describes an issue's or pull request's state with the reason it was closed
+func stateText(item models.ProjectItem) string {
+	switch item.StateReason {
+	case "NOT_PLANNED":
+		return item.State + " (not planned)"
+	case "DUPLICATE":
+		return item.State + " (duplicate)"
+	}
+	return item.State
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+func TestChangeIssueStateClosesAsDuplicate(t *testing.T) {
+	c, p, r := newBoard()
+	original := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+	duplicate := c.AddIssue(p.ID, r.ID, "App crashes", "")
+
+	msg := changeIssueState(context.Background(), c, ChangeIssueStateMsg{
+		Project:     p,
+		Items:       []models.ProjectItem{duplicate},
+		Close:       true,
+		Reason:      "DUPLICATE",
+		DuplicateOf: original.Number,
+		Comment:     "Tracked in #1",
+	})().(IssueStateChangedMsg)
+
+	if msg.Failed != 0 || len(msg.Items) != 1 || msg.Items[0].State != "CLOSED" || msg.Items[0].StateReason != "DUPLICATE" {
+		t.Fatalf("got %+v, want the issue closed as a duplicate", msg)
+	}
+	if comment, ok := msg.Comments[duplicate.ID]; !ok || comment.Body != "Tracked in #1" {
+		t.Errorf("comments = %+v, want the comment posted", msg.Comments)
+	}
+	if item := findItem(t, c, p.ID, duplicate.ID); item.State != "CLOSED" || item.StateReason != "DUPLICATE" {
+		t.Errorf("state = %s %s, want CLOSED DUPLICATE", item.State, item.StateReason)
+	}
+}
+
+func TestChangeIssueStateRejectsDuplicateOfItself(t *testing.T) {
+	c, p, r := newBoard()
+	issue := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+
+	msg := changeIssueState(context.Background(), c, ChangeIssueStateMsg{
+		Project:     p,
+		Items:       []models.ProjectItem{issue},
+		Close:       true,
+		Reason:      "DUPLICATE",
+		DuplicateOf: issue.Number,
+		Comment:     "Duplicate",
+	})().(IssueStateChangedMsg)
+
+	if msg.Failed != 1 || msg.Err == nil || len(msg.Comments) != 0 {
+		t.Fatalf("got %+v, want the issue refused without a comment", msg)
+	}
+	if item := findItem(t, c, p.ID, issue.ID); item.State != "OPEN" {
+		t.Errorf("state = %s, want OPEN", item.State)
+	}
+}
+
+func TestChangeIssueStateContinuesAfterFailure(t *testing.T) {
+	c, p, r := newBoard()
+	items := []models.ProjectItem{
+		c.AddIssue(p.ID, r.ID, "Crash on start", ""),
+		c.AddIssue(p.ID, r.ID, "Typo in docs", ""),
+		c.AddIssue(p.ID, r.ID, "Slow search", ""),
+	}
+	c.FailNext("CloseIssue", apierrors.PermissionError("Resource not accessible", nil))
+
+	msg := changeIssueState(context.Background(), c, ChangeIssueStateMsg{
+		Project: p,
+		Items:   items,
+		Close:   true,
+		Reason:  "NOT_PLANNED",
+	})().(IssueStateChangedMsg)
+
+	if msg.Failed != 1 || msg.Err == nil || len(msg.Items) != 2 {
+		t.Fatalf("got %+v, want the other two closed", msg)
+	}
+	want := []string{"OPEN", "CLOSED", "CLOSED"}
+	for i, item := range items {
+		if got := findItem(t, c, p.ID, item.ID).State; got != want[i] {
+			t.Errorf("#%d state = %s, want %s", item.Number, got, want[i])
+		}
+	}
+
+	// Reopening brings the closed ones back
+	msg = changeIssueState(context.Background(), c, ChangeIssueStateMsg{Project: p, Items: msg.Items})().(IssueStateChangedMsg)
+	if msg.Failed != 0 || len(msg.Items) != 2 || msg.Items[0].StateReason != "REOPENED" {
+		t.Fatalf("got %+v, want both reopened", msg)
+	}
+	for _, item := range items {
+		if got := findItem(t, c, p.ID, item.ID).State; got != "OPEN" {
+			t.Errorf("#%d state = %s, want OPEN", item.Number, got)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/thomaskoefod/githubProjectTUI/internal/models"
@@ -47,20 +50,56 @@ var (
 
 // ItemDetailModel represents the item detail view
 type ItemDetailModel struct {
-	project models.Project
-	item    models.ProjectItem
-	width   int
-	height  int
+	project          models.Project
+	item             models.ProjectItem
+	loadingDetails   bool // Body, comments and timeline are still being fetched
+	detailsErr       error // Why the details failed to load, r retries
+	spinner          spinner.Model
+	selectedComment  int  // Index into item.Comments
+	composing        bool // Comment composer is open
+	editingCommentID string
+	commentInput     textarea.Model
+	confirmDelete    bool // Waiting for a second press to delete the selected comment
+	stateForm        *issueStateForm // Close or reopen form, when open
+	prForm           *pullRequestForm // Pull request action form, when open
+	subIssueForm     *subIssueForm    // Sub-issue or parent form, when open
+	dependencyForm   *dependencyForm  // Dependency form, when open
+	showTimeline     bool             // Timeline tab is shown instead of the details
+	timeline         timelineTab      // Kept while hidden so the filter stays picked
+	width            int
+	height           int
 }
 
 func NewItemDetailModel(project models.Project, item models.ProjectItem) ItemDetailModel {
+	ta := textarea.New()
+	ta.Placeholder = "Write a comment (ctrl+e for $EDITOR)"
+	ta.CharLimit = 65536
+	ta.SetWidth(80)
+	ta.SetHeight(6)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
+
 	return ItemDetailModel{
-		project: project,
-		item:    item,
+		project:         project,
+		item:            item,
+		loadingDetails:  !item.DetailsLoaded,
+		spinner:         sp,
+		selectedComment: len(item.Comments) - 1,
+		commentInput:    ta,
 	}
 }
 
+// canComment reports whether the item is an issue or PR that accepts comments
+func (m ItemDetailModel) canComment() bool {
+	return m.item.Type == "Issue" || m.item.Type == "PullRequest"
+}
+
 func (m ItemDetailModel) Init() tea.Cmd {
+	if m.loadingDetails {
+		return m.spinner.Tick
+	}
 	return nil
 }
 
@@ -69,10 +108,174 @@ func (m ItemDetailModel) Update(msg tea.Msg) (ItemDetailModel, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		inputWidth := msg.Width - 10
+		if inputWidth < 40 {
+			inputWidth = 40
+		}
+		m.commentInput.SetWidth(inputWidth)
+		return m, nil
+
+	case spinner.TickMsg:
+		if !m.loadingDetails {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case ItemDetailsLoadedMsg:
+		if msg.Item.ID == m.item.ID {
+			m.item = applyItemDetails(m.item, msg.Details)
+			m.loadingDetails = false
+			m.detailsErr = nil
+			m.selectedComment = len(m.item.Comments) - 1
+		}
+		return m, nil
+
+	case ItemDetailsFailedMsg:
+		if msg.Item.ID == m.item.ID {
+			m.loadingDetails = false
+			m.detailsErr = msg.Err
+		}
+		return m, nil
+
+	case ExternalEditorMsg:
+		if msg.Err != nil {
+			err := msg.Err
+			return m, func() tea.Msg { return ErrorMsg{Err: err} }
+		}
+		m.commentInput.SetValue(msg.Text)
+		return m, nil
+
+	case CommentSavedMsg:
+		if msg.ItemID == m.item.ID {
+			m.item.Comments = mergeComments(m.item.Comments, []models.Comment{msg.Comment})
+			if msg.IsNew {
+				m.item.CommentCount++
+			}
+			m.selectedComment = commentIndex(m.item.Comments, msg.Comment.ID)
+		}
+		return m, nil
+
+	case CommentDeletedMsg:
+		if msg.ItemID == m.item.ID {
+			if i := commentIndex(m.item.Comments, msg.CommentID); i >= 0 {
+				m.item.Comments = append(m.item.Comments[:i:i], m.item.Comments[i+1:]...)
+				m.item.CommentCount--
+			}
+			if m.selectedComment >= len(m.item.Comments) {
+				m.selectedComment = len(m.item.Comments) - 1
+			}
+		}
+		return m, nil
+
+	case CommentsPageLoadedMsg:
+		if msg.ItemID == m.item.ID {
+			m.item.Comments = mergeComments(m.item.Comments, msg.Comments)
+			m.item.CommentsPage = msg.PageInfo
+		}
+		return m, nil
+
+	case PullRequestUpdatedMsg:
+		if msg.Item.ID == m.item.ID {
+			m.item.State = msg.Item.State
+			m.item.PullRequest = msg.Item.PullRequest
+		}
+		return m, nil
+
+	case TimelineLoadedMsg:
+		if msg.ItemID == m.item.ID {
+			m.timeline = m.timeline.loaded(msg)
+		}
+		return m, nil
+
+	case IssueStateChangedMsg:
+		for _, item := range msg.Items {
+			if item.ID == m.item.ID {
+				m.item.State = item.State
+				m.item.StateReason = item.StateReason
+			}
+		}
+		if comment, ok := msg.Comments[m.item.ID]; ok {
+			m.item.Comments = mergeComments(m.item.Comments, []models.Comment{comment})
+			m.item.CommentCount++
+			m.selectedComment = commentIndex(m.item.Comments, comment.ID)
+		}
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.composing {
+			return m.updateComposer(msg)
+		}
+		if m.stateForm != nil {
+			return m.updateStateForm(msg)
+		}
+		if m.prForm != nil {
+			return m.updatePullRequestForm(msg)
+		}
+		if m.subIssueForm != nil {
+			return m.updateSubIssueForm(msg)
+		}
+		if m.dependencyForm != nil {
+			return m.updateDependencyForm(msg)
+		}
+		if m.showTimeline {
+			return m.updateTimeline(msg)
+		}
+
+		// Any other key cancels a pending comment delete
+		if msg.String() != "D" {
+			m.confirmDelete = false
+		}
+
 		switch msg.String() {
+		case "tab":
+			// Show the timeline, fetched afresh so it has what changed since
+			if m.canComment() {
+				m.showTimeline = true
+				var cmd tea.Cmd
+				m.timeline, cmd = m.timeline.reset(m.item)
+				return m, cmd
+			}
+		case "r":
+			// Retry loading the details when they failed
+			if m.detailsErr != nil {
+				m.detailsErr = nil
+				m.loadingDetails = true
+				return m, tea.Batch(m.spinner.Tick, LoadItemDetailsCmd(m.item))
+			}
+			// Reply with a new comment
+			if m.canComment() && !m.loadingDetails {
+				return m.openComposer("", "")
+			}
+		case "[":
+			if m.selectedComment > 0 {
+				m.selectedComment--
+			}
+		case "]":
+			if m.selectedComment < len(m.item.Comments)-1 {
+				m.selectedComment++
+			}
+		case "E":
+			// Edit selected comment
+			if comment, ok := m.currentComment(); ok && comment.CanUpdate {
+				return m.openComposer(comment.ID, comment.Body)
+			}
+		case "D":
+			// Delete selected comment, asking for a second press first
+			if comment, ok := m.currentComment(); ok && comment.CanDelete {
+				if !m.confirmDelete {
+					m.confirmDelete = true
+					return m, nil
+				}
+				m.confirmDelete = false
+				return m, DeleteCommentCmd(m.item, comment.ID)
+			}
+		case "L":
+			// Load the next page of comments
+			if m.item.CommentsPage.HasNextPage && !m.loadingDetails {
+				return m, LoadMoreCommentsCmd(m.item)
+			}
 		case "e":
 			// Edit item
 			return m, EditItemCmd(m.item)
@@ -81,6 +284,71 @@ func (m ItemDetailModel) Update(msg tea.Msg) (ItemDetailModel, tea.Cmd) {
 			if m.item.Type == "DraftIssue" {
 				return m, LoadRepositoriesCmd(m.project, m.item)
 			}
+		case "x":
+			// Close issue
+			if m.item.Type == "Issue" && m.item.State == "OPEN" {
+				return m.openStateForm(true)
+			}
+		case "X":
+			// Reopen issue
+			if m.item.Type == "Issue" && m.item.State == "CLOSED" {
+				return m.openStateForm(false)
+			}
+		case "R":
+			// Request reviews on an open pull request
+			if m.isOpenPullRequest() {
+				return m.openPullRequestForm("reviewers")
+			}
+		case "v":
+			// Review an open pull request
+			if m.isOpenPullRequest() {
+				return m.openPullRequestForm("review")
+			}
+		case "w":
+			// Mark a draft pull request ready for review
+			if m.isOpenPullRequest() && m.item.PullRequest.IsDraft {
+				return m, MarkReadyForReviewCmd(m.item)
+			}
+		case "m":
+			// Merge an open pull request
+			if m.isOpenPullRequest() {
+				return m.openPullRequestForm("merge")
+			}
+		case "S":
+			// Add a sub-issue
+			if m.item.Type == "Issue" {
+				return m.openSubIssueForm("add")
+			}
+		case "U":
+			// Remove a sub-issue
+			if m.item.Type == "Issue" && len(m.item.SubIssues) > 0 {
+				return m.openSubIssueForm("remove")
+			}
+		case "p":
+			// Move under another parent issue
+			if m.item.Type == "Issue" {
+				return m.openSubIssueForm("parent")
+			}
+		case "b":
+			// Add an issue this one is blocked by
+			if m.item.Type == "Issue" {
+				return m.openDependencyForm("blockedBy")
+			}
+		case "B":
+			// Add an issue this one is blocking
+			if m.item.Type == "Issue" {
+				return m.openDependencyForm("blocking")
+			}
+		case "u":
+			// Remove a dependency
+			if len(dependencies(m.item)) > 0 {
+				return m.openDependencyForm("remove")
+			}
+		case "g":
+			// Go to a related issue or pull request
+			if len(relatedIssues(m.item)) > 0 {
+				return m.openDependencyForm("goto")
+			}
 		case "d":
 			// Delete item
 			return m, DeleteItemCmd(m.project, m.item)
@@ -95,6 +363,165 @@ func (m ItemDetailModel) Update(msg tea.Msg) (ItemDetailModel, tea.Cmd) {
 	return m, nil
 }
 
+func (m ItemDetailModel) updateComposer(msg tea.KeyMsg) (ItemDetailModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.composing = false
+		m.commentInput.Blur()
+		return m, nil
+	case "ctrl+s":
+		body := strings.TrimSpace(m.commentInput.Value())
+		if body == "" {
+			return m, nil
+		}
+		m.composing = false
+		m.commentInput.Blur()
+		return m, SubmitCommentCmd(m.item, m.editingCommentID, body)
+	case "ctrl+e":
+		return m, openEditorCmd(m.commentInput.Value())
+	}
+
+	var cmd tea.Cmd
+	m.commentInput, cmd = m.commentInput.Update(msg)
+	return m, cmd
+}
+
+func (m ItemDetailModel) openComposer(commentID, body string) (ItemDetailModel, tea.Cmd) {
+	m.composing = true
+	m.editingCommentID = commentID
+	m.commentInput.SetValue(body)
+	return m, m.commentInput.Focus()
+}
+
+func (m ItemDetailModel) updateStateForm(msg tea.KeyMsg) (ItemDetailModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.stateForm = nil
+		return m, nil
+	case "ctrl+s":
+		form, cmd := m.stateForm.submit()
+		if cmd != nil {
+			m.stateForm = nil
+			return m, cmd
+		}
+		m.stateForm = &form
+		return m, nil
+	}
+
+	form, cmd := m.stateForm.update(msg)
+	m.stateForm = &form
+	return m, cmd
+}
+
+// isOpenPullRequest reports whether the item is a pull request that can still be reviewed and merged
+func (m ItemDetailModel) isOpenPullRequest() bool {
+	return m.item.Type == "PullRequest" && m.item.State == "OPEN" && m.item.PullRequest != nil
+}
+
+func (m ItemDetailModel) updatePullRequestForm(msg tea.KeyMsg) (ItemDetailModel, tea.Cmd) {
+	key := msg.String()
+	switch {
+	case key == "esc":
+		m.prForm = nil
+		return m, nil
+	case key == "ctrl+s" || key == "enter" && m.prForm.kind != "review":
+		form, cmd := m.prForm.submit()
+		if cmd != nil && form.err == "" {
+			m.prForm = nil
+			return m, cmd
+		}
+		m.prForm = &form
+		return m, cmd
+	}
+
+	form, cmd := m.prForm.update(msg)
+	m.prForm = &form
+	return m, cmd
+}
+
+func (m ItemDetailModel) openPullRequestForm(kind string) (ItemDetailModel, tea.Cmd) {
+	form, cmd := newPullRequestForm(kind, m.item, m.width)
+	m.prForm = &form
+	return m, cmd
+}
+
+func (m ItemDetailModel) updateSubIssueForm(msg tea.KeyMsg) (ItemDetailModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.subIssueForm = nil
+		return m, nil
+	case "enter":
+		form, cmd := m.subIssueForm.submit()
+		if cmd != nil {
+			m.subIssueForm = nil
+			return m, cmd
+		}
+		m.subIssueForm = &form
+		return m, nil
+	}
+
+	form, cmd := m.subIssueForm.update(msg)
+	m.subIssueForm = &form
+	return m, cmd
+}
+
+func (m ItemDetailModel) openSubIssueForm(kind string) (ItemDetailModel, tea.Cmd) {
+	form, cmd := newSubIssueForm(kind, m.project, m.item)
+	m.subIssueForm = &form
+	return m, cmd
+}
+
+func (m ItemDetailModel) updateDependencyForm(msg tea.KeyMsg) (ItemDetailModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.dependencyForm = nil
+		return m, nil
+	case "enter":
+		form, cmd := m.dependencyForm.submit()
+		if cmd != nil {
+			m.dependencyForm = nil
+			return m, cmd
+		}
+		m.dependencyForm = &form
+		return m, nil
+	}
+
+	form, cmd := m.dependencyForm.update(msg)
+	m.dependencyForm = &form
+	return m, cmd
+}
+
+func (m ItemDetailModel) updateTimeline(msg tea.KeyMsg) (ItemDetailModel, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "esc":
+		m.showTimeline = false
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.timeline, cmd = m.timeline.update(msg, m.item)
+	return m, cmd
+}
+
+func (m ItemDetailModel) openDependencyForm(kind string) (ItemDetailModel, tea.Cmd) {
+	form, cmd := newDependencyForm(kind, m.project, m.item)
+	m.dependencyForm = &form
+	return m, cmd
+}
+
+func (m ItemDetailModel) openStateForm(close bool) (ItemDetailModel, tea.Cmd) {
+	form := newIssueStateForm(m.project, []models.ProjectItem{m.item}, close, m.width)
+	m.stateForm = &form
+	return m, nil
+}
+
+func (m ItemDetailModel) currentComment() (models.Comment, bool) {
+	if m.selectedComment < 0 || m.selectedComment >= len(m.item.Comments) {
+		return models.Comment{}, false
+	}
+	return m.item.Comments[m.selectedComment], true
+}
+
 func (m ItemDetailModel) View() string {
 	var b strings.Builder
 
@@ -114,7 +541,7 @@ func (m ItemDetailModel) View() string {
 
 	// State
 	if m.item.State != "" {
-		metaParts = append(metaParts, fmt.Sprintf("State: %s", m.item.State))
+		metaParts = append(metaParts, fmt.Sprintf("State: %s", stateText(m.item)))
 	}
 
 	// Number
@@ -125,6 +552,13 @@ func (m ItemDetailModel) View() string {
 	b.WriteString(itemDetailMetaStyle.Render(strings.Join(metaParts, " • ")))
 	b.WriteString("\n")
 
+	// Timeline tab
+	if m.showTimeline {
+		b.WriteString(m.timeline.view(m.height - 12))
+		b.WriteString(itemDetailHelpStyle.Render(m.timeline.helpText()))
+		return b.String()
+	}
+
 	// Assignees
 	if len(m.item.Assignees) > 0 {
 		assigneeList := strings.Join(m.item.Assignees, ", @")
@@ -132,8 +566,82 @@ func (m ItemDetailModel) View() string {
 		b.WriteString("\n")
 	}
 
+	// Labels and milestone
+	if len(m.item.Labels) > 0 {
+		b.WriteString(itemDetailMetaStyle.Render("Labels: ") + renderLabels(m.item.Labels))
+		b.WriteString("\n")
+	}
+	if m.item.Milestone != nil {
+		b.WriteString(itemDetailMetaStyle.Render("Milestone: " + milestoneTitle(*m.item.Milestone)))
+		b.WriteString("\n")
+	}
+
+	// Sub-issue hierarchy
+	if m.item.Parent != nil {
+		b.WriteString(itemDetailMetaStyle.Render("Parent: " + issueRefText(*m.item.Parent)))
+		b.WriteString("\n")
+	}
+	if len(m.item.SubIssues) > 0 {
+		summary := m.item.SubIssuesSummary
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Sub-issues (%d of %d done):", summary.Completed, summary.Total)))
+		b.WriteString("\n")
+		for _, subIssue := range m.item.SubIssues {
+			b.WriteString(itemDetailValueStyle.Render(issueRefText(subIssue)))
+			b.WriteString("\n")
+		}
+		if more := summary.Total - len(m.item.SubIssues); more > 0 {
+			b.WriteString(itemDetailValueStyle.Render(fmt.Sprintf("... and %d more on GitHub", more)))
+			b.WriteString("\n")
+		}
+	}
+
+	// Dependencies
+	if len(m.item.BlockedBy) > 0 {
+		deps := m.item.Dependencies
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Blocked by (%d open):", deps.OpenBlockers)))
+		b.WriteString("\n")
+		for _, ref := range m.item.BlockedBy {
+			b.WriteString(itemDetailValueStyle.Render(issueRefText(ref)))
+			b.WriteString("\n")
+		}
+		if more := deps.TotalBlockedBy - len(m.item.BlockedBy); more > 0 {
+			b.WriteString(itemDetailValueStyle.Render(fmt.Sprintf("... and %d more on GitHub", more)))
+			b.WriteString("\n")
+		}
+	}
+	if len(m.item.Blocking) > 0 {
+		b.WriteString(itemDetailLabelStyle.Render("Blocking:"))
+		b.WriteString("\n")
+		for _, ref := range m.item.Blocking {
+			b.WriteString(itemDetailValueStyle.Render(issueRefText(ref)))
+			b.WriteString("\n")
+		}
+		if more := m.item.Dependencies.TotalBlocking - len(m.item.Blocking); more > 0 {
+			b.WriteString(itemDetailValueStyle.Render(fmt.Sprintf("... and %d more on GitHub", more)))
+			b.WriteString("\n")
+		}
+	}
+
+	// Pull request review and CI status
+	if m.item.PullRequest != nil {
+		b.WriteString(itemDetailLabelStyle.Render("Pull request:"))
+		b.WriteString("\n")
+		for _, line := range pullRequestBreakdown(m.item.State, *m.item.PullRequest) {
+			b.WriteString(itemDetailValueStyle.Render(line))
+			b.WriteString("\n")
+		}
+	}
+
 	// Description
-	if m.item.Body != "" {
+	if m.loadingDetails {
+		b.WriteString(itemDetailMetaStyle.Render(m.spinner.View() + " Loading description and comments..."))
+		b.WriteString("\n\n")
+	} else if m.detailsErr != nil {
+		b.WriteString(issueStateErrorStyle.Render(m.detailsErr.Error()))
+		b.WriteString("\n")
+		b.WriteString(itemDetailMetaStyle.Render("Press r to retry"))
+		b.WriteString("\n\n")
+	} else if m.item.Body != "" {
 		b.WriteString(itemDetailLabelStyle.Render("Description:"))
 		b.WriteString("\n")
 		
@@ -152,9 +660,27 @@ func (m ItemDetailModel) View() string {
 		b.WriteString("\n\n")
 	}
 
+	// Pull requests and branches linked to an issue
+	if len(m.item.LinkedPullRequests) > 0 || len(m.item.LinkedBranches) > 0 {
+		b.WriteString(itemDetailLabelStyle.Render("Development:"))
+		b.WriteString("\n")
+		for _, ref := range m.item.LinkedPullRequests {
+			b.WriteString(itemDetailValueStyle.Render(linkedPullRequestText(ref)))
+			b.WriteString("\n")
+		}
+		for _, branch := range m.item.LinkedBranches {
+			b.WriteString(itemDetailValueStyle.Render("Branch " + linkedBranchText(branch)))
+			b.WriteString("\n")
+		}
+	}
+
 	// Comments section
 	if len(m.item.Comments) > 0 {
-		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Comments (%d):", len(m.item.Comments))))
+		commentCount := m.item.CommentCount
+		if commentCount < len(m.item.Comments) {
+			commentCount = len(m.item.Comments)
+		}
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Comments (%d of %d loaded):", len(m.item.Comments), commentCount)))
 		b.WriteString("\n")
 
 		commentBoxStyle := lipgloss.NewStyle().
@@ -164,6 +690,9 @@ func (m ItemDetailModel) View() string {
 			MarginLeft(2).
 			MarginTop(1)
 
+		selectedCommentBoxStyle := commentBoxStyle.
+			BorderForeground(lipgloss.Color("#7D56F4"))
+
 		commentAuthorStyle := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#7D56F4"))
@@ -171,13 +700,25 @@ func (m ItemDetailModel) View() string {
 		commentTimeStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#888888"))
 
-		// Show up to 5 most recent comments
-		maxComments := len(m.item.Comments)
-		if maxComments > 5 {
-			maxComments = 5
+		// Show a window of up to 5 comments ending at the selected one
+		end := m.selectedComment + 1
+		if end < 5 {
+			end = 5
+		}
+		if end > len(m.item.Comments) {
+			end = len(m.item.Comments)
+		}
+		start := end - 5
+		if start < 0 {
+			start = 0
 		}
 
-		for i := len(m.item.Comments) - maxComments; i < len(m.item.Comments); i++ {
+		if start > 0 {
+			b.WriteString(itemDetailMetaStyle.Render(fmt.Sprintf("... %d earlier comments", start)))
+			b.WriteString("\n")
+		}
+
+		for i := start; i < end; i++ {
 			comment := m.item.Comments[i]
 			var commentText strings.Builder
 			
@@ -193,16 +734,81 @@ func (m ItemDetailModel) View() string {
 			wrapped := wordWrap(comment.Body, boxWidth-4)
 			commentText.WriteString(wrapped)
 
-			b.WriteString(commentBoxStyle.Width(boxWidth).Render(commentText.String()))
+			style := commentBoxStyle
+			if i == m.selectedComment {
+				style = selectedCommentBoxStyle
+			}
+			b.WriteString(style.Width(boxWidth).Render(commentText.String()))
 			b.WriteString("\n")
 		}
 
-		if len(m.item.Comments) > 5 {
-			b.WriteString(itemDetailMetaStyle.Render(fmt.Sprintf("... and %d more comments", len(m.item.Comments)-5)))
+		if end < len(m.item.Comments) {
+			b.WriteString(itemDetailMetaStyle.Render(fmt.Sprintf("... and %d more comments", len(m.item.Comments)-end)))
+			b.WriteString("\n")
+		}
+		if m.item.CommentsPage.HasNextPage {
+			b.WriteString(itemDetailMetaStyle.Render("More comments on GitHub, press L to load them"))
 			b.WriteString("\n")
 		}
 	}
 
+	// Recent activity
+	if len(m.item.Timeline) > 0 {
+		b.WriteString(itemDetailLabelStyle.Render("Recent activity:"))
+		b.WriteString("\n")
+
+		start := len(m.item.Timeline) - 5
+		if start < 0 {
+			start = 0
+		}
+		for _, event := range m.item.Timeline[start:] {
+			b.WriteString(itemDetailValueStyle.Render(fmt.Sprintf("@%s %s • %s", event.Actor, event.Summary, formatTime(event.CreatedAt))))
+			b.WriteString("\n")
+		}
+	}
+
+	// Comment composer
+	if m.composing {
+		label := "New comment:"
+		if m.editingCommentID != "" {
+			label = "Edit comment:"
+		}
+		b.WriteString(itemDetailLabelStyle.Render(label))
+		b.WriteString("\n")
+		b.WriteString("  " + m.commentInput.View())
+		b.WriteString("\n")
+		b.WriteString(itemDetailHelpStyle.Render("ctrl+s: post • ctrl+e: open $EDITOR • esc: cancel"))
+		return b.String()
+	}
+
+	// Pull request action form
+	if m.prForm != nil {
+		b.WriteString(m.prForm.view())
+		b.WriteString(itemDetailHelpStyle.Render(m.prForm.helpText()))
+		return b.String()
+	}
+
+	// Sub-issue or parent form
+	if m.subIssueForm != nil {
+		b.WriteString(m.subIssueForm.view())
+		b.WriteString(itemDetailHelpStyle.Render(m.subIssueForm.helpText()))
+		return b.String()
+	}
+
+	// Dependency form
+	if m.dependencyForm != nil {
+		b.WriteString(m.dependencyForm.view())
+		b.WriteString(itemDetailHelpStyle.Render(m.dependencyForm.helpText()))
+		return b.String()
+	}
+
+	// Close or reopen form
+	if m.stateForm != nil {
+		b.WriteString(m.stateForm.view())
+		b.WriteString(itemDetailHelpStyle.Render(m.stateForm.helpText()))
+		return b.String()
+	}
+
 	// Timestamps
 	b.WriteString(itemDetailLabelStyle.Render("Details:"))
 	b.WriteString("\n")
@@ -235,10 +841,57 @@ func (m ItemDetailModel) View() string {
 		helpText += " • c: convert to issue"
 	}
 	helpText += " • d: delete"
+	if m.item.Type == "Issue" && m.item.State == "OPEN" {
+		helpText += " • x: close"
+	}
+	if m.item.Type == "Issue" && m.item.State == "CLOSED" {
+		helpText += " • X: reopen"
+	}
+	if m.item.Type == "Issue" {
+		helpText += " • S: add sub-issue • p: set parent"
+		if len(m.item.SubIssues) > 0 {
+			helpText += " • U: remove sub-issue"
+		}
+		helpText += " • b/B: add blocked by/blocking"
+	}
+	if len(dependencies(m.item)) > 0 {
+		helpText += " • u: remove dependency"
+	}
+	if len(relatedIssues(m.item)) > 0 {
+		helpText += " • g: go to related"
+	}
+	if m.isOpenPullRequest() {
+		helpText += " • R: request review • v: review • m: merge"
+		if m.item.PullRequest.IsDraft {
+			helpText += " • w: ready for review"
+		}
+	}
 	if m.item.URL != "" {
 		helpText += " • o: open in browser"
 	}
+	if m.detailsErr != nil {
+		helpText += " • r: retry loading"
+	} else if m.canComment() {
+		helpText += " • r: comment"
+	}
+	if m.canComment() {
+		helpText += " • tab: timeline"
+	}
+	if len(m.item.Comments) > 1 {
+		helpText += " • [/]: select comment"
+	}
+	if comment, ok := m.currentComment(); ok {
+		if comment.CanUpdate {
+			helpText += " • E: edit comment"
+		}
+		if comment.CanDelete {
+			helpText += " • D: delete comment"
+		}
+	}
 	helpText += " • esc: back • q: quit"
+	if m.confirmDelete {
+		helpText = "Press D again to delete the selected comment, any other key to cancel"
+	}
 	b.WriteString(itemDetailHelpStyle.Render(helpText))
 
 	return b.String()
@@ -310,6 +963,59 @@ func wordWrap(text string, width int) string {
 	return result.String()
 }
 
+// applyItemDetails fills in the lazily loaded fields of an item
+func applyItemDetails(item models.ProjectItem, details models.ItemDetails) models.ProjectItem {
+	item.Body = details.Body
+	item.Comments = details.Comments
+	item.CommentCount = details.CommentCount
+	item.CommentsPage = details.CommentsPage
+	item.Timeline = details.Timeline
+	item.LinkedPullRequests = details.LinkedPullRequests
+	item.LinkedBranches = details.LinkedBranches
+	item.DetailsLoaded = true
+	return item
+}
+
+// itemDetailsOf extracts the lazily loaded fields of an item for caching
+func itemDetailsOf(item models.ProjectItem) models.ItemDetails {
+	return models.ItemDetails{
+		Body:         item.Body,
+		Comments:     item.Comments,
+		CommentCount: item.CommentCount,
+		CommentsPage: item.CommentsPage,
+		Timeline:     item.Timeline,
+		UpdatedAt:    item.UpdatedAt,
+
+		LinkedPullRequests: item.LinkedPullRequests,
+		LinkedBranches:     item.LinkedBranches,
+	}
+}
+
+// mergeComments adds or replaces comments by ID, keeping them in chronological order
+func mergeComments(existing, incoming []models.Comment) []models.Comment {
+	merged := append([]models.Comment{}, existing...)
+	for _, comment := range incoming {
+		if i := commentIndex(merged, comment.ID); i >= 0 {
+			merged[i] = comment
+		} else {
+			merged = append(merged, comment)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].CreatedAt.Before(merged[j].CreatedAt)
+	})
+	return merged
+}
+
+func commentIndex(comments []models.Comment, id string) int {
+	for i, comment := range comments {
+		if comment.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
 // OpenURLCmd signals opening a URL in browser
 func OpenURLCmd(url string) tea.Cmd {
 	return func() tea.Msg {
@@ -337,3 +1043,88 @@ type LoadRepositoriesMsg struct {
 	Project models.Project
 	Item    models.ProjectItem
 }
+
+// SubmitCommentCmd signals posting a new comment, or editing one when commentID is set
+func SubmitCommentCmd(item models.ProjectItem, commentID, body string) tea.Cmd {
+	return func() tea.Msg {
+		return SubmitCommentMsg{Item: item, CommentID: commentID, Body: body}
+	}
+}
+
+// DeleteCommentCmd signals deleting a comment
+func DeleteCommentCmd(item models.ProjectItem, commentID string) tea.Cmd {
+	return func() tea.Msg {
+		return DeleteCommentMsg{Item: item, CommentID: commentID}
+	}
+}
+
+// LoadMoreCommentsCmd signals loading the next page of comments
+func LoadMoreCommentsCmd(item models.ProjectItem) tea.Cmd {
+	return func() tea.Msg {
+		return LoadMoreCommentsMsg{Item: item}
+	}
+}
+
+// SubmitCommentMsg is sent to post or edit a comment
+type SubmitCommentMsg struct {
+	Item      models.ProjectItem
+	CommentID string // Empty for a new comment
+	Body      string
+}
+
+// DeleteCommentMsg is sent to delete a comment
+type DeleteCommentMsg struct {
+	Item      models.ProjectItem
+	CommentID string
+}
+
+// LoadMoreCommentsMsg is sent to load the next page of comments
+type LoadMoreCommentsMsg struct {
+	Item models.ProjectItem
+}
+
+// CommentSavedMsg is sent when a comment is posted or edited
+type CommentSavedMsg struct {
+	ItemID  string
+	Comment models.Comment
+	IsNew   bool
+}
+
+// CommentDeletedMsg is sent when a comment is deleted
+type CommentDeletedMsg struct {
+	ItemID    string
+	CommentID string
+}
+
+// CommentsPageLoadedMsg is sent when another page of comments is loaded
+type CommentsPageLoadedMsg struct {
+	ItemID   string
+	Comments []models.Comment
+	PageInfo models.PageInfo
+}
+
+// LoadItemDetailsCmd signals loading an item's body, comments and timeline again
+func LoadItemDetailsCmd(item models.ProjectItem) tea.Cmd {
+	return func() tea.Msg {
+		return LoadItemDetailsMsg{Item: item}
+	}
+}
+
+// LoadItemDetailsMsg is sent to load an item's body, comments and timeline
+type LoadItemDetailsMsg struct {
+	Item models.ProjectItem
+}
+
+// ItemDetailsFailedMsg is sent when an item's details couldn't be loaded
+type ItemDetailsFailedMsg struct {
+	Item    models.ProjectItem
+	Err     error
+	ForEdit bool // The editor was waiting for them
+}
+
+// ItemDetailsLoadedMsg is sent when an item's body, comments and timeline are loaded
+type ItemDetailsLoadedMsg struct {
+	Item    models.ProjectItem
+	Details models.ItemDetails
+	ForEdit bool // Open the editor once loaded
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
@@ -19,6 +21,8 @@ type ItemEditorModel struct {
 	titleInput         textinput.Model
 	bodyInput          textarea.Model
 	assigneeInput      textinput.Model
+	assignees          []string // Assignee chips, in the order they were added
+	selectedChip       int      // Chip selected for removal, -1 while typing
 	convertToIssue     bool   // Toggle: true = convert to issue on save
 	focusIndex         int
 	isNewItem          bool
@@ -27,6 +31,23 @@ type ItemEditorModel struct {
 	suggestions        []string
 	selectedSuggestion int
 	showSuggestions    bool
+
+	// base is the item as it was when editing started. Saving checks it
+	// against GitHub so a teammate's edit isn't silently overwritten.
+	base      models.ItemContent
+	conflict  *editConflict // Set while a conflicting save is being resolved
+	force     bool          // Save even though the item changed on GitHub
+	mergeNote string        // Result of the last merge, shown above the form
+
+	// Labels and milestone of issues and pull requests. The options come
+	// from the item's repository and load after the editor opens.
+	labels         []models.Label
+	milestone      *models.Milestone
+	repoLabels     []models.Label
+	repoMilestones []models.Milestone
+	metadataLoaded bool
+	metadataErr    error
+	pickerCursor   int // Highlighted option in the focused picker
 }
 
 func NewItemEditorModel(project models.Project, owner string, isOrgProject bool, item *models.ProjectItem) ItemEditorModel {
@@ -39,21 +60,30 @@ func NewItemEditorModel(project models.Project, owner string, isOrgProject bool,
 	ta := textarea.New()
 	ta.Placeholder = "Item description (optional)"
 	ta.CharLimit = 2000
+	if item != nil && (item.Type == "Issue" || item.Type == "PullRequest") {
+		// Issue and pull request bodies are often far longer than drafts
+		ta.CharLimit = 65536
+	}
 	ta.SetWidth(80)  // Will be adjusted on WindowSizeMsg
 	ta.SetHeight(10)
 
 	ai := textinput.New()
-	ai.Placeholder = "Assignee username (optional)"
+	ai.Placeholder = "Add assignee (optional)"
 	ai.CharLimit = 100
 	ai.Width = 80  // Will be adjusted on WindowSizeMsg
 
 	isNew := item == nil
+	var base models.ItemContent
+	var assignees []string
+	var labels []models.Label
+	var milestone *models.Milestone
 	if item != nil {
+		base = models.ItemContent{Title: item.Title, Body: item.Body, UpdatedAt: item.UpdatedAt}
 		ti.SetValue(item.Title)
 		ta.SetValue(item.Body)
-		if len(item.Assignees) > 0 {
-			ai.SetValue(item.Assignees[0])
-		}
+		assignees = append(assignees, item.Assignees...)
+		labels = append(labels, item.Labels...)
+		milestone = item.Milestone
 	}
 
 	return ItemEditorModel{
@@ -64,13 +94,48 @@ func NewItemEditorModel(project models.Project, owner string, isOrgProject bool,
 		titleInput:     ti,
 		bodyInput:      ta,
 		assigneeInput:  ai,
+		assignees:      assignees,
+		selectedChip:   -1,
 		convertToIssue: isNew, // Default to true for new items (create real issue)
 		focusIndex:     0,
 		isNewItem:      isNew,
+		base:           base,
+		labels:         labels,
+		milestone:      milestone,
 	}
 }
 
+// isDraft reports whether the item is a new or existing draft, the only kind
+// that can be converted to an issue
+func (m ItemEditorModel) isDraft() bool {
+	return m.isNewItem || (m.item != nil && m.item.Type == "DraftIssue")
+}
+
+// editable reports whether the item's type can be saved from the editor.
+// Redacted items, whose content the viewer can't see, can't.
+func (m ItemEditorModel) editable() bool {
+	return m.isDraft() || m.item.Type == "Issue" || m.item.Type == "PullRequest"
+}
+
+// hasMetadata reports whether the item has labels and a milestone to edit,
+// which only issues and pull requests do
+func (m ItemEditorModel) hasMetadata() bool {
+	return !m.isNewItem && (m.item.Type == "Issue" || m.item.Type == "PullRequest") && m.item.RepositoryID != ""
+}
+
+// fieldCount is the number of fields tab cycles through: title, description
+// and assignees, then labels and milestone for issues and pull requests
+func (m ItemEditorModel) fieldCount() int {
+	if m.hasMetadata() {
+		return 5
+	}
+	return 3
+}
+
 func (m ItemEditorModel) Init() tea.Cmd {
+	if m.hasMetadata() {
+		return tea.Batch(textinput.Blink, LoadRepositoryMetadataCmd(m.item.RepositoryID))
+	}
 	return textinput.Blink
 }
 
@@ -92,6 +157,9 @@ func (m ItemEditorModel) Update(msg tea.Msg) (ItemEditorModel, tea.Cmd) {
 		
 		// Adjust textarea height
 		textareaHeight := msg.Height - 18
+		if m.hasMetadata() {
+			textareaHeight -= 6
+		}
 		if textareaHeight < 5 {
 			textareaHeight = 5
 		}
@@ -99,6 +167,10 @@ func (m ItemEditorModel) Update(msg tea.Msg) (ItemEditorModel, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.conflict != nil {
+			return m.updateConflict(msg)
+		}
+
 		// Handle suggestion navigation when assignee field is focused and suggestions are shown
 		if m.focusIndex == 2 && m.showSuggestions && len(m.suggestions) > 0 {
 			switch msg.String() {
@@ -117,9 +189,7 @@ func (m ItemEditorModel) Update(msg tea.Msg) (ItemEditorModel, tea.Cmd) {
 			case "enter":
 				// Select the suggestion
 				if m.selectedSuggestion >= 0 && m.selectedSuggestion < len(m.suggestions) {
-					m.assigneeInput.SetValue(m.suggestions[m.selectedSuggestion])
-					m.showSuggestions = false
-					m.suggestions = nil
+					m = m.addAssignee(m.suggestions[m.selectedSuggestion])
 				}
 				return m, nil
 			case "esc":
@@ -130,17 +200,27 @@ func (m ItemEditorModel) Update(msg tea.Msg) (ItemEditorModel, tea.Cmd) {
 			}
 		}
 
+		if !m.editable() {
+			return m, nil
+		}
+
+		if m.focusIndex == 2 {
+			if next, handled := m.updateChips(msg); handled {
+				return next, nil
+			}
+		}
+		if m.focusIndex >= 3 {
+			if next, handled := m.updatePicker(msg); handled {
+				return next, nil
+			}
+		}
+
 		switch msg.String() {
 		case "ctrl+s":
-			// Save (might trigger repo selection if converting)
-			if m.convertToIssue && (m.isNewItem || (m.item != nil && m.item.Type == "DraftIssue")) {
-				// Need to select repository for conversion
-				return m, m.saveAndConvertCmd()
-			}
-			return m, m.saveCmd()
+			return m, m.submitCmd()
 		case "ctrl+t":
 			// Toggle convert to issue (only for drafts)
-			if (m.isNewItem || (m.item != nil && m.item.Type == "DraftIssue")) {
+			if m.isDraft() {
 				m.convertToIssue = !m.convertToIssue
 			}
 			return m, nil
@@ -152,16 +232,17 @@ func (m ItemEditorModel) Update(msg tea.Msg) (ItemEditorModel, tea.Cmd) {
 				m.focusIndex--
 			}
 
-			if m.focusIndex > 2 {
+			if m.focusIndex >= m.fieldCount() {
 				m.focusIndex = 0
 			} else if m.focusIndex < 0 {
-				m.focusIndex = 2
+				m.focusIndex = m.fieldCount() - 1
 			}
 
 			m.titleInput.Blur()
 			m.bodyInput.Blur()
 			m.assigneeInput.Blur()
 			m.showSuggestions = false
+			m.selectedChip = -1
 
 			switch m.focusIndex {
 			case 0:
@@ -170,15 +251,45 @@ func (m ItemEditorModel) Update(msg tea.Msg) (ItemEditorModel, tea.Cmd) {
 				m.bodyInput.Focus()
 			case 2:
 				m.assigneeInput.Focus()
+			case 3:
+				m.pickerCursor = 0
+			case 4:
+				// Start on the current milestone, after the "No milestone" option
+				m.pickerCursor = 0
+				for i, milestone := range m.repoMilestones {
+					if milestone.ID == milestoneID(m.milestone) {
+						m.pickerCursor = i + 1
+					}
+				}
 			}
 
 			return m, nil
 		}
 
+	case ItemConflictMsg:
+		m.conflict = &editConflict{theirs: msg.Theirs, err: msg.Err}
+		m.mergeNote = ""
+		return m, nil
+
+	case RepositoryMetadataMsg:
+		if m.hasMetadata() && msg.RepositoryID == m.item.RepositoryID {
+			m.repoLabels = msg.Labels
+			m.repoMilestones = msg.Milestones
+			m.metadataErr = msg.Err
+			m.metadataLoaded = msg.Err == nil
+		}
+		return m, nil
+
 	case UserSuggestionsMsg:
-		m.suggestions = msg.Users
+		// Users already assigned aren't suggested again
+		m.suggestions = nil
+		for _, user := range msg.Users {
+			if !containsLogin(m.assignees, user) {
+				m.suggestions = append(m.suggestions, user)
+			}
+		}
 		m.selectedSuggestion = 0
-		m.showSuggestions = len(msg.Users) > 0
+		m.showSuggestions = len(m.suggestions) > 0
 		return m, nil
 	}
 
@@ -208,6 +319,273 @@ func (m ItemEditorModel) Update(msg tea.Msg) (ItemEditorModel, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// updateChips handles the keys that add and remove assignee chips. Enter adds
+// the typed login; with nothing typed, left and right select a chip and
+// backspace removes the selected or last one.
+func (m ItemEditorModel) updateChips(msg tea.KeyMsg) (ItemEditorModel, bool) {
+	typed := m.assigneeInput.Value() != ""
+
+	switch msg.String() {
+	case "enter":
+		login := strings.TrimPrefix(strings.TrimSpace(m.assigneeInput.Value()), "@")
+		if login != "" {
+			m = m.addAssignee(login)
+		}
+		return m, true
+	case "left":
+		if typed || len(m.assignees) == 0 {
+			return m, false
+		}
+		if m.selectedChip == -1 {
+			m.selectedChip = len(m.assignees) - 1
+		} else if m.selectedChip > 0 {
+			m.selectedChip--
+		}
+		return m, true
+	case "right":
+		if m.selectedChip == -1 {
+			return m, false
+		}
+		m.selectedChip++
+		if m.selectedChip >= len(m.assignees) {
+			m.selectedChip = -1
+		}
+		return m, true
+	case "backspace", "delete":
+		if m.selectedChip >= 0 {
+			m.assignees = slices.Delete(m.assignees, m.selectedChip, m.selectedChip+1)
+			if m.selectedChip >= len(m.assignees) {
+				m.selectedChip = len(m.assignees) - 1
+			}
+			return m, true
+		}
+		if !typed && msg.String() == "backspace" && len(m.assignees) > 0 {
+			m.assignees = m.assignees[:len(m.assignees)-1]
+			return m, true
+		}
+	}
+
+	m.selectedChip = -1
+	return m, false
+}
+
+// updatePicker handles the keys of the label and milestone pickers. Up and
+// down move through the options, space or enter toggles a label or picks the
+// milestone.
+func (m ItemEditorModel) updatePicker(msg tea.KeyMsg) (ItemEditorModel, bool) {
+	options := len(m.repoLabels)
+	if m.focusIndex == 4 {
+		options = len(m.repoMilestones) + 1 // "No milestone" comes first
+	}
+	if !m.metadataLoaded {
+		options = 0
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.pickerCursor > 0 {
+			m.pickerCursor--
+		}
+		return m, true
+	case "down", "j":
+		if m.pickerCursor < options-1 {
+			m.pickerCursor++
+		}
+		return m, true
+	case " ", "enter":
+		if m.pickerCursor >= options {
+			return m, true
+		}
+		if m.focusIndex == 3 {
+			label := m.repoLabels[m.pickerCursor]
+			index := slices.IndexFunc(m.labels, func(l models.Label) bool { return l.ID == label.ID })
+			if index >= 0 {
+				m.labels = slices.Delete(slices.Clone(m.labels), index, index+1)
+			} else {
+				m.labels = append(slices.Clone(m.labels), label)
+			}
+		} else if m.pickerCursor == 0 {
+			m.milestone = nil
+		} else {
+			milestone := m.repoMilestones[m.pickerCursor-1]
+			m.milestone = &milestone
+		}
+		return m, true
+	}
+	return m, false
+}
+
+// renderLabelPicker shows the chosen labels, or every label of the repository
+// with checkboxes while the picker is focused
+func (m ItemEditorModel) renderLabelPicker() string {
+	if m.focusIndex != 3 || !m.metadataLoaded {
+		if len(m.labels) == 0 {
+			return "  " + m.metadataNote("None")
+		}
+		return "  " + renderLabels(m.labels) + m.metadataNote("")
+	}
+	if len(m.repoLabels) == 0 {
+		return "  The repository has no labels"
+	}
+
+	var lines []string
+	start, end := pickerWindow(m.pickerCursor, len(m.repoLabels), 6)
+	for i := start; i < end; i++ {
+		label := m.repoLabels[i]
+		cursor, check := "  ", "[ ]"
+		if i == m.pickerCursor {
+			cursor = "▸ "
+		}
+		if slices.ContainsFunc(m.labels, func(l models.Label) bool { return l.ID == label.ID }) {
+			check = "[x]"
+		}
+		lines = append(lines, "  "+cursor+check+" "+renderLabel(label))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderMilestonePicker shows the chosen milestone, or the repository's open
+// milestones while the picker is focused
+func (m ItemEditorModel) renderMilestonePicker() string {
+	if m.focusIndex != 4 || !m.metadataLoaded {
+		if m.milestone == nil {
+			return "  " + m.metadataNote("None")
+		}
+		return "  " + milestoneTitle(*m.milestone) + m.metadataNote("")
+	}
+
+	var lines []string
+	start, end := pickerWindow(m.pickerCursor, len(m.repoMilestones)+1, 6)
+	for i := start; i < end; i++ {
+		cursor, check := "  ", "( )"
+		if i == m.pickerCursor {
+			cursor = "▸ "
+		}
+		title := "No milestone"
+		if i == 0 {
+			if m.milestone == nil {
+				check = "(•)"
+			}
+		} else {
+			milestone := m.repoMilestones[i-1]
+			title = milestoneTitle(milestone)
+			if milestone.ID == milestoneID(m.milestone) {
+				check = "(•)"
+			}
+		}
+		lines = append(lines, "  "+cursor+check+" "+title)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// metadataNote explains why a picker can't be opened yet, after the current value
+func (m ItemEditorModel) metadataNote(value string) string {
+	note := value
+	switch {
+	case m.metadataErr != nil:
+		note += " (couldn't load the repository's options: " + m.metadataErr.Error() + ")"
+	case !m.metadataLoaded && m.focusIndex >= 3:
+		note += " (loading...)"
+	}
+	return note
+}
+
+// pickerWindow returns the range of options to show so the cursor stays visible
+func pickerWindow(cursor, total, size int) (int, int) {
+	if total <= size {
+		return 0, total
+	}
+	start := cursor - size/2
+	if start < 0 {
+		start = 0
+	}
+	if start > total-size {
+		start = total - size
+	}
+	return start, start + size
+}
+
+// addAssignee adds a chip for the login and clears the input
+func (m ItemEditorModel) addAssignee(login string) ItemEditorModel {
+	if !containsLogin(m.assignees, login) {
+		m.assignees = append(m.assignees, login)
+	}
+	m.assigneeInput.SetValue("")
+	m.showSuggestions = false
+	m.suggestions = nil
+	m.selectedChip = -1
+	return m
+}
+
+// assigneeLogins returns the assignees to save, including a login that was
+// typed but not yet added as a chip
+func (m ItemEditorModel) assigneeLogins() []string {
+	logins := append([]string{}, m.assignees...)
+	typed := strings.TrimPrefix(strings.TrimSpace(m.assigneeInput.Value()), "@")
+	if typed != "" && !containsLogin(logins, typed) {
+		logins = append(logins, typed)
+	}
+	return logins
+}
+
+// renderChips renders the assignee chips in front of the assignee input
+func (m ItemEditorModel) renderChips() string {
+	chipStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#444444")).
+		Padding(0, 1)
+
+	selectedChipStyle := chipStyle.
+		Background(lipgloss.Color("#FF5F87")).
+		Bold(true)
+
+	var b strings.Builder
+	for i, login := range m.assignees {
+		if i == m.selectedChip {
+			b.WriteString(selectedChipStyle.Render("@" + login + " ×"))
+		} else {
+			b.WriteString(chipStyle.Render("@" + login))
+		}
+		b.WriteString(" ")
+	}
+	return b.String()
+}
+
+// updateConflict handles keys while the conflict screen is shown
+func (m ItemEditorModel) updateConflict(msg tea.KeyMsg) (ItemEditorModel, tea.Cmd) {
+	switch msg.String() {
+	case "m":
+		// Fold their changes into the form and keep editing against their version
+		title, titleConflict := mergeTitle(m.base.Title, m.titleInput.Value(), m.conflict.theirs.Title)
+		body, bodyConflict := mergeText(m.base.Body, m.bodyInput.Value(), m.conflict.theirs.Body)
+		m.titleInput.SetValue(title)
+		m.bodyInput.SetValue(body)
+		m.base = m.conflict.theirs
+		m.conflict = nil
+		switch {
+		case bodyConflict:
+			m.mergeNote = "Merged, lines you both changed are between " + conflictMarkerOurs + " and " + conflictMarkerTheirs + ". Resolve them, then save."
+		case titleConflict:
+			m.mergeNote = "Merged, but you both changed the title. Yours was kept, theirs was: " + m.base.Title
+		default:
+			m.mergeNote = "Merged their changes. Review and save."
+		}
+		return m, nil
+	case "o":
+		m.force = true
+		m.conflict = nil
+		return m, m.submitCmd()
+	case "d":
+		m.conflict = nil
+		return m, DiscardEditCmd()
+	case "esc":
+		// Back to the form, saving again re-checks against GitHub
+		m.conflict = nil
+		return m, nil
+	}
+	return m, nil
+}
+
 func (m ItemEditorModel) View() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -228,13 +606,45 @@ func (m ItemEditorModel) View() string {
 
 	title := "New Item"
 	if !m.isNewItem {
-		title = "Edit Item"
+		switch m.item.Type {
+		case "DraftIssue":
+			title = "Edit Draft"
+		case "Issue":
+			title = fmt.Sprintf("Edit Issue #%d", m.item.Number)
+		case "PullRequest":
+			title = fmt.Sprintf("Edit Pull Request #%d", m.item.Number)
+		default:
+			title = "Edit Item"
+		}
 	}
 	b.WriteString(titleStyle.Render(title))
 	b.WriteString("\n")
+
+	if m.conflict != nil {
+		ours := models.ItemContent{Title: m.titleInput.Value(), Body: m.bodyInput.Value()}
+		b.WriteString(renderConflict(m.base, ours, m.conflict, (m.height-16)/2))
+		b.WriteString(helpStyle.Render("m: merge • o: overwrite with yours • d: discard yours • esc: keep editing"))
+		return b.String()
+	}
+
 	b.WriteString(labelStyle.Render("Project: " + m.project.Title))
 	b.WriteString("\n\n")
 
+	if !m.editable() {
+		b.WriteString(labelStyle.Render("This item can't be edited here, you may not have access to its content."))
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("esc: back"))
+		return b.String()
+	}
+
+	if m.mergeNote != "" {
+		noteStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFA500")).
+			MarginLeft(2)
+		b.WriteString(noteStyle.Render(m.mergeNote))
+		b.WriteString("\n\n")
+	}
+
 	b.WriteString(labelStyle.Render("Title:"))
 	b.WriteString("\n")
 	b.WriteString("  " + m.titleInput.View())
@@ -245,13 +655,25 @@ func (m ItemEditorModel) View() string {
 	b.WriteString("  " + m.bodyInput.View())
 	b.WriteString("\n\n")
 
-	b.WriteString(labelStyle.Render("Assignee:"))
+	b.WriteString(labelStyle.Render("Assignees:"))
 	b.WriteString("\n")
-	b.WriteString("  " + m.assigneeInput.View())
+	b.WriteString("  " + m.renderChips() + m.assigneeInput.View())
 	b.WriteString("\n")
 
+	if m.hasMetadata() {
+		b.WriteString("\n")
+		b.WriteString(labelStyle.Render("Labels:"))
+		b.WriteString("\n")
+		b.WriteString(m.renderLabelPicker())
+		b.WriteString("\n\n")
+		b.WriteString(labelStyle.Render("Milestone:"))
+		b.WriteString("\n")
+		b.WriteString(m.renderMilestonePicker())
+		b.WriteString("\n")
+	}
+
 	// Show type toggle for drafts
-	if m.isNewItem || (m.item != nil && m.item.Type == "DraftIssue") {
+	if m.isDraft() {
 		b.WriteString("\n")
 		typeLabel := "Type: "
 		if m.convertToIssue {
@@ -301,9 +723,18 @@ func (m ItemEditorModel) View() string {
 	}
 
 	helpText := "tab: switch fields • ctrl+s: save"
-	if m.isNewItem || (m.item != nil && m.item.Type == "DraftIssue") {
+	if m.isDraft() {
 		helpText += " • ctrl+t: toggle type"
 	}
+	if m.focusIndex == 2 {
+		helpText += " • enter: add assignee • ←/backspace: remove"
+	}
+	if m.focusIndex == 3 && m.metadataLoaded {
+		helpText += " • ↑/↓: move • space: toggle label"
+	}
+	if m.focusIndex == 4 && m.metadataLoaded {
+		helpText += " • ↑/↓: move • space: choose milestone"
+	}
 	helpText += " • esc: cancel"
 	if m.showSuggestions && len(m.suggestions) > 0 {
 		helpText = "↑/↓: navigate suggestions • enter: select • esc: close • ctrl+s: save"
@@ -313,6 +744,15 @@ func (m ItemEditorModel) View() string {
 	return b.String()
 }
 
+// submitCmd saves the item, going through repository selection when converting
+func (m ItemEditorModel) submitCmd() tea.Cmd {
+	if m.convertToIssue && m.isDraft() {
+		// Need to select repository for conversion
+		return m.saveAndConvertCmd()
+	}
+	return m.saveCmd()
+}
+
 func (m ItemEditorModel) saveCmd() tea.Cmd {
 	return func() tea.Msg {
 		return SaveItemMsg{
@@ -320,8 +760,12 @@ func (m ItemEditorModel) saveCmd() tea.Cmd {
 			Item:      m.item,
 			Title:     m.titleInput.Value(),
 			Body:      m.bodyInput.Value(),
-			Assignee:  m.assigneeInput.Value(),
+			Assignees: m.assigneeLogins(),
+			Labels:    m.labels,
+			Milestone: m.milestone,
 			IsNewItem: m.isNewItem,
+			Base:      m.base,
+			Force:     m.force,
 		}
 	}
 }
@@ -334,8 +778,10 @@ func (m ItemEditorModel) saveAndConvertCmd() tea.Cmd {
 			Item:      m.item,
 			Title:     m.titleInput.Value(),
 			Body:      m.bodyInput.Value(),
-			Assignee:  m.assigneeInput.Value(),
+			Assignees: m.assigneeLogins(),
 			IsNewItem: m.isNewItem,
+			Base:      m.base,
+			Force:     m.force,
 		}
 	}
 }
@@ -346,8 +792,12 @@ type SaveItemMsg struct {
 	Item      *models.ProjectItem
 	Title     string
 	Body      string
-	Assignee  string
+	Assignees []string // Logins
+	Labels    []models.Label
+	Milestone *models.Milestone // nil for none
 	IsNewItem bool
+	Base      models.ItemContent // Item as it was when editing started
+	Force     bool               // Overwrite even if the item changed on GitHub
 }
 
 // SaveAndConvertMsg is sent when saving an item and converting to issue
@@ -356,8 +806,17 @@ type SaveAndConvertMsg struct {
 	Item      *models.ProjectItem
 	Title     string
 	Body      string
-	Assignee  string
+	Assignees []string // Logins
 	IsNewItem bool
+	Base      models.ItemContent // Item as it was when editing started
+	Force     bool               // Overwrite even if the item changed on GitHub
+}
+
+// SearchUsersMsg is sent to look up assignee suggestions
+type SearchUsersMsg struct {
+	Query        string
+	Owner        string
+	IsOrgProject bool
 }
 
 // UserSuggestionsMsg contains user search results
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// renderLabel renders a label as a chip in the label's own color, with dark
+// or light text depending on how bright the color is
+func renderLabel(label models.Label) string {
+	style := lipgloss.NewStyle().Padding(0, 1)
+	if len(label.Color) != 6 {
+		return style.Background(lipgloss.Color("#444444")).Foreground(lipgloss.Color("#FFFFFF")).Render(label.Name)
+	}
+
+	rgb, err := strconv.ParseUint(label.Color, 16, 32)
+	if err != nil {
+		return style.Background(lipgloss.Color("#444444")).Foreground(lipgloss.Color("#FFFFFF")).Render(label.Name)
+	}
+	r, g, b := rgb>>16&0xFF, rgb>>8&0xFF, rgb&0xFF
+	foreground := "#FFFFFF"
+	if (299*r+587*g+114*b)/1000 > 150 {
+		foreground = "#000000"
+	}
+	return style.
+		Background(lipgloss.Color("#" + label.Color)).
+		Foreground(lipgloss.Color(foreground)).
+		Render(label.Name)
+}
+
+// renderLabels renders labels as chips separated by spaces
+func renderLabels(labels []models.Label) string {
+	chips := make([]string, len(labels))
+	for i, label := range labels {
+		chips[i] = renderLabel(label)
+	}
+	return strings.Join(chips, " ")
+}
+
+// milestoneTitle describes a milestone with its due date, if it has one
+func milestoneTitle(milestone models.Milestone) string {
+	if milestone.DueOn.IsZero() {
+		return milestone.Title
+	}
+	return fmt.Sprintf("%s (due %s)", milestone.Title, milestone.DueOn.Format("Jan 2, 2006"))
+}
+
+func labelIDs(labels []models.Label) []string {
+	ids := make([]string, len(labels))
+	for i, label := range labels {
+		ids[i] = label.ID
+	}
+	return ids
+}
+
+func milestoneID(milestone *models.Milestone) string {
+	if milestone == nil {
+		return ""
+	}
+	return milestone.ID
+}
+
+// updateLabelsAndMilestone adds and removes an issue's or pull request's
+// labels and moves it to another milestone, sending only what changed. Labels
+// and milestones are given by ID. Drafts have neither, so nothing is sent for them.
+func updateLabelsAndMilestone(ctx context.Context, client api.Interface, contentType, contentID string, previousLabels, labels []string, previousMilestone, milestone string) error {
+	if contentType != "Issue" && contentType != "PullRequest" {
+		return nil
+	}
+
+	var add, remove []string
+	for _, id := range labels {
+		if !slices.Contains(previousLabels, id) {
+			add = append(add, id)
+		}
+	}
+	for _, id := range previousLabels {
+		if !slices.Contains(labels, id) {
+			remove = append(remove, id)
+		}
+	}
+	if len(add) > 0 {
+		if err := client.AddLabels(ctx, contentID, add); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		if err := client.RemoveLabels(ctx, contentID, remove); err != nil {
+			return err
+		}
+	}
+
+	if milestone == previousMilestone {
+		return nil
+	}
+	if contentType == "PullRequest" {
+		return client.SetPullRequestMilestone(ctx, contentID, milestone)
+	}
+	return client.SetIssueMilestone(ctx, contentID, milestone)
+}
+
+// LoadRepositoryMetadataMsg is sent to load the labels and milestones an
+// issue or pull request can be given
+type LoadRepositoryMetadataMsg struct {
+	RepositoryID string
+}
+
+// RepositoryMetadataMsg contains a repository's labels and open milestones
+type RepositoryMetadataMsg struct {
+	RepositoryID string
+	Labels       []models.Label
+	Milestones   []models.Milestone
+	Err          error
+}
+
+// LoadRepositoryMetadataCmd signals loading a repository's labels and milestones
+func LoadRepositoryMetadataCmd(repositoryID string) tea.Cmd {
+	return func() tea.Msg {
+		return LoadRepositoryMetadataMsg{RepositoryID: repositoryID}
+	}
+}
+
+func loadRepositoryMetadata(ctx context.Context, client api.Interface, msg LoadRepositoryMetadataMsg) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		labels, err := client.ListRepositoryLabels(ctx, msg.RepositoryID)
+		if err != nil {
+			return RepositoryMetadataMsg{RepositoryID: msg.RepositoryID, Err: err}
+		}
+		milestones, err := client.ListRepositoryMilestones(ctx, msg.RepositoryID)
+		if err != nil {
+			return RepositoryMetadataMsg{RepositoryID: msg.RepositoryID, Err: err}
+		}
+		return RepositoryMetadataMsg{RepositoryID: msg.RepositoryID, Labels: labels, Milestones: milestones}
+	})
+}
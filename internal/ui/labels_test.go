@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+)
+
+func TestUpdateLabelsAndMilestone(t *testing.T) {
+	ctx := context.Background()
+	c, p, r := newBoard()
+	bug := c.AddLabel(r.ID, "bug", "d73a4a")
+	docs := c.AddLabel(r.ID, "docs", "0075ca")
+	v1 := c.AddMilestone(r.ID, "v1.0", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	issue := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+	if err := c.AddLabels(ctx, issue.ContentID, []string{bug.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := updateLabelsAndMilestone(ctx, c, "Issue", issue.ContentID, []string{bug.ID}, []string{docs.ID}, "", v1.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved := findItem(t, c, p.ID, issue.ID)
+	if len(saved.Labels) != 1 || saved.Labels[0].Name != "docs" {
+		t.Errorf("labels = %+v, want [docs]", saved.Labels)
+	}
+	if saved.Milestone == nil || saved.Milestone.Title != "v1.0" {
+		t.Errorf("milestone = %+v, want v1.0", saved.Milestone)
+	}
+
+	// Clearing the milestone leaves the unchanged labels alone
+	before := len(c.Calls())
+	if err := updateLabelsAndMilestone(ctx, c, "Issue", issue.ContentID, []string{docs.ID}, []string{docs.ID}, v1.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if calls := c.Calls()[before:]; len(calls) != 1 || calls[0] != "SetIssueMilestone" {
+		t.Errorf("calls = %v, want only SetIssueMilestone", calls)
+	}
+	if saved := findItem(t, c, p.ID, issue.ID); saved.Milestone != nil {
+		t.Errorf("milestone = %+v, want none", saved.Milestone)
+	}
+}
+
+func TestLoadRepositoryMetadata(t *testing.T) {
+	c := fake.New("octocat")
+	r := c.AddRepository("octocat", "api")
+	c.AddLabel(r.ID, "bug", "d73a4a")
+	c.AddMilestone(r.ID, "v1.0", time.Time{})
+
+	msg := loadRepositoryMetadata(context.Background(), c, LoadRepositoryMetadataMsg{RepositoryID: r.ID})().(cancellableMsg).msg
+
+	metadata, ok := msg.(RepositoryMetadataMsg)
+	if !ok {
+		t.Fatalf("got %#v, want RepositoryMetadataMsg", msg)
+	}
+	if metadata.Err != nil || len(metadata.Labels) != 1 || len(metadata.Milestones) != 1 || metadata.RepositoryID != r.ID {
+		t.Errorf("metadata = %+v, want the label and milestone", metadata)
+	}
+}
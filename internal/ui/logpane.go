@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/logging"
+)
+
+// logPaneHeight is the number of lines the debug log pane takes from the view, including its title
+const logPaneHeight = 10
+
+// logPaneInterval is how often the log pane picks up new entries
+const logPaneInterval = time.Second
+
+var (
+	logPaneTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#7D56F4"))
+
+	logDebugStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262"))
+
+	logInfoStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#AAAAAA"))
+
+	logWarnStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFA500"))
+
+	logErrorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F87"))
+)
+
+// renderLogPane shows the most recent log entries, newest last
+func (m Model) renderLogPane() string {
+	width := m.width
+	if width <= 3 {
+		// No size yet
+		width = 80
+	}
+
+	title := "Log"
+	if m.logPath != "" {
+		title += " • " + m.logPath
+	}
+	lines := []string{logPaneTitleStyle.Render(truncate(title, width))}
+
+	for _, entry := range logging.Recent(logPaneHeight - 1) {
+		style := logInfoStyle
+		switch {
+		case entry.Level >= slog.LevelError:
+			style = logErrorStyle
+		case entry.Level >= slog.LevelWarn:
+			style = logWarnStyle
+		case entry.Level < slog.LevelInfo:
+			style = logDebugStyle
+		}
+		lines = append(lines, style.Render(truncate(entry.String(), width)))
+	}
+
+	// Keep the pane a fixed height so the view above doesn't jump
+	for len(lines) < logPaneHeight {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// logPaneTick redraws the log pane while it is shown
+func logPaneTick(seq int) tea.Cmd {
+	return tea.Tick(logPaneInterval, func(time.Time) tea.Msg {
+		return logPaneTickMsg{seq: seq}
+	})
+}
+
+// logPaneTickMsg triggers a redraw of the log pane
+type logPaneTickMsg struct {
+	seq int
+}
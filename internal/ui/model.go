@@ -1,23 +1,33 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"log/slog"
 	"os/exec"
 	"runtime"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/thomaskoefod/githubProjectTUI/internal/api"
 	"github.com/thomaskoefod/githubProjectTUI/internal/auth"
+	"github.com/thomaskoefod/githubProjectTUI/internal/cache"
 	"github.com/thomaskoefod/githubProjectTUI/internal/config"
 	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
 	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+	"github.com/thomaskoefod/githubProjectTUI/internal/outbox"
 )
 
 type view int
 
+// statusBarHeight is the number of lines reserved below every view for the status bar
+const statusBarHeight = 1
+
 const (
 	viewLoading view = iota
 	viewOwnerSelector
@@ -27,12 +37,14 @@ const (
 	viewItemEditor
 	viewProjectCreator
 	viewRepositorySelector
+	viewCollaborators
+	viewOutbox
 	viewHelp
 )
 
 type Model struct {
 	currentView        view
-	apiClient          *api.Client
+	apiClient          api.Interface
 	config             *config.Config
 	username           string
 	orgs               []string
@@ -45,30 +57,86 @@ type Model struct {
 	itemEditor         ItemEditorModel
 	projectCreator     ProjectCreatorModel
 	repositorySelector RepositorySelectorModel
+	collaborators      CollaboratorsModel
+	itemDetails        map[string]models.ItemDetails // Lazily loaded details by content ID
 	width              int
 	height             int
 	err                error
 	loading            bool
 	message            string
 	debugMode          bool
+	cancelLoad         context.CancelFunc                // Cancels the in-flight navigation load
+	backgroundCtx      context.Context                   // Shared by loads running alongside it, e.g. timeline pages
+	cancelBackground   context.CancelFunc                // Cancels backgroundCtx
+	lastLoad           func(ctx context.Context) tea.Cmd // Most recent navigation load, for retrying
+	retryLoad          func(ctx context.Context) tea.Cmd // Load offered as the "r" recovery action for m.err
+	apiErr             *apierrors.APIError               // Classified form of m.err, if any
+	refreshSeq         int                               // Invalidates auto-refresh ticks from an earlier schedule
+	cache              *cache.Cache                      // On-disk cache, nil when bypassed
+	notice             string                            // Non-fatal problem shown in the status bar
+	outbox             *outbox.Outbox                    // Changes queued while offline, nil when disabled
+	outboxView         OutboxModel
+	outboxReturn       view // View to go back to from the pending changes screen
+	replaying          bool // An outbox replay is in flight
+	windowHeight       int    // Terminal height, before the status bar and log pane are taken off
+	logPath            string // Where the log file is written, shown in the log pane
+	logPaneSeq         int    // Invalidates log pane ticks from before it was last toggled
+}
+
+// Options configures the application model
+type Options struct {
+	// Cache is used to render views before GitHub responds. Nil disables caching.
+	Cache *cache.Cache
+	// Outbox queues changes made while GitHub is unreachable. Nil disables offline mode.
+	Outbox *outbox.Outbox
+	// LogPath is shown in the debug log pane. Empty when logging to memory only.
+	LogPath string
+	// Client replaces the GitHub client, e.g. with a fake in tests. When set,
+	// the gh login check is skipped.
+	Client api.Interface
 }
 
-func NewModel() Model {
+func NewModel(opts Options) Model {
 	return Model{
 		currentView: viewLoading,
 		loading:     true,
+		itemDetails: make(map[string]models.ItemDetails),
+		cache:       opts.Cache,
+		outbox:      opts.Outbox,
+		logPath:     opts.LogPath,
+		apiClient:   opts.Client,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
-		initializeApp,
+		initializeApp(m.apiClient),
+		outboxTick(),
 	)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case cancellableMsg:
+		if msg.ctx.Err() != nil {
+			// Result of a load the user cancelled or navigated away from
+			return m, nil
+		}
+		if msg.ctx != m.backgroundCtx {
+			m.releaseLoad() // Load finished, release its context
+			if _, failed := msg.msg.(ErrorMsg); failed {
+				m.retryLoad = m.lastLoad
+			}
+		}
+		return m.Update(msg.msg)
+
 	case tea.WindowSizeMsg:
+		// Sub-models get the space above the status bar and log pane
+		m.windowHeight = msg.Height
+		msg.Height -= statusBarHeight
+		if m.debugMode {
+			msg.Height -= logPaneHeight
+		}
 		m.width = msg.Width
 		m.height = msg.Height
 
@@ -88,6 +156,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.projectCreator, _ = m.projectCreator.Update(msg)
 		case viewRepositorySelector:
 			m.repositorySelector, _ = m.repositorySelector.Update(msg)
+		case viewCollaborators:
+			m.collaborators, _ = m.collaborators.Update(msg)
+		case viewOutbox:
+			m.outboxView, _ = m.outboxView.Update(msg)
 		}
 
 		return m, nil
@@ -111,39 +183,81 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.currentOwner = msg.Username
 			m.currentIsUser = true
-			return m, loadProjects(m.apiClient, msg.Username, true)
+			cmd := m.openProjects(msg.Username, true)
+			return m, cmd
 		}
 
 	case OwnerSelectedMsg:
 		m.currentOwner = msg.Owner
 		m.currentIsUser = msg.IsUser
-		m.loading = true
-		m.message = fmt.Sprintf("Loading projects for %s...", msg.Owner)
-		return m, loadProjects(m.apiClient, msg.Owner, msg.IsUser)
+		cmd := m.openProjects(msg.Owner, msg.IsUser)
+		return m, cmd
 
 	case ProjectsLoadedMsg:
-		m.projectList = NewProjectListModel(msg.Projects)
-		m.projectList.width = m.width
-		m.projectList.height = m.height
-		m.currentView = viewProjectList
 		m.loading = false
-		// Force window size update to list
-		m.projectList, _ = m.projectList.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		if msg.Revalidated && m.currentView == viewProjectList {
+			// Update the cached list in place and point out what changed
+			m.notice = ""
+			return m, m.projectList.setProjects(msg.Projects)
+		}
+		m.showProjects(msg.Projects)
 		return m, nil
 
 	case ProjectSelectedMsg:
-		m.loading = true
-		m.message = "Loading project items..."
-		return m, loadProjectItems(m.apiClient, msg.Project)
+		cmd := m.openProject(msg.Project)
+		return m, cmd
 
 	case ProjectItemsLoadedMsg:
-		m.projectDetail = NewProjectDetailModel(msg.Project, msg.Items)
-		m.projectDetail.width = m.width
-		m.projectDetail.height = m.height
-		m.currentView = viewProjectDetail
 		m.loading = false
+		if msg.Revalidated && m.currentView == viewProjectDetail && m.projectDetail.project.ID == msg.Project.ID {
+			// Update the cached items in place and point out what changed
+			m.notice = ""
+			m.projectDetail.fields = msg.Fields
+			m.projectDetail.setItems(msg.Items, true)
+			return m, m.scheduleRefresh()
+		}
+		m.showProjectDetail(msg.Project, msg.Items, msg.Fields)
+		return m, m.scheduleRefresh()
+
+	case RevalidateFailedMsg:
+		// Keep showing the cached data rather than replacing it with an error screen
+		message := msg.Err.Error()
+		var apiErr *apierrors.APIError
+		if errors.As(msg.Err, &apiErr) {
+			message = apiErr.GetUserFriendlyMessage()
+		}
+		m.notice = "⚠️ Showing cached data, refresh failed: " + message
 		return m, nil
 
+	case autoRefreshMsg:
+		if msg.seq != m.refreshSeq {
+			return m, nil
+		}
+		// Only refresh while the project is on screen and nothing else is loading
+		if m.currentView != viewProjectDetail || m.loading || m.projectDetail.project.ID != msg.project.ID {
+			return m, m.scheduleRefresh()
+		}
+		return m, refreshProjectItems(m.apiClient, msg.seq, msg.project, m.refreshInterval())
+
+	case projectItemsRefreshedMsg:
+		if msg.seq != m.refreshSeq {
+			return m, nil
+		}
+		// Failed refreshes (including ones held back by a low rate limit budget) wait for the next tick
+		if msg.err == nil && m.projectDetail.project.ID == msg.project.ID {
+			m.projectDetail.setItems(msg.items, true)
+		}
+		return m, m.scheduleRefresh()
+
+	case SearchUsersMsg:
+		return m, searchUsers(m.beginBackgroundLoad(), m.apiClient, msg)
+
+	case LoadRepositoryMetadataMsg:
+		return m, loadRepositoryMetadata(m.beginBackgroundLoad(), m.apiClient, msg)
+
+	case SearchCollaboratorsMsg:
+		return m, searchCollaborators(m.beginBackgroundLoad(), m.apiClient, msg)
+
 	case CreateItemMsg:
 		m.itemEditor = NewItemEditorModel(msg.Project, m.currentOwner, !m.currentIsUser, nil)
 		m.itemEditor.width = m.width
@@ -152,35 +266,101 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.itemEditor.Init()
 
 	case ViewItemMsg:
-		m.itemDetail = NewItemDetailModel(msg.Project, msg.Item)
-		m.itemDetail.width = m.width
-		m.itemDetail.height = m.height
+		item := m.withCachedDetails(msg.Item)
+		m.itemDetail = NewItemDetailModel(msg.Project, item)
+		m.itemDetail, _ = m.itemDetail.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
 		m.currentView = viewItemDetail
+		if !item.DetailsLoaded {
+			cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+				return loadItemDetails(ctx, m.apiClient, item, false)
+			})
+			return m, tea.Batch(m.itemDetail.Init(), cmd)
+		}
 		return m, nil
 
+	case ItemDetailsLoadedMsg:
+		m.itemDetails[msg.Item.ContentID] = msg.Details
+		item := applyItemDetails(msg.Item, msg.Details)
+		m.projectDetail.replaceItem(item)
+		if msg.ForEdit {
+			m.loading = false
+			return m.Update(EditItemMsg{Item: item})
+		}
+		var cmd tea.Cmd
+		m.itemDetail, cmd = m.itemDetail.Update(msg)
+		return m, cmd
+
+	case LoadItemDetailsMsg:
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadItemDetails(ctx, m.apiClient, msg.Item, false)
+		})
+		return m, cmd
+
+	case ItemDetailsFailedMsg:
+		if msg.ForEdit {
+			// The editor can't open without the body, offer to load it again
+			m.retryLoad = m.lastLoad
+			return m.Update(ErrorMsg{Err: msg.Err})
+		}
+		// The item view shows the failure and retries it with r
+		var cmd tea.Cmd
+		m.itemDetail, cmd = m.itemDetail.Update(msg)
+		return m, cmd
+
 	case NewProjectMsg:
-		m.projectCreator = NewProjectCreatorModel(m.currentOwner, m.currentIsUser)
+		m.projectCreator = NewProjectCreatorModel(m.currentOwner, m.currentIsUser, m.projectList.projects)
 		m.projectCreator.width = m.width
 		m.projectCreator.height = m.height
 		m.currentView = viewProjectCreator
 		return m, m.projectCreator.Init()
 
 	case EditItemMsg:
-		m.itemEditor = NewItemEditorModel(models.Project{}, m.currentOwner, !m.currentIsUser, &msg.Item)
+		// The editor needs the body, load it first if we only have the table row
+		msg.Item = m.withCachedDetails(msg.Item)
+		if !msg.Item.DetailsLoaded {
+			m.loading = true
+			m.message = "Loading item..."
+			cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+				return loadItemDetails(ctx, m.apiClient, msg.Item, true)
+			})
+			return m, cmd
+		}
+		m.itemEditor = NewItemEditorModel(m.projectDetail.project, m.currentOwner, !m.currentIsUser, &msg.Item)
 		m.itemEditor.width = m.width
 		m.itemEditor.height = m.height
 		m.currentView = viewItemEditor
 		return m, m.itemEditor.Init()
 
 	case SaveItemMsg:
+		if msg.Project.ID == "" {
+			// Edits opened from an item don't carry the project, it is the one on screen
+			msg.Project = m.projectDetail.project
+		}
 		m.loading = true
 		m.message = "Saving item..."
-		return m, saveItem(m.apiClient, msg)
+		return m, saveItem(context.Background(), m.apiClient, m.outbox, msg)
+
+	case ItemConflictMsg:
+		// Someone else edited the item, let the user decide what to keep
+		m.loading = false
+		m.message = ""
+		m.currentView = viewItemEditor
+		var cmd tea.Cmd
+		m.itemEditor, cmd = m.itemEditor.Update(msg)
+		return m, cmd
+
+	case DiscardEditMsg:
+		m.currentView = viewProjectDetail
+		project := m.projectDetail.project
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjectItems(ctx, m.apiClient, m.cache, m.currentOwner, project, false)
+		})
+		return m, cmd
 
 	case SaveAndConvertMsg:
 		m.loading = true
 		m.message = "Saving and preparing conversion..."
-		return m, saveAndConvert(m.apiClient, m.currentOwner, m.currentIsUser, msg)
+		return m, saveAndConvert(context.Background(), m.apiClient, m.currentOwner, m.currentIsUser, msg)
 
 	case ItemSavedAndReadyToConvertMsg:
 		// Item saved, now show repository selector
@@ -194,17 +374,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case CreateProjectMsg:
 		m.loading = true
 		m.message = "Creating project..."
-		return m, createProject(m.apiClient, msg)
+		return m, createProject(context.Background(), m.apiClient, msg)
 
 	case ProjectCreatedMsg:
 		m.loading = false
 		// Reload projects for current owner
-		return m, loadProjects(m.apiClient, m.currentOwner, m.currentIsUser)
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjects(ctx, m.apiClient, m.cache, m.currentOwner, m.currentIsUser, false)
+		})
+		return m, cmd
+
+	case ToggleTemplateMsg:
+		m.loading = true
+		m.message = "Updating template status..."
+		return m, toggleTemplate(context.Background(), m.apiClient, msg.Project)
+
+	case TemplateToggledMsg:
+		m.loading = false
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjects(ctx, m.apiClient, m.cache, m.currentOwner, m.currentIsUser, false)
+		})
+		return m, cmd
 
 	case ItemSavedMsg:
 		m.loading = false
 		// Reload project items
-		return m, loadProjectItems(m.apiClient, m.itemEditor.project)
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjectItems(ctx, m.apiClient, m.cache, m.currentOwner, m.itemEditor.project, false)
+		})
+		return m, cmd
 
 	case PartialSuccessMsg:
 		m.loading = false
@@ -212,23 +410,106 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Show warning message with success indicator
 		m.message = "⚠️ " + msg.Message
 		// Reload project items but keep warning visible
-		return m, loadProjectItems(m.apiClient, m.itemEditor.project)
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjectItems(ctx, m.apiClient, m.cache, m.currentOwner, m.itemEditor.project, false)
+		})
+		return m, cmd
 
 	case DeleteItemMsg:
 		m.loading = true
 		m.message = "Deleting item..."
-		return m, deleteItem(m.apiClient, msg)
+		return m, deleteItem(context.Background(), m.apiClient, m.outbox, msg)
+
+	case MutationQueuedMsg:
+		m.loading = false
+		m.message = ""
+		m.notice = "⏳ GitHub is unreachable, your change was queued and will sync automatically"
+		m.syncPending()
+		if m.currentView == viewItemEditor {
+			m.currentView = viewProjectDetail
+		}
+		return m, nil
+
+	case ShowOutboxMsg:
+		if m.outbox == nil {
+			return m, nil
+		}
+		m.outboxView = NewOutboxModel(m.outbox.Pending())
+		m.outboxView, _ = m.outboxView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		if m.currentView != viewOutbox {
+			m.outboxReturn = m.currentView
+		}
+		m.currentView = viewOutbox
+		return m, nil
+
+	case DiscardMutationMsg:
+		if err := m.outbox.Discard(msg.ID); err != nil {
+			return m, func() tea.Msg { return ErrorMsg{Err: err} }
+		}
+		m.syncPending()
+		return m, nil
+
+	case ForceMutationMsg:
+		if err := m.outbox.Force(msg.ID); err != nil {
+			return m, func() tea.Msg { return ErrorMsg{Err: err} }
+		}
+		m.syncPending()
+		return m, ReplayOutboxCmd()
+
+	case logPaneTickMsg:
+		if !m.debugMode || msg.seq != m.logPaneSeq {
+			return m, nil
+		}
+		return m, logPaneTick(msg.seq)
+
+	case outboxTickMsg, ReplayOutboxMsg:
+		var next tea.Cmd
+		_, automatic := msg.(outboxTickMsg)
+		if automatic {
+			next = outboxTick()
+		}
+		if m.replaying || m.apiClient == nil || !hasSendable(m.outbox.Pending()) {
+			return m, next
+		}
+		m.replaying = true
+		return m, tea.Batch(next, replayOutbox(m.apiClient, m.outbox, automatic))
+
+	case OutboxReplayedMsg:
+		m.replaying = false
+		m.syncPending()
+		m.notice = ""
+		for _, entry := range m.outbox.Pending() {
+			if entry.Conflict != "" {
+				m.notice = "⚠️ Some queued changes conflict with changes on GitHub • P: review"
+				break
+			}
+		}
+		// Show the synced changes if the project they belong to is open
+		if msg.Sent > 0 && m.currentView == viewProjectDetail && !m.loading {
+			client, store, owner, project := m.apiClient, m.cache, m.currentOwner, m.projectDetail.project
+			cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+				return loadProjectItems(ctx, client, store, owner, project, true)
+			})
+			return m, cmd
+		}
+		return m, nil
 
 	case ItemDeletedMsg:
 		m.loading = false
 		m.message = ""
 		// Reload project items to reflect deletion
-		return m, loadProjectItems(m.apiClient, msg.Project)
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjectItems(ctx, m.apiClient, m.cache, m.currentOwner, msg.Project, false)
+		})
+		return m, cmd
 
 	case LoadRepositoriesMsg:
 		m.loading = true
 		m.message = "Loading repositories..."
-		return m, loadRepositories(m.apiClient, m.currentOwner, m.currentIsUser, msg.Project, msg.Item)
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadRepositories(ctx, m.apiClient, m.currentOwner, m.currentIsUser, msg.Project, msg.Item)
+		})
+		return m, cmd
 
 	case RepositoriesLoadedMsg:
 		// Check if there's a saved default repository for this project (if config is available)
@@ -239,7 +520,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if repo.ID == defaultRepoID {
 						m.loading = true
 						m.message = "Converting to issue in " + repo.Name + " (default)..."
-						return m, convertDraft(m.apiClient, ConvertDraftMsg{
+						return m, convertDraft(context.Background(), m.apiClient, ConvertDraftMsg{
 							Project:    msg.Project,
 							Item:       msg.Item,
 							Repository: repo,
@@ -249,7 +530,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Default repo not found (maybe deleted), clear it from config
 				m.config.ClearDefaultRepository(msg.Project.ID)
 				if err := m.config.Save(); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to save config: %v\n", err)
+					slog.Warn("failed to save config", "err", err)
 				}
 			}
 		}
@@ -258,7 +539,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(msg.Repositories) == 1 {
 			m.loading = true
 			m.message = "Converting to issue in " + msg.Repositories[0].Name + "..."
-			return m, convertDraft(m.apiClient, ConvertDraftMsg{
+			return m, convertDraft(context.Background(), m.apiClient, ConvertDraftMsg{
 				Project:    msg.Project,
 				Item:       msg.Item,
 				Repository: msg.Repositories[0],
@@ -279,22 +560,235 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.SaveAsDefault && m.config != nil {
 			m.config.SetDefaultRepository(msg.Project.ID, msg.Repository.ID)
 			if err := m.config.Save(); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to save config: %v\n", err)
+				slog.Warn("failed to save config", "err", err)
 			}
 		}
 		m.loading = true
 		m.message = "Converting draft to issue..."
-		return m, convertDraft(m.apiClient, msg)
+		return m, convertDraft(context.Background(), m.apiClient, msg)
 
 	case DraftConvertedMsg:
 		m.loading = false
 		m.message = ""
 		// Reload project items to show the converted issue
-		return m, loadProjectItems(m.apiClient, msg.Project)
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjectItems(ctx, m.apiClient, m.cache, m.currentOwner, msg.Project, false)
+		})
+		return m, cmd
+
+	case ManageCollaboratorsMsg:
+		m.loading = true
+		m.message = "Loading collaborators..."
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadCollaborators(ctx, m.apiClient, msg.Project)
+		})
+		return m, cmd
+
+	case CollaboratorsLoadedMsg:
+		// Keep the cursor in place when refreshing after a role change
+		cursor := 0
+		if m.currentView == viewCollaborators && m.collaborators.project.ID == msg.Project.ID {
+			cursor = m.collaborators.cursor
+		}
+		m.collaborators = NewCollaboratorsModel(msg.Project, m.currentOwner, !m.currentIsUser, msg.Collaborators)
+		if cursor < len(msg.Collaborators) {
+			m.collaborators.cursor = cursor
+		}
+		m.collaborators, _ = m.collaborators.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		m.currentView = viewCollaborators
+		m.loading = false
+		return m, nil
+
+	case UpdateCollaboratorMsg:
+		m.loading = true
+		m.message = "Updating collaborators..."
+		return m, updateCollaborator(context.Background(), m.apiClient, msg)
+
+	case LoadStatusUpdatesMsg:
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadStatusUpdates(ctx, m.apiClient, msg.Project)
+		})
+		return m, cmd
+
+	case PostStatusUpdateMsg:
+		return m, postStatusUpdate(context.Background(), m.apiClient, msg)
+
+	case SubmitCommentMsg:
+		m.loading = true
+		m.message = "Posting comment..."
+		return m, submitComment(context.Background(), m.apiClient, msg)
+
+	case DeleteCommentMsg:
+		m.loading = true
+		m.message = "Deleting comment..."
+		return m, deleteComment(context.Background(), m.apiClient, msg)
+
+	case LoadMoreCommentsMsg:
+		m.loading = true
+		m.message = "Loading comments..."
+		cmd := m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadMoreComments(ctx, m.apiClient, msg.Item)
+		})
+		return m, cmd
+
+	case LoadTimelineMsg:
+		// Loads in the background, the tab shows its progress
+		return m, loadTimeline(m.beginBackgroundLoad(), m.apiClient, msg)
+
+	case TimelineLoadedMsg:
+		var cmd tea.Cmd
+		m.itemDetail, cmd = m.itemDetail.Update(msg)
+		return m, cmd
+
+	case CommentSavedMsg, CommentDeletedMsg, CommentsPageLoadedMsg:
+		m.loading = false
+		var cmd tea.Cmd
+		m.itemDetail, cmd = m.itemDetail.Update(msg)
+		// Keep the table's copy and the details cache in sync so reopening the item shows the change
+		m.projectDetail.replaceItem(m.itemDetail.item)
+		m.itemDetails[m.itemDetail.item.ContentID] = itemDetailsOf(m.itemDetail.item)
+		return m, cmd
+
+	case ChangeIssueStateMsg:
+		m.loading = true
+		m.message = "Reopening issues..."
+		if msg.Close {
+			m.message = "Closing issues..."
+		}
+		ctx := context.Background()
+		if len(msg.Items) > 1 {
+			// Bulk changes hold back as the rate limit budget runs low
+			ctx = api.WithPriority(ctx, api.PriorityBackground)
+		}
+		return m, changeIssueState(ctx, m.apiClient, msg)
+
+	case IssueStateChangedMsg:
+		m.loading = false
+		if len(msg.Items) == 0 && msg.Err != nil {
+			err := msg.Err
+			return m, func() tea.Msg { return ErrorMsg{Err: err} }
+		}
+		if msg.Failed > 0 {
+			m.notice = fmt.Sprintf("⚠️ %d of %d issues couldn't be changed: %v", msg.Failed, msg.Failed+len(msg.Items), msg.Err)
+		}
+		m.projectDetail, _ = m.projectDetail.Update(msg)
+		// Drop cached details that miss a new comment so they're fetched again
+		for _, item := range m.projectDetail.items {
+			if _, ok := msg.Comments[item.ID]; ok {
+				delete(m.itemDetails, item.ContentID)
+			}
+		}
+		if m.currentView == viewItemDetail {
+			m.itemDetail, _ = m.itemDetail.Update(msg)
+			m.projectDetail.replaceItem(m.itemDetail.item)
+			m.projectDetail.refreshRows()
+			m.itemDetails[m.itemDetail.item.ContentID] = itemDetailsOf(m.itemDetail.item)
+		}
+		return m, nil
+
+	case RequestReviewsMsg:
+		m.loading = true
+		m.message = "Requesting reviews..."
+		return m, requestReviews(context.Background(), m.apiClient, msg)
+
+	case SubmitReviewMsg:
+		m.loading = true
+		m.message = "Submitting review..."
+		return m, pullRequestAction(context.Background(), m.apiClient, msg.Item, func() error {
+			return m.apiClient.SubmitReview(context.Background(), msg.Item.ContentID, msg.Event, msg.Body)
+		})
+
+	case MarkReadyForReviewMsg:
+		m.loading = true
+		m.message = "Marking ready for review..."
+		return m, pullRequestAction(context.Background(), m.apiClient, msg.Item, func() error {
+			return m.apiClient.MarkReadyForReview(context.Background(), msg.Item.ContentID)
+		})
+
+	case MergePullRequestMsg:
+		m.loading = true
+		m.message = "Merging pull request..."
+		return m, pullRequestAction(context.Background(), m.apiClient, msg.Item, func() error {
+			return m.apiClient.MergePullRequest(context.Background(), msg.Item.ContentID, msg.Method)
+		})
+
+	case AddSubIssueMsg:
+		m.loading = true
+		m.message = "Adding sub-issue..."
+		return m, addSubIssue(context.Background(), m.apiClient, msg)
+
+	case RemoveSubIssueMsg:
+		m.loading = true
+		m.message = "Removing sub-issue..."
+		return m, removeSubIssue(context.Background(), m.apiClient, msg)
+
+	case SetParentMsg:
+		m.loading = true
+		m.message = "Moving issue..."
+		return m, setParent(context.Background(), m.apiClient, msg)
+
+	case AddDependencyMsg:
+		m.loading = true
+		m.message = "Adding dependency..."
+		return m, addDependency(context.Background(), m.apiClient, msg)
+
+	case RemoveDependencyMsg:
+		m.loading = true
+		m.message = "Removing dependency..."
+		return m, removeDependency(context.Background(), m.apiClient, msg)
+
+	case IssueRelationsChangedMsg:
+		m.loading = false
+		m.message = ""
+		if m.projectDetail.project.ID == msg.Project.ID {
+			m.projectDetail.setItems(msg.Items, false)
+		}
+		// The fetched copies lack the item view's lazily loaded details, so only take the relations
+		for _, item := range msg.Items {
+			if item.ID == m.itemDetail.item.ID {
+				m.itemDetail.item.Parent = item.Parent
+				m.itemDetail.item.SubIssues = item.SubIssues
+				m.itemDetail.item.SubIssuesSummary = item.SubIssuesSummary
+				m.itemDetail.item.BlockedBy = item.BlockedBy
+				m.itemDetail.item.Blocking = item.Blocking
+				m.itemDetail.item.Dependencies = item.Dependencies
+			}
+		}
+		return m, nil
+
+	case OpenRelatedIssueMsg:
+		// Issues on the board open in the item view, others on GitHub
+		for _, item := range m.projectDetail.items {
+			if item.ContentID == msg.Ref.ID {
+				return m, ViewItemCmd(m.projectDetail.project, item)
+			}
+		}
+		return m, OpenURLCmd(msg.Ref.URL)
+
+	case PullRequestUpdatedMsg:
+		m.loading = false
+		m.message = ""
+		m.itemDetail, _ = m.itemDetail.Update(msg)
+		// Keep the table's copy in sync so the row's glyphs show the change
+		for _, item := range m.projectDetail.items {
+			if item.ID == msg.Item.ID {
+				item.State = msg.Item.State
+				item.PullRequest = msg.Item.PullRequest
+				m.projectDetail.replaceItem(item)
+			}
+		}
+		m.projectDetail.refreshRows()
+		return m, nil
 
 	case ErrorMsg:
 		// Extract user-friendly error message if it's an APIError
-		if apiErr, ok := msg.Err.(*apierrors.APIError); ok {
+		var apiErr *apierrors.APIError
+		if errors.As(msg.Err, &apiErr) {
+			m.apiErr = apiErr
+			// A conflict means our copy is stale, reloading is the way out
+			if apiErr.Type == apierrors.ErrorTypeConflict && m.retryLoad == nil {
+				m.retryLoad = m.lastLoad
+			}
 			// Use the user-friendly message
 			m.err = fmt.Errorf("%s", apiErr.GetUserFriendlyMessage())
 			
@@ -309,12 +803,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				icon = "⚠️ "
 			case apierrors.ErrorTypeRetryable:
 				icon = "🔄 "
+			case apierrors.ErrorTypeConflict:
+				icon = "🔀 "
+			case apierrors.ErrorTypeBranchProtection:
+				icon = "🛡️ "
 			default:
 				icon = "❌ "
 			}
 			m.message = icon + apiErr.GetUserFriendlyMessage()
 		} else {
 			m.err = msg.Err
+			m.apiErr = nil
 			m.message = ""
 		}
 		m.loading = false
@@ -326,10 +825,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.currentView == viewOwnerSelector || m.currentView == viewProjectList {
 				return m, tea.Quit
 			}
+		case "r":
+			// Recovery action on the error screen: run the failed load again
+			if m.err != nil && m.retryLoad != nil {
+				load := m.retryLoad
+				m.clearError()
+				m.loading = true
+				m.message = "Retrying..."
+				cmd := m.startLoad(load)
+				return m, cmd
+			}
 		case "esc":
 			// Clear error first
 			if m.err != nil {
-				m.err = nil
+				m.clearError()
+				return m, nil
+			}
+
+			// Abort a load in progress and stay where we are
+			if m.loading && m.cancelLoad != nil {
+				m.cancelLoads()
+				m.loading = false
+				m.message = ""
 				return m, nil
 			}
 			
@@ -337,17 +854,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch m.currentView {
 			case viewProjectList:
 				if len(m.orgs) > 0 {
+					m.cancelLoads()
 					m.currentView = viewOwnerSelector
 					return m, nil
 				}
 			case viewProjectDetail:
+				// Let the status pane, the close/reopen form and bulk mode close themselves first
+				if m.projectDetail.showStatus || m.projectDetail.stateForm != nil || m.projectDetail.bulk {
+					break
+				}
+				m.cancelLoads()
 				m.currentView = viewProjectList
 				return m, nil
 			case viewItemDetail:
+				// Let the comment composer, the action forms and the timeline close themselves first
+				if m.itemDetail.composing || m.itemDetail.stateForm != nil || m.itemDetail.prForm != nil || m.itemDetail.subIssueForm != nil || m.itemDetail.dependencyForm != nil || m.itemDetail.showTimeline {
+					break
+				}
+				m.cancelLoads()
 				m.currentView = viewProjectDetail
 				return m, nil
 			case viewItemEditor:
+				// Let the conflict screen close itself first
+				if m.itemEditor.conflict != nil {
+					break
+				}
 				// Go back to item detail if we came from there, otherwise project detail
+				m.cancelLoads()
 				if m.itemEditor.item != nil {
 					m.currentView = viewItemDetail
 				} else {
@@ -355,25 +888,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			case viewProjectCreator:
+				m.cancelLoads()
 				m.currentView = viewProjectList
 				return m, nil
 			case viewRepositorySelector:
+				m.cancelLoads()
 				m.currentView = viewItemDetail
 				return m, nil
+			case viewCollaborators:
+				// Let the collaborators screen cancel its search first
+				if m.collaborators.adding {
+					break
+				}
+				m.cancelLoads()
+				m.currentView = viewProjectDetail
+				return m, nil
 			case viewHelp:
+				m.cancelLoads()
 				m.currentView = viewProjectList
 				return m, nil
+			case viewOutbox:
+				m.currentView = m.outboxReturn
+				return m, nil
 			}
 		case "?":
+			// "?" is just a character while typing into a text field
+			if m.isTyping() {
+				break
+			}
 			if m.currentView == viewHelp {
 				m.currentView = viewProjectList
 			} else {
 				m.currentView = viewHelp
 			}
 			return m, nil
+		case "P":
+			// Review changes queued while offline
+			if m.outbox == nil || m.isTyping() || m.currentView == viewLoading {
+				break
+			}
+			return m, ShowOutboxCmd()
 		case "ctrl+d":
 			m.debugMode = !m.debugMode
-			return m, nil
+			m.logPaneSeq++
+			// The log pane takes its room from the view
+			resized, _ := m.Update(tea.WindowSizeMsg{Width: m.width, Height: m.windowHeight})
+			if m.debugMode {
+				return resized, logPaneTick(m.logPaneSeq)
+			}
+			return resized, nil
 		}
 	}
 
@@ -394,12 +957,206 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.projectCreator, cmd = m.projectCreator.Update(msg)
 	case viewRepositorySelector:
 		m.repositorySelector, cmd = m.repositorySelector.Update(msg)
+	case viewCollaborators:
+		m.collaborators, cmd = m.collaborators.Update(msg)
+	case viewOutbox:
+		m.outboxView, cmd = m.outboxView.Update(msg)
 	}
 
 	return m, cmd
 }
 
+// openProjects shows an owner's project list. Cached projects are shown right
+// away and revalidated in the background; otherwise the list loads as usual.
+func (m *Model) openProjects(owner string, isUser bool) tea.Cmd {
+	client, store := m.apiClient, m.cache
+
+	var projects []models.Project
+	if _, ok := store.Load(cache.ProjectsKey(owner), &projects); ok {
+		m.showProjects(projects)
+		return m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjects(ctx, client, store, owner, isUser, true)
+		})
+	}
+
+	m.loading = true
+	m.message = fmt.Sprintf("Loading projects for %s...", owner)
+	return m.startLoad(func(ctx context.Context) tea.Cmd {
+		return loadProjects(ctx, client, store, owner, isUser, false)
+	})
+}
+
+// openProject shows a project's items, from the cache first when possible
+func (m *Model) openProject(project models.Project) tea.Cmd {
+	client, store, owner := m.apiClient, m.cache, m.currentOwner
+
+	var items []models.ProjectItem
+	var fields []models.ProjectField
+	if _, ok := store.Load(cache.ItemsKey(owner, project.Number), &items); ok {
+		store.Load(cache.FieldsKey(owner, project.Number), &fields)
+		m.showProjectDetail(project, items, fields)
+		return m.startLoad(func(ctx context.Context) tea.Cmd {
+			return loadProjectItems(ctx, client, store, owner, project, true)
+		})
+	}
+
+	m.loading = true
+	m.message = "Loading project items..."
+	return m.startLoad(func(ctx context.Context) tea.Cmd {
+		return loadProjectItems(ctx, client, store, owner, project, false)
+	})
+}
+
+func (m *Model) showProjects(projects []models.Project) {
+	// Reloading the list in view, e.g. after toggling a template, keeps it filtered
+	templatesOnly := m.currentView == viewProjectList && m.projectList.templatesOnly
+	m.projectList = NewProjectListModel(projects)
+	if templatesOnly {
+		m.projectList.setTemplatesOnly(true)
+	}
+	m.projectList.width = m.width
+	m.projectList.height = m.height
+	m.currentView = viewProjectList
+	m.notice = ""
+	// Force window size update to list
+	m.projectList, _ = m.projectList.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+}
+
+func (m *Model) showProjectDetail(project models.Project, items []models.ProjectItem, fields []models.ProjectField) {
+	m.projectDetail = NewProjectDetailModel(project, items)
+	m.projectDetail.fields = fields
+	m.projectDetail.width = m.width
+	m.projectDetail.height = m.height
+	m.projectDetail.setPending(m.outbox.Pending())
+	m.currentView = viewProjectDetail
+	m.notice = ""
+}
+
+// syncPending refreshes every view that shows queued changes
+func (m *Model) syncPending() {
+	pending := m.outbox.Pending()
+	m.projectDetail.setPending(pending)
+	m.outboxView.setEntries(pending)
+}
+
+// refreshInterval returns the configured auto-refresh interval, zero when disabled
+func (m Model) refreshInterval() time.Duration {
+	if m.config == nil || m.config.AutoRefreshSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(m.config.AutoRefreshSeconds) * time.Second
+}
+
+// scheduleRefresh queues the next background refresh of the open project, if
+// auto-refresh is enabled. Any tick scheduled earlier is invalidated.
+func (m *Model) scheduleRefresh() tea.Cmd {
+	m.refreshSeq++
+	interval := m.refreshInterval()
+	if interval == 0 {
+		return nil
+	}
+	seq := m.refreshSeq
+	project := m.projectDetail.project
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return autoRefreshMsg{seq: seq, project: project}
+	})
+}
+
+// clearError dismisses the error screen
+func (m *Model) clearError() {
+	m.err = nil
+	m.apiErr = nil
+	m.retryLoad = nil
+	m.message = ""
+}
+
+// beginLoad cancels any in-flight load and returns the context for a new one.
+// Only one navigation load runs at a time; starting another supersedes it.
+func (m *Model) beginLoad() context.Context {
+	m.cancelLoads()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	return ctx
+}
+
+// startLoad begins a navigation load and remembers it so it can be retried
+// from the error screen
+func (m *Model) startLoad(load func(ctx context.Context) tea.Cmd) tea.Cmd {
+	ctx := m.beginLoad()
+	m.lastLoad = load
+	return load(ctx)
+}
+
+// beginBackgroundLoad returns the context for a load that runs alongside the
+// navigation load, such as a timeline page or suggestions. Background loads
+// don't supersede each other; they are cancelled with the navigation load.
+func (m *Model) beginBackgroundLoad() context.Context {
+	if m.backgroundCtx == nil {
+		m.backgroundCtx, m.cancelBackground = context.WithCancel(context.Background())
+	}
+	return m.backgroundCtx
+}
+
+// cancelLoads cancels the in-flight load and any background loads, so their
+// results are dropped
+func (m *Model) cancelLoads() {
+	m.releaseLoad()
+	if m.cancelBackground != nil {
+		m.cancelBackground()
+		m.backgroundCtx, m.cancelBackground = nil, nil
+	}
+}
+
+// releaseLoad cancels the in-flight navigation load, if any
+func (m *Model) releaseLoad() {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+		m.cancelLoad = nil
+	}
+}
+
+// withCachedDetails fills in an item's details from the cache when they are
+// still current, i.e. the item hasn't been updated since they were fetched
+func (m Model) withCachedDetails(item models.ProjectItem) models.ProjectItem {
+	if item.DetailsLoaded {
+		return item
+	}
+	if item.ContentID == "" {
+		// Nothing to fetch details for
+		item.DetailsLoaded = true
+		return item
+	}
+	if details, ok := m.itemDetails[item.ContentID]; ok && !item.UpdatedAt.After(details.UpdatedAt) {
+		return applyItemDetails(item, details)
+	}
+	return item
+}
+
+// isTyping reports whether the current view has a text field capturing keys
+func (m Model) isTyping() bool {
+	switch m.currentView {
+	case viewItemEditor, viewProjectCreator, viewRepositorySelector:
+		return true
+	case viewItemDetail:
+		return m.itemDetail.composing || m.itemDetail.stateForm != nil || m.itemDetail.prForm != nil || m.itemDetail.subIssueForm != nil || m.itemDetail.dependencyForm != nil
+	case viewProjectDetail:
+		return m.projectDetail.stateForm != nil || m.projectDetail.showStatus && m.projectDetail.statusPane.composing
+	case viewCollaborators:
+		return m.collaborators.adding
+	case viewProjectList:
+		return m.projectList.list.FilterState() == list.Filtering
+	}
+	return false
+}
+
 func (m Model) View() string {
+	if m.debugMode {
+		return lipgloss.JoinVertical(lipgloss.Left, m.renderView(), m.renderLogPane(), m.renderStatusBar())
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, m.renderView(), m.renderStatusBar())
+}
+
+func (m Model) renderView() string {
 	if m.loading {
 		return m.renderLoading()
 	}
@@ -423,6 +1180,10 @@ func (m Model) View() string {
 		return m.projectCreator.View()
 	case viewRepositorySelector:
 		return m.repositorySelector.View()
+	case viewCollaborators:
+		return m.collaborators.View()
+	case viewOutbox:
+		return m.outboxView.View()
 	case viewHelp:
 		return m.renderHelp()
 	default:
@@ -430,6 +1191,57 @@ func (m Model) View() string {
 	}
 }
 
+// renderStatusBar shows the remaining API budget and any throttling in effect
+func (m Model) renderStatusBar() string {
+	statusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#626262")).
+		PaddingLeft(2)
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFA500")).
+		PaddingLeft(2)
+
+	exhaustedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0000")).
+		PaddingLeft(2)
+
+	if m.notice != "" {
+		return warningStyle.Render(m.notice)
+	}
+
+	if m.apiClient == nil {
+		return ""
+	}
+	rl := m.apiClient.RateLimit()
+	if !rl.Known {
+		return ""
+	}
+
+	if rl.BackoffUntil.After(time.Now()) {
+		return warningStyle.Render(fmt.Sprintf("⏸ Secondary rate limit hit, requests paused until %s", rl.BackoffUntil.Local().Format("15:04:05")))
+	}
+
+	text := fmt.Sprintf("API budget %d/%d • resets %s", rl.Remaining, rl.Limit, rl.ResetAt.Local().Format("15:04"))
+	if pending := len(m.outbox.Pending()); pending > 0 {
+		text = fmt.Sprintf("⏳ %d changes waiting to sync (P: review) • %s", pending, text)
+	}
+	if rl.Cost > 0 {
+		text += fmt.Sprintf(" • last query cost %d", rl.Cost)
+	}
+
+	switch {
+	case rl.Remaining <= 0:
+		return exhaustedStyle.Render("⏱️ " + text)
+	case rl.Low():
+		if m.refreshInterval() > 0 {
+			text += " • background refresh paused"
+		}
+		return warningStyle.Render("⚠️ " + text)
+	default:
+		return statusStyle.Render(text)
+	}
+}
+
 func (m Model) renderLoading() string {
 	loadingStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#7D56F4")).
@@ -473,11 +1285,18 @@ Actions:
   n              Create new item/project
   e              Edit selected item
   d              Delete selected item
+  s              Project status updates
+  r              Comment on issue/PR (item view)
+  [ / ]          Select previous/next comment
+  E / D          Edit/delete your selected comment
+  L              Load more comments
+  C              Manage project collaborators
+  P              Review changes queued while offline
 
 General:
   ?              Toggle help
   q or ctrl+c    Quit (from main views)
-  ctrl+d         Toggle debug mode
+  ctrl+d         Toggle debug log pane
 `
 
 	help := helpStyle.Render(helpText)
@@ -498,6 +1317,10 @@ func (m Model) renderError() string {
 		Foreground(lipgloss.Color("#888888")).
 		Padding(1, 2)
 
+	recoveryStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFA500")).
+		Padding(0, 2)
+
 	var content string
 	
 	// If we have a friendly message, show it prominently
@@ -506,47 +1329,109 @@ func (m Model) renderError() string {
 		errorText = m.message
 	}
 	
+	keys := "Press esc to continue, q to quit"
+	if m.retryLoad != nil {
+		keys = "Press r to retry, esc to continue, q to quit"
+	}
+
 	if m.debugMode {
+		technical := fmt.Sprintf("Current view: %v\nUsername: %s\nOrgs: %v\nOwner: %s\nTechnical error: %v",
+			m.currentView, m.username, m.orgs, m.currentOwner, m.err)
+		if m.apiErr != nil {
+			technical += fmt.Sprintf("\nType: %s • HTTP status: %d • GraphQL type: %s\nCause: %v",
+				m.apiErr.Type, m.apiErr.HTTPStatus, m.apiErr.GraphQLType, m.apiErr.OriginalErr)
+		}
 		content = lipgloss.JoinVertical(lipgloss.Left,
 			errorStyle.Render(errorText),
-			debugStyle.Render(fmt.Sprintf("Current view: %v\nUsername: %s\nOrgs: %v\nOwner: %s\nTechnical error: %v", 
-				m.currentView, m.username, m.orgs, m.currentOwner, m.err)),
-			helpStyle.Render("Press esc to continue, q to quit, ctrl+d to hide debug"),
+			recoveryStyle.Render(m.recoveryHint()),
+			debugStyle.Render(technical),
+			helpStyle.Render(keys+", ctrl+d to hide debug"),
 		)
 	} else {
 		content = lipgloss.JoinVertical(lipgloss.Left,
 			errorStyle.Render(errorText),
-			helpStyle.Render("Press esc to continue, q to quit, ctrl+d for debug"),
+			recoveryStyle.Render(m.recoveryHint()),
+			helpStyle.Render(keys+", ctrl+d for debug"),
 		)
 	}
 
 	return content
 }
 
+// recoveryHint suggests what the user can do about the current error, based on its type
+func (m Model) recoveryHint() string {
+	if m.apiErr == nil {
+		return ""
+	}
+
+	switch m.apiErr.Type {
+	case apierrors.ErrorTypeRateLimit:
+		if m.apiErr.RetryAfter > 0 {
+			return fmt.Sprintf("The limit resets in about %v. Wait, then press r to retry.", m.apiErr.RetryAfter.Round(time.Second))
+		}
+		return "Wait a minute for the limit to reset, then press r to retry."
+	case apierrors.ErrorTypePermission:
+		return "Run 'gh auth refresh -s project,read:org' to grant the missing scopes, or ask an admin for access."
+	case apierrors.ErrorTypeValidation:
+		return "Check the values you entered, then press esc and try again."
+	case apierrors.ErrorTypeConflict:
+		if m.retryLoad != nil {
+			return "Press r to reload the latest version, then reapply your change."
+		}
+		return "Reload the item to get the latest version, then reapply your change."
+	case apierrors.ErrorTypeRetryable:
+		return "Check your network connection. The request was already retried automatically."
+	case apierrors.ErrorTypeBranchProtection:
+		return "Satisfy the branch's protection rules first, such as required reviews and checks, then try again."
+	default:
+		return ""
+	}
+}
+
 // Commands and messages
 
-func initializeApp() tea.Msg {
-	// Check authentication
-	if err := auth.CheckAuthentication(); err != nil {
-		return ErrorMsg{Err: err}
+// initializeApp connects to GitHub, or uses client if one was provided
+func initializeApp(client api.Interface) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			var err error
+			if client, err = newClient(); err != nil {
+				return ErrorMsg{Err: err}
+			}
+		}
+		return initialize(client)
 	}
+}
 
-	// Get username
-	username, err := auth.GetAuthenticatedUser()
-	if err != nil {
-		return ErrorMsg{Err: fmt.Errorf("failed to get user: %w", err)}
+// newClient checks that the user is logged in and creates a GitHub client
+func newClient() (api.Interface, error) {
+	// A replayed session needs no credentials
+	if !api.Replaying() {
+		if err := auth.CheckAuthentication(); err != nil {
+			return nil, err
+		}
 	}
 
-	// Create API client
 	client, err := api.NewClient()
 	if err != nil {
-		return ErrorMsg{Err: fmt.Errorf("failed to create API client: %w", err)}
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+	return client, nil
+}
+
+// initialize loads what every session needs before showing projects
+func initialize(client api.Interface) tea.Msg {
+	// Get username through the client so the lookup is part of recorded sessions
+	username, err := client.GetViewer(context.Background())
+	if err != nil {
+		return ErrorMsg{Err: fmt.Errorf("failed to get user: %w", err)}
 	}
 
 	// Get organizations
-	orgs, err := client.GetUserOrganizations(username)
+	orgs, err := client.GetUserOrganizations(context.Background(), username)
 	if err != nil {
 		// Non-fatal, just log and continue
+		slog.Warn("failed to load organizations", "err", err)
 		orgs = []string{}
 	}
 
@@ -561,115 +1446,281 @@ func initializeApp() tea.Msg {
 	}
 }
 
-func loadProjects(client *api.Client, owner string, isUser bool) tea.Cmd {
-	return func() tea.Msg {
+// loadProjects loads an owner's projects and caches them. A revalidating load
+// refreshes a cached list already on screen, so it fails softly.
+func loadProjects(ctx context.Context, client api.Interface, store *cache.Cache, owner string, isUser bool, revalidate bool) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
 		var projects []models.Project
 		var err error
 		
 		if isUser {
-			projects, err = client.ListUserProjects(owner, 100)
+			projects, err = client.ListUserProjects(ctx, owner, 100)
 		} else {
-			projects, err = client.ListOrgProjects(owner, 100)
+			projects, err = client.ListOrgProjects(ctx, owner, 100)
 		}
 		
 		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("failed to load projects for %s: %w", owner, err)}
+			err = fmt.Errorf("failed to load projects for %s: %w", owner, err)
+			if revalidate {
+				return RevalidateFailedMsg{Err: err}
+			}
+			return ErrorMsg{Err: err}
 		}
-		return ProjectsLoadedMsg{Projects: projects}
-	}
+		// The cache is best effort, a failed write only costs a spinner next time
+		_ = store.Store(cache.ProjectsKey(owner), projects)
+		return ProjectsLoadedMsg{Projects: projects, Revalidated: revalidate}
+	})
 }
 
-func loadProjectItems(client *api.Client, project models.Project) tea.Cmd {
-	return func() tea.Msg {
-		items, err := client.ListProjectItems(project.ID, 100)
-		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("failed to load items: %w", err)}
+// loadProjectItems loads a project's items and fields and caches them. A
+// revalidating load refreshes cached items already on screen, so it fails softly.
+func loadProjectItems(ctx context.Context, client api.Interface, store *cache.Cache, owner string, project models.Project, revalidate bool) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		items, err := client.ListProjectItems(ctx, project.ID, 100)
+		if err == nil {
+			var fields []models.ProjectField
+			fields, err = client.ListProjectFields(ctx, project.ID)
+			if err == nil {
+				// The cache is best effort, a failed write only costs a spinner next time
+				_ = store.Store(cache.ItemsKey(owner, project.Number), items)
+				_ = store.Store(cache.FieldsKey(owner, project.Number), fields)
+				return ProjectItemsLoadedMsg{
+					Project:     project,
+					Items:       items,
+					Fields:      fields,
+					Revalidated: revalidate,
+				}
+			}
 		}
-		return ProjectItemsLoadedMsg{
-			Project: project,
-			Items:   items,
+
+		err = fmt.Errorf("failed to load items: %w", err)
+		if revalidate {
+			return RevalidateFailedMsg{Err: err}
 		}
+		return ErrorMsg{Err: err}
+	})
+}
+
+// refreshProjectItems reloads a project's items as background work, so the
+// request waits while the rate limit budget is low. It gives up after timeout,
+// which is the refresh interval, rather than let refreshes pile up.
+func refreshProjectItems(client api.Interface, seq int, project models.Project, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(api.WithPriority(context.Background(), api.PriorityBackground), timeout)
+		defer cancel()
+
+		items, err := client.ListProjectItems(ctx, project.ID, 100)
+		return projectItemsRefreshedMsg{seq: seq, project: project, items: items, err: err}
 	}
 }
 
-func saveItem(client *api.Client, msg SaveItemMsg) tea.Cmd {
+func saveItem(ctx context.Context, client api.Interface, box *outbox.Outbox, msg SaveItemMsg) tea.Cmd {
 	return func() tea.Msg {
-		// DEBUG: Log save attempt
-		fmt.Fprintf(os.Stderr, "\n=== SAVE ITEM DEBUG ===\n")
-		fmt.Fprintf(os.Stderr, "IsNewItem: %v\n", msg.IsNewItem)
-		fmt.Fprintf(os.Stderr, "Title: %s\n", msg.Title)
-		fmt.Fprintf(os.Stderr, "Assignee: %s\n", msg.Assignee)
-		
-		// Get assignee node ID if username provided
-		var assigneeIDs []string
-		if msg.Assignee != "" {
-			nodeID, err := client.GetUserNodeID(msg.Assignee)
+		slog.Debug("saving item", "new", msg.IsNewItem, "title", msg.Title, "assignees", msg.Assignees, "force", msg.Force)
+
+		if msg.IsNewItem {
+			// Get assignee node IDs if usernames provided
+			assigneeIDs, err := userNodeIDs(ctx, client, msg.Assignees)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "ERROR: Failed to get user node ID: %v\n", err)
-				return ErrorMsg{Err: fmt.Errorf("failed to get user ID for %s: %w", msg.Assignee, err)}
+				slog.Warn("failed to look up assignees", "assignees", msg.Assignees, "err", err)
+				if queued, ok := queueIfOffline(box, err, msg.Project, savedMutation(msg)); ok {
+					return queued
+				}
+				return ErrorMsg{Err: err}
 			}
-			assigneeIDs = []string{nodeID}
-			fmt.Fprintf(os.Stderr, "Assignee node ID: %s\n", nodeID)
-		}
 
-		if msg.IsNewItem {
 			// Create draft issue (without assignees initially)
-			fmt.Fprintf(os.Stderr, "Creating draft issue in project: %s\n", msg.Project.ID)
-			item, err := client.CreateDraftIssue(models.CreateItemInput{
+			item, err := client.CreateDraftIssue(ctx, models.CreateItemInput{
 				ProjectID:   msg.Project.ID,
 				Title:       msg.Title,
 				Body:        msg.Body,
 			})
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "ERROR: CreateDraftIssue failed: %v\n", err)
+				if queued, ok := queueIfOffline(box, err, msg.Project, savedMutation(msg)); ok {
+					return queued
+				}
 				return ErrorMsg{Err: err}
 			}
-			
-			fmt.Fprintf(os.Stderr, "Created item - ID: %s, ContentID: %s\n", item.ID, item.ContentID)
-			
+
 			// If assignees specified, update the draft issue with them
 			// Use ContentID (draft issue ID), not project item ID
 			if len(assigneeIDs) > 0 {
-				fmt.Fprintf(os.Stderr, "Updating draft with assignees - ContentID: %s\n", item.ContentID)
-				_, err = client.UpdateDraftIssue(item.ContentID, msg.Title, msg.Body, assigneeIDs)
+				_, err = client.UpdateDraftIssue(ctx, item.ContentID, msg.Title, msg.Body, assigneeIDs)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "ERROR: UpdateDraftIssue failed: %v\n", err)
+					// The draft exists, so only the assignment is queued
+					assignment := savedMutation(msg)
+					assignment.Kind = outbox.KindUpdateDraft
+					assignment.ItemID = item.ID
+					assignment.ContentID = item.ContentID
+					if queued, ok := queueIfOffline(box, err, msg.Project, assignment); ok {
+						return queued
+					}
 					// Partial success: draft created but assignee failed
-					if apiErr, ok := err.(*apierrors.APIError); ok {
+					var apiErr *apierrors.APIError
+					if errors.As(err, &apiErr) {
 						return PartialSuccessMsg{
-							Message:      "Draft issue created, but failed to assign user: " + apiErr.GetUserFriendlyMessage(),
+							Message:      "Draft issue created, but failed to assign users: " + apiErr.GetUserFriendlyMessage(),
 							WarningError: err,
 						}
 					}
 					return PartialSuccessMsg{
-						Message:      "Draft issue created, but failed to assign user",
+						Message:      "Draft issue created, but failed to assign users",
 						WarningError: err,
 					}
 				}
-				fmt.Fprintf(os.Stderr, "Successfully assigned user\n")
 			}
 		} else {
 			// For updates, use ContentID (the actual draft issue/issue ID)
-			fmt.Fprintf(os.Stderr, "Editing existing item - ID: %s, ContentID: %s\n", msg.Item.ID, msg.Item.ContentID)
 			contentID := msg.Item.ContentID
 			if contentID == "" {
-				fmt.Fprintf(os.Stderr, "ERROR: ContentID is empty! Falling back to ID: %s\n", msg.Item.ID)
+				slog.Warn("item has no content ID, falling back to its project item ID", "item", msg.Item.ID)
 				// Fallback for items that might not have ContentID populated
 				contentID = msg.Item.ID
 			}
-			fmt.Fprintf(os.Stderr, "Updating draft issue with ContentID: %s\n", contentID)
-			_, err := client.UpdateDraftIssue(contentID, msg.Title, msg.Body, assigneeIDs)
+			if !msg.Force && !msg.Base.UpdatedAt.IsZero() {
+				conflict, err := findConflict(ctx, client, contentID, msg.Base)
+				if err != nil {
+					if queued, ok := queueIfOffline(box, err, msg.Project, savedMutation(msg)); ok {
+						return queued
+					}
+					return ErrorMsg{Err: fmt.Errorf("failed to check for changes: %w", err)}
+				}
+				if conflict != nil {
+					slog.Info("save stopped by a conflicting edit", "content", contentID)
+					return *conflict
+				}
+			}
+			err := updateContent(ctx, client, msg.Item.Type, contentID, msg.Title, msg.Body, msg.Item.Assignees, msg.Assignees)
+			if err == nil {
+				err = updateLabelsAndMilestone(ctx, client, msg.Item.Type, contentID, labelIDs(msg.Item.Labels), labelIDs(msg.Labels), milestoneID(msg.Item.Milestone), milestoneID(msg.Milestone))
+			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "ERROR: UpdateDraftIssue failed: %v\n", err)
+				if queued, ok := queueIfOffline(box, err, msg.Project, savedMutation(msg)); ok {
+					return queued
+				}
 				return ErrorMsg{Err: fmt.Errorf("failed to update item: %w", err)}
 			}
-			fmt.Fprintf(os.Stderr, "Successfully updated item\n")
 		}
-		fmt.Fprintf(os.Stderr, "=== SAVE COMPLETE ===\n\n")
 		return ItemSavedMsg{}
 	}
 }
 
+// updateContent saves an edit of an existing item with the mutations its type
+// takes. Drafts get title, body and assignees in one mutation; issues and pull
+// requests are updated first and then have assignees added and removed.
+func updateContent(ctx context.Context, client api.Interface, contentType, contentID, title, body string, previousAssignees, assignees []string) error {
+	add, remove := assigneeChanges(previousAssignees, assignees)
+
+	if contentType != "Issue" && contentType != "PullRequest" {
+		// A draft's assignees are replaced as a whole, nil keeps them
+		var assigneeIDs []string
+		if len(add) > 0 || len(remove) > 0 {
+			ids, err := userNodeIDs(ctx, client, assignees)
+			if err != nil {
+				return err
+			}
+			assigneeIDs = ids
+		}
+		_, err := client.UpdateDraftIssue(ctx, contentID, title, body, assigneeIDs)
+		return err
+	}
+
+	var err error
+	if contentType == "PullRequest" {
+		_, err = client.UpdatePullRequest(ctx, contentID, title, body)
+	} else {
+		_, err = client.UpdateIssue(ctx, contentID, title, body)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(add) > 0 {
+		ids, err := userNodeIDs(ctx, client, add)
+		if err != nil {
+			return err
+		}
+		if err := client.AddAssignees(ctx, contentID, ids); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		ids, err := userNodeIDs(ctx, client, remove)
+		if err != nil {
+			return err
+		}
+		if err := client.RemoveAssignees(ctx, contentID, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assigneeChanges works out who to assign and unassign to go from the
+// previous assignees to the edited ones. Logins compare case-insensitively,
+// as they do on GitHub.
+func assigneeChanges(previous, assignees []string) (add, remove []string) {
+	for _, login := range assignees {
+		if !containsLogin(previous, login) {
+			add = append(add, login)
+		}
+	}
+	for _, login := range previous {
+		if !containsLogin(assignees, login) {
+			remove = append(remove, login)
+		}
+	}
+	return add, remove
+}
+
+func containsLogin(logins []string, login string) bool {
+	return slices.ContainsFunc(logins, func(l string) bool {
+		return strings.EqualFold(l, login)
+	})
+}
+
+// userNodeIDs looks up the node IDs of users by login
+func userNodeIDs(ctx context.Context, client api.Interface, logins []string) ([]string, error) {
+	ids := make([]string, 0, len(logins))
+	for _, login := range logins {
+		id, err := client.GetUserNodeID(ctx, login)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user ID for %s: %w", login, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// savedMutation describes a save as a change to queue while offline
+func savedMutation(msg SaveItemMsg) outbox.Mutation {
+	mutation := outbox.Mutation{
+		Kind:      outbox.KindCreateDraft,
+		Title:     msg.Title,
+		Body:      msg.Body,
+		Assignees: msg.Assignees,
+	}
+	if !msg.IsNewItem && msg.Item != nil {
+		mutation.Kind = outbox.KindUpdateDraft
+		mutation.ItemID = msg.Item.ID
+		mutation.ContentID = msg.Item.ContentID
+		if mutation.ContentID == "" {
+			mutation.ContentID = msg.Item.ID
+		}
+		mutation.BaseUpdatedAt = msg.Base.UpdatedAt
+		mutation.Force = msg.Force
+		mutation.PreviousAssignees = msg.Item.Assignees
+		if msg.Item.Type == "Issue" || msg.Item.Type == "PullRequest" {
+			mutation.ContentType = msg.Item.Type
+			mutation.LabelIDs = labelIDs(msg.Labels)
+			mutation.PreviousLabelIDs = labelIDs(msg.Item.Labels)
+			mutation.MilestoneID = milestoneID(msg.Milestone)
+			mutation.PreviousMilestoneID = milestoneID(msg.Item.Milestone)
+		}
+	}
+	return mutation
+}
+
 func openURL(url string) tea.Cmd {
 	return func() tea.Msg {
 		// Try different commands based on OS
@@ -693,7 +1744,7 @@ func openURL(url string) tea.Cmd {
 	}
 }
 
-func createProject(client *api.Client, msg CreateProjectMsg) tea.Cmd {
+func createProject(ctx context.Context, client api.Interface, msg CreateProjectMsg) tea.Cmd {
 	return func() tea.Msg {
 		// First, get the owner ID
 		var ownerID string
@@ -701,17 +1752,46 @@ func createProject(client *api.Client, msg CreateProjectMsg) tea.Cmd {
 		
 		if msg.IsUserOwner {
 			// For user, we need to query the user's node ID
-			ownerID, err = client.GetUserNodeID(msg.OwnerLogin)
+			ownerID, err = client.GetUserNodeID(ctx, msg.OwnerLogin)
 		} else {
 			// For org, we need to query the org's node ID
-			ownerID, err = client.GetOrgNodeID(msg.OwnerLogin)
+			ownerID, err = client.GetOrgNodeID(ctx, msg.OwnerLogin)
 		}
 		
 		if err != nil {
 			return ErrorMsg{Err: fmt.Errorf("failed to get owner ID: %w", err)}
 		}
 		
-		_, err = client.CreateProject(models.CreateProjectInput{
+		if msg.SourceProject != nil {
+			// Copy fields and views from the source project
+			project, err := client.CopyProject(ctx, models.CopyProjectInput{
+				SourceProjectID:    msg.SourceProject.ID,
+				OwnerID:            ownerID,
+				Title:              msg.Title,
+				IncludeDraftIssues: msg.IncludeDrafts,
+			})
+			if err != nil {
+				return ErrorMsg{Err: fmt.Errorf("failed to copy project: %w", err)}
+			}
+
+			// copyProjectV2 only takes a title, apply the rest afterwards
+			update := models.UpdateProjectInput{ProjectID: project.ID}
+			if msg.Description != "" {
+				update.ShortDescription = &msg.Description
+			}
+			if msg.Public {
+				update.Public = &msg.Public
+			}
+			if update.ShortDescription != nil || update.Public != nil {
+				if err := client.UpdateProject(ctx, update); err != nil {
+					return ErrorMsg{Err: fmt.Errorf("project copied, but failed to update its settings: %w", err)}
+				}
+			}
+
+			return ProjectCreatedMsg{}
+		}
+		
+		_, err = client.CreateProject(ctx, models.CreateProjectInput{
 			OwnerID:          ownerID,
 			Title:            msg.Title,
 			ShortDescription: msg.Description,
@@ -726,19 +1806,32 @@ func createProject(client *api.Client, msg CreateProjectMsg) tea.Cmd {
 	}
 }
 
-func deleteItem(client *api.Client, msg DeleteItemMsg) tea.Cmd {
+func toggleTemplate(ctx context.Context, client api.Interface, project models.Project) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.SetProjectTemplate(ctx, project.ID, !project.Template); err != nil {
+			return ErrorMsg{Err: fmt.Errorf("failed to update template status: %w", err)}
+		}
+		return TemplateToggledMsg{}
+	}
+}
+
+func deleteItem(ctx context.Context, client api.Interface, box *outbox.Outbox, msg DeleteItemMsg) tea.Cmd {
 	return func() tea.Msg {
-		err := client.DeleteProjectItem(msg.Project.ID, msg.Item.ID)
+		err := client.DeleteProjectItem(ctx, msg.Project.ID, msg.Item.ID)
 		if err != nil {
+			deletion := outbox.Mutation{Kind: outbox.KindDeleteItem, ItemID: msg.Item.ID, Title: msg.Item.Title}
+			if queued, ok := queueIfOffline(box, err, msg.Project, deletion); ok {
+				return queued
+			}
 			return ErrorMsg{Err: fmt.Errorf("failed to delete item: %w", err)}
 		}
 		return ItemDeletedMsg{Project: msg.Project}
 	}
 }
 
-func loadRepositories(client *api.Client, owner string, isUser bool, project models.Project, item models.ProjectItem) tea.Cmd {
-	return func() tea.Msg {
-		repos, err := client.ListRepositories(owner, isUser)
+func loadRepositories(ctx context.Context, client api.Interface, owner string, isUser bool, project models.Project, item models.ProjectItem) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		repos, err := client.ListRepositories(ctx, owner, isUser)
 		if err != nil {
 			return ErrorMsg{Err: fmt.Errorf("failed to load repositories: %w", err)}
 		}
@@ -747,16 +1840,16 @@ func loadRepositories(client *api.Client, owner string, isUser bool, project mod
 			Project:      project,
 			Item:         item,
 		}
-	}
+	})
 }
 
-func convertDraft(client *api.Client, msg ConvertDraftMsg) tea.Cmd {
+func convertDraft(ctx context.Context, client api.Interface, msg ConvertDraftMsg) tea.Cmd {
 	return func() tea.Msg {
 		// Get the repository node ID
 		repoID := msg.Repository.ID
 		
 		// Convert the draft issue to a real issue
-		_, err := client.ConvertDraftIssueToIssue(msg.Item.ID, repoID)
+		_, err := client.ConvertDraftIssueToIssue(ctx, msg.Item.ID, repoID)
 		if err != nil {
 			return ErrorMsg{Err: fmt.Errorf("failed to convert draft to issue: %w", err)}
 		}
@@ -765,24 +1858,20 @@ func convertDraft(client *api.Client, msg ConvertDraftMsg) tea.Cmd {
 	}
 }
 
-func saveAndConvert(client *api.Client, owner string, isUser bool, msg SaveAndConvertMsg) tea.Cmd {
+func saveAndConvert(ctx context.Context, client api.Interface, owner string, isUser bool, msg SaveAndConvertMsg) tea.Cmd {
 	return func() tea.Msg {
-		// Get assignee node ID if username provided
-		var assigneeIDs []string
-		if msg.Assignee != "" {
-			nodeID, err := client.GetUserNodeID(msg.Assignee)
-			if err != nil {
-				return ErrorMsg{Err: fmt.Errorf("failed to get user ID for %s: %w", msg.Assignee, err)}
-			}
-			assigneeIDs = []string{nodeID}
-		}
-
 		var savedItem *models.ProjectItem
 		var err error
 
 		if msg.IsNewItem {
+			// Get assignee node IDs if usernames provided
+			assigneeIDs, err := userNodeIDs(ctx, client, msg.Assignees)
+			if err != nil {
+				return ErrorMsg{Err: err}
+			}
+
 			// Create draft issue (without assignees initially)
-			savedItem, err = client.CreateDraftIssue(models.CreateItemInput{
+			savedItem, err = client.CreateDraftIssue(ctx, models.CreateItemInput{
 				ProjectID:   msg.Project.ID,
 				Title:       msg.Title,
 				Body:        msg.Body,
@@ -793,9 +1882,9 @@ func saveAndConvert(client *api.Client, owner string, isUser bool, msg SaveAndCo
 			
 			// If assignees specified, update the draft issue with them
 			if len(assigneeIDs) > 0 {
-				_, err = client.UpdateDraftIssue(savedItem.ContentID, msg.Title, msg.Body, assigneeIDs)
+				_, err = client.UpdateDraftIssue(ctx, savedItem.ContentID, msg.Title, msg.Body, assigneeIDs)
 				if err != nil {
-					return ErrorMsg{Err: fmt.Errorf("item created but failed to assign user: %w", err)}
+					return ErrorMsg{Err: fmt.Errorf("item created but failed to assign users: %w", err)}
 				}
 			}
 		} else {
@@ -804,7 +1893,24 @@ func saveAndConvert(client *api.Client, owner string, isUser bool, msg SaveAndCo
 			if contentID == "" {
 				contentID = msg.Item.ID
 			}
-			savedItem, err = client.UpdateDraftIssue(contentID, msg.Title, msg.Body, assigneeIDs)
+			if !msg.Force && !msg.Base.UpdatedAt.IsZero() {
+				conflict, err := findConflict(ctx, client, contentID, msg.Base)
+				if err != nil {
+					return ErrorMsg{Err: fmt.Errorf("failed to check for changes: %w", err)}
+				}
+				if conflict != nil {
+					return *conflict
+				}
+			}
+			// Only set the assignees if they changed, nil keeps them
+			var assigneeIDs []string
+			if add, remove := assigneeChanges(msg.Item.Assignees, msg.Assignees); len(add) > 0 || len(remove) > 0 {
+				assigneeIDs, err = userNodeIDs(ctx, client, msg.Assignees)
+				if err != nil {
+					return ErrorMsg{Err: err}
+				}
+			}
+			savedItem, err = client.UpdateDraftIssue(ctx, contentID, msg.Title, msg.Body, assigneeIDs)
 			if err != nil {
 				return ErrorMsg{Err: fmt.Errorf("failed to update item: %w", err)}
 			}
@@ -818,22 +1924,160 @@ func saveAndConvert(client *api.Client, owner string, isUser bool, msg SaveAndCo
 	}
 }
 
+func loadCollaborators(ctx context.Context, client api.Interface, project models.Project) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		collaborators, err := client.ListProjectCollaborators(ctx, project.ID, 100)
+		if err != nil {
+			return ErrorMsg{Err: fmt.Errorf("failed to load collaborators: %w", err)}
+		}
+		return CollaboratorsLoadedMsg{
+			Project:       project,
+			Collaborators: collaborators,
+		}
+	})
+}
+
+func updateCollaborator(ctx context.Context, client api.Interface, msg UpdateCollaboratorMsg) tea.Cmd {
+	return func() tea.Msg {
+		collaborator := msg.Collaborator
+
+		// Users picked from search only carry a login, resolve their node ID
+		if collaborator.ID == "" {
+			nodeID, err := client.GetUserNodeID(ctx, collaborator.Login)
+			if err != nil {
+				return ErrorMsg{Err: fmt.Errorf("failed to get user ID for %s: %w", collaborator.Login, err)}
+			}
+			collaborator.ID = nodeID
+		}
+
+		err := client.UpdateProjectCollaborators(ctx, msg.Project.ID, []models.ProjectCollaborator{collaborator})
+		if err != nil {
+			return ErrorMsg{Err: fmt.Errorf("failed to update collaborators: %w", err)}
+		}
+
+		return loadCollaborators(ctx, client, msg.Project)()
+	}
+}
+
+func loadStatusUpdates(ctx context.Context, client api.Interface, project models.Project) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		updates, err := client.ListStatusUpdates(ctx, project.ID, 50)
+		if err != nil {
+			return StatusUpdatesFailedMsg{Project: project, Err: fmt.Errorf("failed to load status updates: %w", err)}
+		}
+		return StatusUpdatesLoadedMsg{
+			Project: project,
+			Updates: updates,
+		}
+	})
+}
+
+func postStatusUpdate(ctx context.Context, client api.Interface, msg PostStatusUpdateMsg) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := client.CreateStatusUpdate(ctx, msg.Input); err != nil {
+			return StatusUpdatesFailedMsg{Project: msg.Project, Err: fmt.Errorf("failed to post status update: %w", err), Posting: true}
+		}
+		return loadStatusUpdates(ctx, client, msg.Project)()
+	}
+}
+
+func loadItemDetails(ctx context.Context, client api.Interface, item models.ProjectItem, forEdit bool) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		details, err := client.GetItemDetails(ctx, item.ContentID)
+		if err != nil {
+			return ItemDetailsFailedMsg{
+				Item:    item,
+				Err:     fmt.Errorf("failed to load item details: %w", err),
+				ForEdit: forEdit,
+			}
+		}
+		return ItemDetailsLoadedMsg{
+			Item:    item,
+			Details: *details,
+			ForEdit: forEdit,
+		}
+	})
+}
+
+func submitComment(ctx context.Context, client api.Interface, msg SubmitCommentMsg) tea.Cmd {
+	return func() tea.Msg {
+		if msg.CommentID != "" {
+			comment, err := client.UpdateComment(ctx, msg.CommentID, msg.Body)
+			if err != nil {
+				return ErrorMsg{Err: fmt.Errorf("failed to update comment: %w", err)}
+			}
+			return CommentSavedMsg{ItemID: msg.Item.ID, Comment: *comment}
+		}
+
+		comment, err := client.AddComment(ctx, msg.Item.ContentID, msg.Body)
+		if err != nil {
+			return ErrorMsg{Err: fmt.Errorf("failed to add comment: %w", err)}
+		}
+		return CommentSavedMsg{ItemID: msg.Item.ID, Comment: *comment, IsNew: true}
+	}
+}
+
+func deleteComment(ctx context.Context, client api.Interface, msg DeleteCommentMsg) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.DeleteComment(ctx, msg.CommentID); err != nil {
+			return ErrorMsg{Err: fmt.Errorf("failed to delete comment: %w", err)}
+		}
+		return CommentDeletedMsg{ItemID: msg.Item.ID, CommentID: msg.CommentID}
+	}
+}
+
+func loadMoreComments(ctx context.Context, client api.Interface, item models.ProjectItem) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		comments, pageInfo, err := client.ListComments(ctx, item.ContentID, 50, item.CommentsPage.EndCursor)
+		if err != nil {
+			return ErrorMsg{Err: fmt.Errorf("failed to load comments: %w", err)}
+		}
+		return CommentsPageLoadedMsg{
+			ItemID:   item.ID,
+			Comments: comments,
+			PageInfo: pageInfo,
+		}
+	})
+}
+
+// cancellable tags a load's result with its context so Update can drop it
+// if the load was cancelled before the result arrived
+func cancellable(ctx context.Context, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return cancellableMsg{ctx: ctx, msg: cmd()}
+	}
+}
+
 // Messages
 
+// cancellableMsg wraps the result of a load started with beginLoad
+type cancellableMsg struct {
+	ctx context.Context
+	msg tea.Msg
+}
+
 type InitializedMsg struct {
-	Client   *api.Client
+	Client   api.Interface
 	Username string
 	Orgs     []string
 	Config   *config.Config
 }
 
 type ProjectsLoadedMsg struct {
-	Projects []models.Project
+	Projects    []models.Project
+	Revalidated bool // Refreshes a cached list already on screen
 }
 
 type ProjectItemsLoadedMsg struct {
-	Project models.Project
-	Items   []models.ProjectItem
+	Project     models.Project
+	Items       []models.ProjectItem
+	Fields      []models.ProjectField
+	Revalidated bool // Refreshes cached items already on screen
+}
+
+// RevalidateFailedMsg is sent when refreshing cached data fails
+type RevalidateFailedMsg struct {
+	Err error
 }
 
 type ItemSavedMsg struct{}
@@ -844,6 +2088,8 @@ type ItemDeletedMsg struct {
 
 type ProjectCreatedMsg struct{}
 
+type TemplateToggledMsg struct{}
+
 type ItemSavedAndReadyToConvertMsg struct {
 	Project models.Project
 	Item    models.ProjectItem
@@ -858,20 +2104,37 @@ type ErrorMsg struct {
 	Err error
 }
 
+// autoRefreshMsg is the tick that triggers a background refresh of the open project
+type autoRefreshMsg struct {
+	seq     int
+	project models.Project
+}
+
+// projectItemsRefreshedMsg carries the result of a background refresh
+type projectItemsRefreshedMsg struct {
+	seq     int
+	project models.Project
+	items   []models.ProjectItem
+	err     error
+}
+
+// searchUsersCmd signals a search for assignee suggestions
 func searchUsersCmd(query string, owner string, isOrgProject bool) tea.Cmd {
 	return func() tea.Msg {
-		client, err := api.NewClient()
-		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("failed to create API client: %w", err)}
-		}
-		
+		return SearchUsersMsg{Query: query, Owner: owner, IsOrgProject: isOrgProject}
+	}
+}
+
+func searchUsers(ctx context.Context, client api.Interface, msg SearchUsersMsg) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
 		var users []string
-		if isOrgProject {
+		var err error
+		if msg.IsOrgProject {
 			// For org projects, search only org members
-			users, err = client.SearchOrgMembers(owner, query, 5)
+			users, err = client.SearchOrgMembers(ctx, msg.Owner, msg.Query, 5)
 		} else {
 			// For personal projects, search all users
-			users, err = client.SearchUsers(query, 5)
+			users, err = client.SearchUsers(ctx, msg.Query, 5)
 		}
 		
 		if err != nil {
@@ -880,5 +2143,5 @@ func searchUsersCmd(query string, owner string, isOrgProject bool) tea.Cmd {
 		}
 		
 		return UserSuggestionsMsg{Users: users}
-	}
+	})
 }
@@ -0,0 +1,391 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+	"github.com/thomaskoefod/githubProjectTUI/internal/outbox"
+)
+
+// errOffline is what the client returns when GitHub can't be reached
+var errOffline = apierrors.RetryableError("dial tcp: connection refused", nil)
+
+// openOutbox opens an empty outbox in a temporary config directory
+func openOutbox(t *testing.T) *outbox.Outbox {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	box, err := outbox.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return box
+}
+
+// newBoard returns a fake client with octocat's Roadmap project and api
+// repository, which most tests put their items in
+func newBoard() (*fake.Client, models.Project, models.Repository) {
+	c := fake.New("octocat")
+	return c, c.AddProject("octocat", "Roadmap"), c.AddRepository("octocat", "api")
+}
+
+// findItem returns the project's item with the ID, failing the test if it's gone
+func findItem(t *testing.T, c *fake.Client, projectID, itemID string) models.ProjectItem {
+	t.Helper()
+	for _, item := range c.Items(projectID) {
+		if item.ID == itemID {
+			return item
+		}
+	}
+	t.Fatalf("item %s not in project", itemID)
+	return models.ProjectItem{}
+}
+
+func TestSaveAndConvertCreatesDraft(t *testing.T) {
+	c := fake.New("octocat")
+	c.AddUser("hubot")
+	p := c.AddProject("octocat", "Roadmap")
+
+	msg := saveAndConvert(context.Background(), c, "octocat", true, SaveAndConvertMsg{
+		Project:   p,
+		Title:     "Write docs",
+		Body:      "For the API",
+		Assignees: []string{"hubot"},
+		IsNewItem: true,
+	})()
+
+	saved, ok := msg.(ItemSavedAndReadyToConvertMsg)
+	if !ok {
+		t.Fatalf("got %#v, want ItemSavedAndReadyToConvertMsg", msg)
+	}
+	item := findItem(t, c, p.ID, saved.Item.ID)
+	if item.Type != "DraftIssue" || item.Title != "Write docs" || item.Body != "For the API" {
+		t.Errorf("saved item = %+v", item)
+	}
+	if len(item.Assignees) != 1 || item.Assignees[0] != "hubot" {
+		t.Errorf("assignees = %v, want [hubot]", item.Assignees)
+	}
+}
+
+func TestSaveAndConvertUpdatesDraft(t *testing.T) {
+	c := fake.New("octocat")
+	p := c.AddProject("octocat", "Roadmap")
+	draft := c.AddDraft(p.ID, "Write docs", "")
+
+	msg := saveAndConvert(context.Background(), c, "octocat", true, SaveAndConvertMsg{
+		Project: p,
+		Item:    &draft,
+		Title:   "Write the docs",
+		Body:    "For the API",
+		Base:    models.ItemContent{Title: draft.Title, UpdatedAt: draft.UpdatedAt},
+	})()
+
+	if _, ok := msg.(ItemSavedAndReadyToConvertMsg); !ok {
+		t.Fatalf("got %#v, want ItemSavedAndReadyToConvertMsg", msg)
+	}
+	if item := findItem(t, c, p.ID, draft.ID); item.Title != "Write the docs" || item.Body != "For the API" {
+		t.Errorf("saved item = %+v", item)
+	}
+}
+
+func TestSaveAndConvertStopsOnConflict(t *testing.T) {
+	c := fake.New("octocat")
+	p := c.AddProject("octocat", "Roadmap")
+	draft := c.AddDraft(p.ID, "Write docs", "")
+	c.Edit(draft.ContentID, "Write docs", "Their body")
+
+	msg := saveAndConvert(context.Background(), c, "octocat", true, SaveAndConvertMsg{
+		Project: p,
+		Item:    &draft,
+		Title:   "Write docs",
+		Body:    "My body",
+		Base:    models.ItemContent{Title: draft.Title, UpdatedAt: draft.UpdatedAt},
+	})()
+
+	if _, ok := msg.(ItemConflictMsg); !ok {
+		t.Fatalf("got %#v, want ItemConflictMsg", msg)
+	}
+	if item := findItem(t, c, p.ID, draft.ID); item.Body != "Their body" {
+		t.Errorf("body = %q, their change was overwritten", item.Body)
+	}
+}
+
+func TestConvertDraft(t *testing.T) {
+	c, p, r := newBoard()
+	draft := c.AddDraft(p.ID, "Write docs", "For the API")
+
+	msg := convertDraft(context.Background(), c, ConvertDraftMsg{Project: p, Item: draft, Repository: r})()
+
+	if converted, ok := msg.(DraftConvertedMsg); !ok || converted.Project.ID != p.ID {
+		t.Fatalf("got %#v, want DraftConvertedMsg for the project", msg)
+	}
+	item := findItem(t, c, p.ID, draft.ID)
+	if item.Type != "Issue" || item.Number != 1 || item.RepositoryID != r.ID || item.Title != "Write docs" {
+		t.Errorf("converted item = %+v", item)
+	}
+}
+
+func TestConvertDraftFailure(t *testing.T) {
+	c, p, r := newBoard()
+	draft := c.AddDraft(p.ID, "Write docs", "")
+	c.FailNext("ConvertDraftIssueToIssue", apierrors.PermissionError("Resource not accessible", nil))
+
+	msg := convertDraft(context.Background(), c, ConvertDraftMsg{Project: p, Item: draft, Repository: r})()
+
+	errMsg, ok := msg.(ErrorMsg)
+	if !ok {
+		t.Fatalf("got %#v, want ErrorMsg", msg)
+	}
+	var apiErr *apierrors.APIError
+	if !errors.As(errMsg.Err, &apiErr) || apiErr.Type != apierrors.ErrorTypePermission {
+		t.Errorf("error = %v, want a permission error", errMsg.Err)
+	}
+	if item := findItem(t, c, p.ID, draft.ID); item.Type != "DraftIssue" {
+		t.Errorf("type = %s, want the draft left as is", item.Type)
+	}
+}
+
+func TestDeleteItem(t *testing.T) {
+	c := fake.New("octocat")
+	p := c.AddProject("octocat", "Roadmap")
+	draft := c.AddDraft(p.ID, "Write docs", "")
+	keep := c.AddDraft(p.ID, "Fix bug", "")
+	box := openOutbox(t)
+
+	msg := deleteItem(context.Background(), c, box, DeleteItemMsg{Project: p, Item: draft})()
+
+	if deleted, ok := msg.(ItemDeletedMsg); !ok || deleted.Project.ID != p.ID {
+		t.Fatalf("got %#v, want ItemDeletedMsg for the project", msg)
+	}
+	if items := c.Items(p.ID); len(items) != 1 || items[0].ID != keep.ID {
+		t.Errorf("items = %+v, want only %s left", items, keep.ID)
+	}
+	if pending := box.Pending(); len(pending) != 0 {
+		t.Errorf("queued %+v, want nothing", pending)
+	}
+}
+
+func TestDeleteItemQueuesWhenOffline(t *testing.T) {
+	c := fake.New("octocat")
+	p := c.AddProject("octocat", "Roadmap")
+	draft := c.AddDraft(p.ID, "Write docs", "")
+	box := openOutbox(t)
+	c.FailNext("DeleteProjectItem", errOffline)
+
+	msg := deleteItem(context.Background(), c, box, DeleteItemMsg{Project: p, Item: draft})()
+
+	queued, ok := msg.(MutationQueuedMsg)
+	if !ok {
+		t.Fatalf("got %#v, want MutationQueuedMsg", msg)
+	}
+	pending := box.Pending()
+	if len(pending) != 1 || pending[0].ID != queued.Mutation.ID {
+		t.Fatalf("queued %+v, want the deletion", pending)
+	}
+	if m := pending[0]; m.Kind != outbox.KindDeleteItem || m.ProjectID != p.ID || m.ItemID != draft.ID {
+		t.Errorf("queued %+v", m)
+	}
+	// Nothing reached GitHub, the item is deleted when the outbox replays
+	findItem(t, c, p.ID, draft.ID)
+}
+
+func TestDeleteItemReportsOtherFailures(t *testing.T) {
+	c := fake.New("octocat")
+	p := c.AddProject("octocat", "Roadmap")
+	draft := c.AddDraft(p.ID, "Write docs", "")
+	box := openOutbox(t)
+	c.FailNext("DeleteProjectItem", apierrors.PermissionError("Resource not accessible", nil))
+
+	msg := deleteItem(context.Background(), c, box, DeleteItemMsg{Project: p, Item: draft})()
+
+	if _, ok := msg.(ErrorMsg); !ok {
+		t.Fatalf("got %#v, want ErrorMsg", msg)
+	}
+	if pending := box.Pending(); len(pending) != 0 {
+		t.Errorf("queued %+v, want nothing", pending)
+	}
+	findItem(t, c, p.ID, draft.ID)
+}
+
+func TestLoadItemDetailsFailure(t *testing.T) {
+	c, p, r := newBoard()
+	issue := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+	c.FailNext("GetItemDetails", errOffline)
+	m := NewItemDetailModel(p, issue)
+
+	msg := loadItemDetails(context.Background(), c, issue, false)().(cancellableMsg).msg
+	failed, ok := msg.(ItemDetailsFailedMsg)
+	if !ok {
+		t.Fatalf("got %#v, want ItemDetailsFailedMsg", msg)
+	}
+	m, _ = m.Update(failed)
+	if m.loadingDetails || m.detailsErr == nil {
+		t.Fatalf("loadingDetails = %v, detailsErr = %v, want the failure shown", m.loadingDetails, m.detailsErr)
+	}
+
+	// r loads the details again
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if !m.loadingDetails || cmd == nil {
+		t.Fatal("r didn't retry loading the details")
+	}
+	msg = loadItemDetails(context.Background(), c, issue, false)().(cancellableMsg).msg
+	if _, ok := msg.(ItemDetailsLoadedMsg); !ok {
+		t.Fatalf("got %#v, want ItemDetailsLoadedMsg", msg)
+	}
+	m, _ = m.Update(msg)
+	if m.loadingDetails || m.detailsErr != nil {
+		t.Errorf("loadingDetails = %v, detailsErr = %v, want the details shown", m.loadingDetails, m.detailsErr)
+	}
+}
+
+// update runs msg through the model, keeping the model for the next step
+func update(m *Model, msg tea.Msg) tea.Cmd {
+	next, cmd := m.Update(msg)
+	*m = next.(Model)
+	return cmd
+}
+
+func TestBackgroundLoadsRunAlongsideNavigationLoads(t *testing.T) {
+	c, p, r := newBoard()
+	issue := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+	m := NewModel(Options{Client: c})
+	m.loading = false
+	m.currentView = viewItemDetail
+	m.itemDetail = NewItemDetailModel(p, issue)
+
+	details := update(&m, LoadItemDetailsMsg{Item: issue})
+	timeline := update(&m, LoadTimelineMsg{Item: issue})
+
+	// The timeline page neither supersedes the details nor releases their load
+	page := timeline().(cancellableMsg)
+	update(&m, page)
+	if loaded := details().(cancellableMsg); loaded.ctx.Err() != nil {
+		t.Fatal("details load was cancelled by the timeline page")
+	}
+}
+
+func TestEscCancelsBackgroundLoads(t *testing.T) {
+	c, p, r := newBoard()
+	issue := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+	m := NewModel(Options{Client: c})
+	m.loading = false
+	m.currentView = viewItemDetail
+	m.itemDetail = NewItemDetailModel(p, issue)
+
+	cmds := []tea.Cmd{
+		update(&m, LoadTimelineMsg{Item: issue}),
+		update(&m, LoadRepositoryMetadataMsg{RepositoryID: r.ID}),
+		update(&m, SearchUsersMsg{Query: "hu", Owner: "octocat"}),
+		update(&m, SearchCollaboratorsMsg{Query: "hu", Owner: "octocat"}),
+	}
+	update(&m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.currentView != viewProjectDetail {
+		t.Fatalf("view = %v, want back on the project", m.currentView)
+	}
+	for _, cmd := range cmds {
+		if result := cmd().(cancellableMsg); result.ctx.Err() == nil {
+			t.Errorf("%T wasn't cancelled", result.msg)
+		}
+	}
+}
+
+func TestSaveItemUpdatesIssuesAndPullRequests(t *testing.T) {
+	for _, itemType := range []string{"Issue", "PullRequest"} {
+		t.Run(itemType, func(t *testing.T) {
+			ctx := context.Background()
+			c, p, r := newBoard()
+			hubot := c.AddUser("hubot")
+			c.AddUser("monalisa")
+			item := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+			if itemType == "PullRequest" {
+				item = c.AddPullRequest(p.ID, r.ID, "Crash on start", "")
+			}
+			if err := c.AddAssignees(ctx, item.ContentID, []string{hubot}); err != nil {
+				t.Fatal(err)
+			}
+			item = findItem(t, c, p.ID, item.ID)
+			before := len(c.Calls())
+
+			msg := saveItem(ctx, c, nil, SaveItemMsg{
+				Project:   p,
+				Item:      &item,
+				Title:     "Crash on launch",
+				Body:      "Steps to reproduce",
+				Assignees: []string{"monalisa"},
+			})()
+
+			if _, ok := msg.(ItemSavedMsg); !ok {
+				t.Fatalf("got %#v, want ItemSavedMsg", msg)
+			}
+			saved := findItem(t, c, p.ID, item.ID)
+			if saved.Title != "Crash on launch" || saved.Body != "Steps to reproduce" {
+				t.Errorf("saved item = %+v", saved)
+			}
+			if len(saved.Assignees) != 1 || saved.Assignees[0] != "monalisa" {
+				t.Errorf("assignees = %v, want [monalisa]", saved.Assignees)
+			}
+			calls := c.Calls()[before:]
+			for _, want := range []string{"Update" + itemType, "AddAssignees", "RemoveAssignees"} {
+				if !slices.Contains(calls, want) {
+					t.Errorf("calls = %v, want %s", calls, want)
+				}
+			}
+			if slices.Contains(calls, "UpdateDraftIssue") {
+				t.Errorf("calls = %v, saved as a draft", calls)
+			}
+		})
+	}
+}
+
+func TestAssigneeChanges(t *testing.T) {
+	tests := []struct {
+		name                string
+		previous, assignees []string
+		add, remove         []string
+	}{
+		{"unchanged", []string{"hubot", "monalisa"}, []string{"monalisa", "hubot"}, nil, nil},
+		{"case differs", []string{"Hubot"}, []string{"hubot"}, nil, nil},
+		{"added", []string{"hubot"}, []string{"hubot", "monalisa"}, []string{"monalisa"}, nil},
+		{"removed", []string{"hubot", "monalisa"}, []string{"monalisa"}, nil, []string{"hubot"}},
+		{"replaced", []string{"hubot"}, []string{"monalisa"}, []string{"monalisa"}, []string{"hubot"}},
+		{"cleared", []string{"hubot"}, nil, nil, []string{"hubot"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, remove := assigneeChanges(tt.previous, tt.assignees)
+			if !slices.Equal(add, tt.add) || !slices.Equal(remove, tt.remove) {
+				t.Errorf("assigneeChanges(%v, %v) = %v, %v, want %v, %v", tt.previous, tt.assignees, add, remove, tt.add, tt.remove)
+			}
+		})
+	}
+}
+
+func TestSaveItemLeavesUnchangedAssignees(t *testing.T) {
+	ctx := context.Background()
+	c, p, r := newBoard()
+	hubot := c.AddUser("hubot")
+	issue := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+	if err := c.AddAssignees(ctx, issue.ContentID, []string{hubot}); err != nil {
+		t.Fatal(err)
+	}
+	issue = findItem(t, c, p.ID, issue.ID)
+	before := len(c.Calls())
+
+	msg := saveItem(ctx, c, nil, SaveItemMsg{Project: p, Item: &issue, Title: "Crash on launch", Assignees: []string{"Hubot"}})()
+
+	if _, ok := msg.(ItemSavedMsg); !ok {
+		t.Fatalf("got %#v, want ItemSavedMsg", msg)
+	}
+	if calls := c.Calls()[before:]; slices.Contains(calls, "AddAssignees") || slices.Contains(calls, "RemoveAssignees") {
+		t.Errorf("calls = %v, want the assignees left alone", calls)
+	}
+	if saved := findItem(t, c, p.ID, issue.ID); len(saved.Assignees) != 1 || saved.Assignees[0] != "hubot" {
+		t.Errorf("assignees = %v, want [hubot]", saved.Assignees)
+	}
+}
@@ -0,0 +1,312 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+	"github.com/thomaskoefod/githubProjectTUI/internal/outbox"
+)
+
+// outboxReplayInterval is how often queued changes are retried
+const outboxReplayInterval = 30 * time.Second
+
+var (
+	outboxTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#7D56F4")).
+				MarginLeft(2).
+				MarginTop(1)
+
+	outboxLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#888888")).
+				MarginLeft(2)
+
+	outboxRowStyle = lipgloss.NewStyle().
+			MarginLeft(4)
+
+	outboxSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("229")).
+				Background(lipgloss.Color("57")).
+				MarginLeft(2)
+
+	outboxConflictStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFA500")).
+				MarginLeft(6)
+
+	outboxHelpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			MarginLeft(2).
+			MarginTop(1)
+)
+
+// OutboxModel lists changes waiting to be sent to GitHub and lets the user
+// resolve the ones held back by a conflict
+type OutboxModel struct {
+	entries []outbox.Mutation
+	cursor  int
+	width   int
+	height  int
+}
+
+func NewOutboxModel(entries []outbox.Mutation) OutboxModel {
+	return OutboxModel{entries: entries}
+}
+
+// setEntries refreshes the list, keeping the cursor in range
+func (m *OutboxModel) setEntries(entries []outbox.Mutation) {
+	m.entries = entries
+	if m.cursor >= len(entries) {
+		m.cursor = len(entries) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m OutboxModel) Update(msg tea.Msg) (OutboxModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+		case "x":
+			if m.cursor < len(m.entries) {
+				return m, DiscardMutationCmd(m.entries[m.cursor].ID)
+			}
+		case "f":
+			if m.cursor < len(m.entries) && m.entries[m.cursor].Conflict != "" {
+				return m, ForceMutationCmd(m.entries[m.cursor].ID)
+			}
+		case "r":
+			return m, ReplayOutboxCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m OutboxModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(outboxTitleStyle.Render("Pending Changes"))
+	b.WriteString("\n")
+	b.WriteString(outboxLabelStyle.Render("Changes made while GitHub was unreachable, sent in order when it is back"))
+	b.WriteString("\n\n")
+
+	if len(m.entries) == 0 {
+		b.WriteString(outboxLabelStyle.Render("Nothing waiting to sync"))
+		b.WriteString("\n")
+	}
+
+	for i, entry := range m.entries {
+		status := "⏳"
+		if entry.Conflict != "" {
+			status = "⚠"
+		}
+		row := fmt.Sprintf("%s %s • queued %s", status, entry.Describe(), formatTime(entry.QueuedAt))
+		if entry.Attempts > 0 {
+			row += fmt.Sprintf(" • %d attempts", entry.Attempts)
+		}
+		if i == m.cursor {
+			b.WriteString(outboxSelectedStyle.Render("▸ " + row))
+		} else {
+			b.WriteString(outboxRowStyle.Render(row))
+		}
+		b.WriteString("\n")
+		if entry.Conflict != "" {
+			b.WriteString(outboxConflictStyle.Render("Held back: " + entry.Conflict))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(outboxHelpStyle.Render("↑/↓: navigate • r: sync now • f: overwrite despite conflict • x: discard change • esc: back"))
+
+	return b.String()
+}
+
+// isOffline reports whether err means GitHub could not be reached, as opposed
+// to GitHub rejecting the request
+func isOffline(err error) bool {
+	var apiErr *apierrors.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Type == apierrors.ErrorTypeRetryable && apiErr.HTTPStatus == 0
+}
+
+// queueIfOffline stores a mutation that failed because GitHub is unreachable.
+// It returns false when the error should be reported as usual instead.
+func queueIfOffline(box *outbox.Outbox, err error, project models.Project, mutation outbox.Mutation) (tea.Msg, bool) {
+	if box == nil || !isOffline(err) {
+		return nil, false
+	}
+
+	mutation.ProjectID = project.ID
+	queued, queueErr := box.Enqueue(mutation)
+	if queueErr != nil {
+		return ErrorMsg{Err: fmt.Errorf("offline, and failed to queue the change: %w", queueErr)}, true
+	}
+	return MutationQueuedMsg{Project: project, Mutation: queued}, true
+}
+
+// applyMutation sends a queued mutation to GitHub
+func applyMutation(ctx context.Context, client api.Interface, mutation *outbox.Mutation) error {
+	switch mutation.Kind {
+	case outbox.KindCreateDraft:
+		assigneeIDs, err := userNodeIDs(ctx, client, mutation.Assignees)
+		if err != nil {
+			return err
+		}
+
+		item, err := client.CreateDraftIssue(ctx, models.CreateItemInput{
+			ProjectID: mutation.ProjectID,
+			Title:     mutation.Title,
+			Body:      mutation.Body,
+		})
+		if err != nil {
+			return err
+		}
+		if len(assigneeIDs) == 0 {
+			return nil
+		}
+		// The draft exists now, so a failed assignment must not create it again
+		mutation.Kind = outbox.KindUpdateDraft
+		mutation.ItemID = item.ID
+		mutation.ContentID = item.ContentID
+		mutation.Force = true
+		_, err = client.UpdateDraftIssue(ctx, item.ContentID, mutation.Title, mutation.Body, assigneeIDs)
+		return err
+
+	case outbox.KindUpdateDraft:
+		if !mutation.Force && !mutation.BaseUpdatedAt.IsZero() {
+			updatedAt, err := client.GetItemUpdatedAt(ctx, mutation.ContentID)
+			if err != nil {
+				return err
+			}
+			if updatedAt.After(mutation.BaseUpdatedAt) {
+				return apierrors.ConflictError(fmt.Sprintf("changed on GitHub at %s, after you edited it", updatedAt.Local().Format("Jan 2 15:04")), nil)
+			}
+		}
+		err := updateContent(ctx, client, mutation.ContentType, mutation.ContentID, mutation.Title, mutation.Body, mutation.PreviousAssignees, mutation.Assignees)
+		if err != nil {
+			return err
+		}
+		return updateLabelsAndMilestone(ctx, client, mutation.ContentType, mutation.ContentID, mutation.PreviousLabelIDs, mutation.LabelIDs, mutation.PreviousMilestoneID, mutation.MilestoneID)
+
+	case outbox.KindDeleteItem:
+		return client.DeleteProjectItem(ctx, mutation.ProjectID, mutation.ItemID)
+
+	default:
+		return fmt.Errorf("unknown queued change %q", mutation.Kind)
+	}
+}
+
+// replayOutbox sends queued mutations in the background. Automatic replays
+// yield to the user's own requests as the rate limit budget runs low.
+func replayOutbox(client api.Interface, box *outbox.Outbox, automatic bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if automatic {
+			ctx = api.WithPriority(ctx, api.PriorityBackground)
+		}
+		sent, err := box.Replay(ctx, func(ctx context.Context, mutation *outbox.Mutation) error {
+			return applyMutation(ctx, client, mutation)
+		})
+		return OutboxReplayedMsg{Sent: sent, Err: err}
+	}
+}
+
+// outboxTick schedules the next automatic replay
+func outboxTick() tea.Cmd {
+	return tea.Tick(outboxReplayInterval, func(time.Time) tea.Msg {
+		return outboxTickMsg{}
+	})
+}
+
+// hasSendable reports whether any queued mutation is not held by a conflict
+func hasSendable(entries []outbox.Mutation) bool {
+	for _, entry := range entries {
+		if entry.Conflict == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ShowOutboxCmd signals opening the pending changes screen
+func ShowOutboxCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ShowOutboxMsg{}
+	}
+}
+
+// DiscardMutationCmd signals dropping a queued change
+func DiscardMutationCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return DiscardMutationMsg{ID: id}
+	}
+}
+
+// ForceMutationCmd signals sending a conflicted change anyway
+func ForceMutationCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return ForceMutationMsg{ID: id}
+	}
+}
+
+// ReplayOutboxCmd signals sending queued changes now
+func ReplayOutboxCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ReplayOutboxMsg{}
+	}
+}
+
+// ShowOutboxMsg is sent to open the pending changes screen
+type ShowOutboxMsg struct{}
+
+// DiscardMutationMsg is sent to drop a queued change
+type DiscardMutationMsg struct {
+	ID string
+}
+
+// ForceMutationMsg is sent to send a conflicted change anyway
+type ForceMutationMsg struct {
+	ID string
+}
+
+// ReplayOutboxMsg is sent to send queued changes now
+type ReplayOutboxMsg struct{}
+
+// OutboxReplayedMsg is sent when a replay of queued changes finishes
+type OutboxReplayedMsg struct {
+	Sent int
+	Err  error // Set when replay stopped early, e.g. still offline
+}
+
+// MutationQueuedMsg is sent when a change was queued because GitHub is unreachable
+type MutationQueuedMsg struct {
+	Project  models.Project
+	Mutation outbox.Mutation
+}
+
+// outboxTickMsg triggers an automatic replay
+type outboxTickMsg struct{}
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
 )
 
 var (
@@ -37,13 +38,16 @@ type ProjectCreatorModel struct {
 	titleInput    textinput.Model
 	descInput     textarea.Model
 	publicToggle  bool
+	sources       []models.Project // Projects that can be copied, templates first
+	sourceIndex   int              // -1 = start from an empty project
+	includeDrafts bool             // Copy draft issues along with the project
 	focusIndex    int
 	width         int
 	height        int
 	validationErr string
 }
 
-func NewProjectCreatorModel(ownerLogin string, isUserOwner bool) ProjectCreatorModel {
+func NewProjectCreatorModel(ownerLogin string, isUserOwner bool, projects []models.Project) ProjectCreatorModel {
 	ti := textinput.New()
 	ti.Placeholder = "Project title"
 	ti.Focus()
@@ -56,12 +60,27 @@ func NewProjectCreatorModel(ownerLogin string, isUserOwner bool) ProjectCreatorM
 	ta.SetWidth(80)
 	ta.SetHeight(5)
 
+	// Offer templates before regular projects as copy sources
+	sources := make([]models.Project, 0, len(projects))
+	for _, p := range projects {
+		if p.Template {
+			sources = append(sources, p)
+		}
+	}
+	for _, p := range projects {
+		if !p.Template {
+			sources = append(sources, p)
+		}
+	}
+
 	return ProjectCreatorModel{
 		ownerLogin:   ownerLogin,
 		isUserOwner:  isUserOwner,
 		titleInput:   ti,
 		descInput:    ta,
 		publicToggle: false,
+		sources:      sources,
+		sourceIndex:  -1,
 		focusIndex:   0,
 	}
 }
@@ -112,10 +131,11 @@ func (m ProjectCreatorModel) Update(msg tea.Msg) (ProjectCreatorModel, tea.Cmd)
 				m.focusIndex--
 			}
 
-			if m.focusIndex > 2 {
+			lastField := m.lastFieldIndex()
+			if m.focusIndex > lastField {
 				m.focusIndex = 0
 			} else if m.focusIndex < 0 {
-				m.focusIndex = 2
+				m.focusIndex = lastField
 			}
 
 			m.updateFocus()
@@ -127,6 +147,25 @@ func (m ProjectCreatorModel) Update(msg tea.Msg) (ProjectCreatorModel, tea.Cmd)
 				m.publicToggle = !m.publicToggle
 				return m, nil
 			}
+			if m.focusIndex == 3 {
+				m.cycleSource(1)
+				return m, nil
+			}
+			if m.focusIndex == 4 {
+				m.includeDrafts = !m.includeDrafts
+				return m, nil
+			}
+
+		case "left", "right":
+			// Cycle the project to start from
+			if m.focusIndex == 3 {
+				if msg.String() == "right" {
+					m.cycleSource(1)
+				} else {
+					m.cycleSource(-1)
+				}
+				return m, nil
+			}
 		}
 	}
 
@@ -143,6 +182,21 @@ func (m ProjectCreatorModel) Update(msg tea.Msg) (ProjectCreatorModel, tea.Cmd)
 	return m, tea.Batch(cmds...)
 }
 
+// lastFieldIndex returns the index of the last focusable field.
+// The include-drafts toggle only exists when copying a project.
+func (m ProjectCreatorModel) lastFieldIndex() int {
+	if m.sourceIndex >= 0 {
+		return 4
+	}
+	return 3
+}
+
+// cycleSource moves the copy source by delta, wrapping through "empty project"
+func (m *ProjectCreatorModel) cycleSource(delta int) {
+	count := len(m.sources) + 1
+	m.sourceIndex = ((m.sourceIndex+1+delta)%count+count)%count - 1
+}
+
 func (m *ProjectCreatorModel) updateFocus() {
 	switch m.focusIndex {
 	case 0:
@@ -151,7 +205,7 @@ func (m *ProjectCreatorModel) updateFocus() {
 	case 1:
 		m.titleInput.Blur()
 		m.descInput.Focus()
-	case 2:
+	default:
 		m.titleInput.Blur()
 		m.descInput.Blur()
 	}
@@ -204,6 +258,34 @@ func (m ProjectCreatorModel) View() string {
 	b.WriteString(projectCreatorLabelStyle.Render(focusIndicator + " Visibility: " + checkbox + " " + visibility))
 	b.WriteString("\n")
 
+	// Copy source selector
+	focusIndicator = " "
+	if m.focusIndex == 3 {
+		focusIndicator = "▶"
+	}
+	source := "Empty project"
+	if m.sourceIndex >= 0 {
+		source = "Copy of " + m.sources[m.sourceIndex].Title
+		if m.sources[m.sourceIndex].Template {
+			source += " (template)"
+		}
+	}
+	b.WriteString(projectCreatorLabelStyle.Render(focusIndicator + " Start from: ◂ " + source + " ▸"))
+	b.WriteString("\n")
+
+	if m.sourceIndex >= 0 {
+		focusIndicator = " "
+		if m.focusIndex == 4 {
+			focusIndicator = "▶"
+		}
+		checkbox = "[ ]"
+		if m.includeDrafts {
+			checkbox = "[x]"
+		}
+		b.WriteString(projectCreatorLabelStyle.Render(focusIndicator + " Include draft issues: " + checkbox))
+		b.WriteString("\n")
+	}
+
 	// Validation error
 	if m.validationErr != "" {
 		b.WriteString("\n")
@@ -213,28 +295,37 @@ func (m ProjectCreatorModel) View() string {
 
 	// Help
 	b.WriteString("\n")
-	b.WriteString(projectCreatorHelpStyle.Render("tab: next field • space: toggle visibility • ctrl+s: create • esc: cancel"))
+	b.WriteString(projectCreatorHelpStyle.Render("tab: next field • space: toggle • ←/→: change source • ctrl+s: create • esc: cancel"))
 
 	return b.String()
 }
 
 func (m ProjectCreatorModel) createProjectCmd() tea.Cmd {
+	var source *models.Project
+	if m.sourceIndex >= 0 {
+		source = &m.sources[m.sourceIndex]
+	}
+
 	return func() tea.Msg {
 		return CreateProjectMsg{
-			OwnerLogin:  m.ownerLogin,
-			IsUserOwner: m.isUserOwner,
-			Title:       m.titleInput.Value(),
-			Description: m.descInput.Value(),
-			Public:      m.publicToggle,
+			OwnerLogin:    m.ownerLogin,
+			IsUserOwner:   m.isUserOwner,
+			Title:         m.titleInput.Value(),
+			Description:   m.descInput.Value(),
+			Public:        m.publicToggle,
+			SourceProject: source,
+			IncludeDrafts: m.includeDrafts,
 		}
 	}
 }
 
 // CreateProjectMsg is sent when creating a project
 type CreateProjectMsg struct {
-	OwnerLogin  string
-	IsUserOwner bool
-	Title       string
-	Description string
-	Public      bool
+	OwnerLogin    string
+	IsUserOwner   bool
+	Title         string
+	Description   string
+	Public        bool
+	SourceProject *models.Project // Optional: project to copy fields and views from
+	IncludeDrafts bool            // Copy draft issues from SourceProject
 }
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+	"github.com/thomaskoefod/githubProjectTUI/internal/outbox"
 )
 
 var baseStyle = lipgloss.NewStyle().
@@ -16,58 +17,33 @@ var baseStyle = lipgloss.NewStyle().
 
 // ProjectDetailModel represents the project detail view
 type ProjectDetailModel struct {
-	project models.Project
-	items   []models.ProjectItem
-	table   table.Model
-	width   int
-	height  int
+	project    models.Project
+	items      []models.ProjectItem
+	fields     []models.ProjectField
+	changed    map[string]bool // Items that changed when cached data was refreshed
+	pending    map[string]bool // Items with changes waiting to sync, true if held by a conflict
+	queued     int             // Changes to this project waiting to sync
+	table      table.Model
+	statusPane StatusUpdatesModel
+	showStatus bool            // Status update pane replaces the table while open
+	showLabels bool            // Show the Labels column
+	bulk       bool            // Bulk mode, where actions apply to the selected items
+	selected   map[string]bool // Items selected in bulk mode
+	bulkNote   string          // What the last bulk action skipped
+	stateForm  *issueStateForm // Close or reopen form, replaces the table while open
+	tree       bool            // Tree mode, where sub-issues are shown under their parents
+	collapsed  map[string]bool // Items whose sub-issues are hidden in tree mode
+	parents    map[string]bool // Items with sub-issues in the project, in tree mode
+	readyOnly  bool            // Only show open items that aren't blocked
+	visible    []models.ProjectItem // Items in table row order
+	width      int
+	height     int
 }
 
 func NewProjectDetailModel(project models.Project, items []models.ProjectItem) ProjectDetailModel {
-	columns := []table.Column{
-		{Title: "Type", Width: 12},
-		{Title: "Title", Width: 40},
-		{Title: "Assignees", Width: 20},
-		{Title: "Status", Width: 12},
-		{Title: "Number", Width: 10},
-	}
+	columns := itemColumns(40, false)
 
-	rows := make([]table.Row, len(items))
-	for i, item := range items {
-		itemType := item.Type
-		if itemType == "" {
-			itemType = "Unknown"
-		}
-		
-		status := item.State
-		if status == "" {
-			status = "-"
-		}
-
-		number := "-"
-		if item.Number > 0 {
-			number = fmt.Sprintf("#%d", item.Number)
-		}
-
-		// Format assignees as comma-separated list with @ prefix
-		assignees := "-"
-		if len(item.Assignees) > 0 {
-			assigneeList := make([]string, len(item.Assignees))
-			for j, a := range item.Assignees {
-				assigneeList[j] = "@" + a
-			}
-			assignees = strings.Join(assigneeList, ", ")
-			assignees = truncate(assignees, 20)
-		}
-
-		rows[i] = table.Row{
-			itemType,
-			truncate(item.Title, 40),
-			assignees,
-			status,
-			number,
-		}
-	}
+	rows := itemRows(items, nil, nil, false)
 
 	t := table.New(
 		table.WithColumns(columns),
@@ -89,9 +65,11 @@ func NewProjectDetailModel(project models.Project, items []models.ProjectItem) P
 	t.SetStyles(s)
 
 	return ProjectDetailModel{
-		project: project,
-		items:   items,
-		table:   t,
+		project:    project,
+		items:      items,
+		visible:    items,
+		table:      t,
+		statusPane: NewStatusUpdatesModel(project), // Resized along with the table even while closed
 	}
 }
 
@@ -106,6 +84,7 @@ func (m ProjectDetailModel) Update(msg tea.Msg) (ProjectDetailModel, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.statusPane, _ = m.statusPane.Update(msg)
 		// Adjust table height based on window size
 		// Leave room for header (6 lines) and footer (2 lines)
 		tableHeight := msg.Height - 8
@@ -114,50 +93,132 @@ func (m ProjectDetailModel) Update(msg tea.Msg) (ProjectDetailModel, tea.Cmd) {
 		}
 		m.table.SetHeight(tableHeight)
 		
-		// Adjust column widths based on terminal width
-		availableWidth := msg.Width - 10
-		if availableWidth < 60 {
-			availableWidth = 60
-		}
-		
-		// Calculate column widths proportionally
-		typeWidth := 12
-		numberWidth := 10
-		statusWidth := 12
-		assigneesWidth := 20
-		titleWidth := availableWidth - typeWidth - numberWidth - statusWidth - assigneesWidth - 10
-		if titleWidth < 20 {
-			titleWidth = 20
+		m.layoutColumns()
+		return m, nil
+
+	case StatusUpdatesLoadedMsg, StatusUpdatesFailedMsg:
+		m.statusPane, cmd = m.statusPane.Update(msg)
+		return m, cmd
+
+	case IssueStateChangedMsg:
+		for _, item := range msg.Items {
+			m.replaceItem(item)
 		}
-		
-		m.table.SetColumns([]table.Column{
-			{Title: "Type", Width: typeWidth},
-			{Title: "Title", Width: titleWidth},
-			{Title: "Assignees", Width: assigneesWidth},
-			{Title: "Status", Width: statusWidth},
-			{Title: "Number", Width: numberWidth},
-		})
+		m.selected = nil
+		m.refreshRows()
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.stateForm != nil {
+			return m.updateStateForm(msg)
+		}
+		if m.showStatus {
+			// Close the pane unless the composer wants the key
+			if !m.statusPane.composing && (msg.String() == "s" || msg.String() == "esc") {
+				m.showStatus = false
+				return m, nil
+			}
+			m.statusPane, cmd = m.statusPane.Update(msg)
+			return m, cmd
+		}
+
 		switch msg.String() {
+		case "s":
+			// Open status updates pane
+			m.showStatus = true
+			m.statusPane = NewStatusUpdatesModel(m.project)
+			m.statusPane, _ = m.statusPane.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+			return m, LoadStatusUpdatesCmd(m.project)
 		case "n":
 			// Create new item
 			return m, CreateItemCmd(m.project)
 		case "e":
 			// Edit selected item
-			if m.table.Cursor() < len(m.items) {
-				return m, EditItemCmd(m.items[m.table.Cursor()])
+			if item, ok := m.cursorItem(); ok {
+				return m, EditItemCmd(item)
 			}
 		case "d":
 			// Delete selected item
-			if m.table.Cursor() < len(m.items) {
-				return m, DeleteItemCmd(m.project, m.items[m.table.Cursor()])
+			if item, ok := m.cursorItem(); ok {
+				return m, DeleteItemCmd(m.project, item)
+			}
+		case "C":
+			// Manage collaborators
+			return m, ManageCollaboratorsCmd(m.project)
+		case "esc":
+			// Leave bulk mode before leaving the project
+			if m.bulk {
+				m.bulk = false
+				m.selected = nil
+				m.bulkNote = ""
+				m.refreshRows()
+				return m, nil
+			}
+		case "v":
+			// Toggle bulk mode
+			m.bulk = !m.bulk
+			m.selected = nil
+			m.bulkNote = ""
+			m.refreshRows()
+			return m, nil
+		case " ":
+			// Select or unselect the item under the cursor and move on
+			if item, ok := m.cursorItem(); ok && m.bulk {
+				m.toggleSelected(item.ID)
+				m.table.MoveDown(1)
+				return m, nil
+			}
+		case "a":
+			// Select all shown items, or none when all are selected
+			if m.bulk {
+				all := len(m.selected) == len(m.visible)
+				m.selected = nil
+				if !all {
+					for _, item := range m.visible {
+						m.toggleSelected(item.ID)
+					}
+				}
+				m.refreshRows()
+				return m, nil
+			}
+		case "x":
+			// Close issues
+			return m.openStateForm(true)
+		case "X":
+			// Reopen issues
+			return m.openStateForm(false)
+		case "t":
+			// Toggle tree mode
+			m.tree = !m.tree
+			m.refreshRows()
+			return m, nil
+		case "left", "h":
+			// Collapse the item's sub-issues, or the parent it's under
+			if item, ok := m.cursorItem(); ok && m.tree {
+				m.collapse(item)
+				return m, nil
 			}
+		case "right", "l":
+			// Expand the item's sub-issues
+			if item, ok := m.cursorItem(); ok && m.tree && m.collapsed[item.ID] {
+				delete(m.collapsed, item.ID)
+				m.refreshRows()
+				return m, nil
+			}
+		case "R":
+			// Toggle showing only ready work
+			m.readyOnly = !m.readyOnly
+			m.refreshRows()
+			return m, nil
+		case "L":
+			// Toggle the Labels column
+			m.showLabels = !m.showLabels
+			m.layoutColumns()
+			return m, nil
 		case "enter":
 			// View item details
-			if m.table.Cursor() < len(m.items) {
-				return m, ViewItemCmd(m.project, m.items[m.table.Cursor()])
+			if item, ok := m.cursorItem(); ok {
+				return m, ViewItemCmd(m.project, item)
 			}
 		}
 	}
@@ -204,18 +265,381 @@ func (m ProjectDetailModel) View() string {
 	
 	b.WriteString(infoStyle.Render(fmt.Sprintf("%s • %s • %d items", 
 		status, visibility, len(m.items))))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+
+	if len(m.fields) > 0 {
+		names := make([]string, len(m.fields))
+		for i, field := range m.fields {
+			names[i] = field.Name
+		}
+		b.WriteString(infoStyle.Render("Fields: " + truncate(strings.Join(names, ", "), 80)))
+		b.WriteString("\n")
+	}
+	if m.queued > 0 {
+		b.WriteString(infoStyle.Render(fmt.Sprintf("⏳ %d changes waiting to sync • P: review", m.queued)))
+		b.WriteString("\n")
+	}
+	if len(m.changed) > 0 {
+		b.WriteString(infoStyle.Render(fmt.Sprintf("✦ %d items changed since last visit", len(m.changed))))
+		b.WriteString("\n")
+	}
+	if m.readyOnly {
+		b.WriteString(infoStyle.Render(fmt.Sprintf("Showing %d ready items, open and not blocked • R: show all", len(m.visible))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.stateForm != nil {
+		b.WriteString(m.stateForm.view())
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render(m.stateForm.helpText()))
+		return b.String()
+	}
+
+	if m.showStatus {
+		b.WriteString(titleStyle.Render("Status Updates"))
+		b.WriteString("\n")
+		b.WriteString(m.statusPane.View())
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render(m.statusPane.HelpText()))
+		return b.String()
+	}
 
 	// Items table
 	b.WriteString(m.table.View())
 	b.WriteString("\n\n")
 
 	// Help
-	b.WriteString(helpStyle.Render("enter: view • n: new item • e: edit • d: delete • esc: back • q: quit"))
+	if m.bulk {
+		if m.bulkNote != "" {
+			b.WriteString(infoStyle.Render(m.bulkNote))
+			b.WriteString("\n")
+		}
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Bulk mode, %d selected • space: select • a: select all • x: close issues • X: reopen issues • v: leave bulk mode", len(m.selected))))
+		return b.String()
+	}
+	if m.tree {
+		b.WriteString(helpStyle.Render("Tree mode • ←/→: collapse/expand sub-issues • t: leave tree mode • enter: view • n: new item • e: edit • d: delete • esc: back"))
+		return b.String()
+	}
+	b.WriteString(helpStyle.Render("enter: view • n: new item • e: edit • d: delete • x/X: close/reopen issue • v: bulk mode • t: tree mode • R: ready work • s: status updates • C: collaborators • L: labels column • esc: back • q: quit"))
 
 	return b.String()
 }
 
+func (m ProjectDetailModel) updateStateForm(msg tea.KeyMsg) (ProjectDetailModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.stateForm = nil
+		return m, nil
+	case "ctrl+s":
+		form, cmd := m.stateForm.submit()
+		if cmd != nil {
+			m.stateForm = nil
+			return m, cmd
+		}
+		m.stateForm = &form
+		return m, nil
+	}
+
+	form, cmd := m.stateForm.update(msg)
+	m.stateForm = &form
+	return m, cmd
+}
+
+// openStateForm opens the form to close or reopen the selected issues in bulk
+// mode, or the issue under the cursor. Items that aren't issues in the right
+// state are skipped.
+func (m ProjectDetailModel) openStateForm(close bool) (ProjectDetailModel, tea.Cmd) {
+	state := "CLOSED"
+	if close {
+		state = "OPEN"
+	}
+
+	var targets []models.ProjectItem
+	if m.bulk && len(m.selected) > 0 {
+		targets = m.selectedItems()
+	} else if item, ok := m.cursorItem(); ok {
+		targets = []models.ProjectItem{item}
+	}
+	issues := issuesInState(targets, state)
+
+	m.bulkNote = ""
+	if skipped := len(targets) - len(issues); skipped > 0 && m.bulk {
+		noun := "items"
+		if skipped == 1 {
+			noun = "item"
+		}
+		m.bulkNote = fmt.Sprintf("Skipped %d %s that aren't %s issues", skipped, noun, strings.ToLower(state))
+	}
+	if len(issues) == 0 {
+		return m, nil
+	}
+
+	form := newIssueStateForm(m.project, issues, close, m.width)
+	m.stateForm = &form
+	return m, nil
+}
+
+// cursorItem returns the item in the row under the cursor
+func (m ProjectDetailModel) cursorItem() (models.ProjectItem, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return models.ProjectItem{}, false
+	}
+	return m.visible[cursor], true
+}
+
+// collapse hides an item's sub-issues in tree mode. On an item without shown
+// sub-issues it collapses the parent instead and moves the cursor there.
+func (m *ProjectDetailModel) collapse(item models.ProjectItem) {
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	if m.parents[item.ID] && !m.collapsed[item.ID] {
+		m.collapsed[item.ID] = true
+		m.refreshRows()
+		return
+	}
+	if item.Parent == nil {
+		return
+	}
+	for i, parent := range m.visible {
+		if parent.ContentID == item.Parent.ID {
+			m.collapsed[parent.ID] = true
+			m.refreshRows()
+			m.table.SetCursor(i)
+			return
+		}
+	}
+}
+
+func (m *ProjectDetailModel) toggleSelected(id string) {
+	if m.selected == nil {
+		m.selected = make(map[string]bool)
+	}
+	if m.selected[id] {
+		delete(m.selected, id)
+	} else {
+		m.selected[id] = true
+	}
+	m.refreshRows()
+}
+
+// selectedItems returns the items selected in bulk mode, in table order
+func (m ProjectDetailModel) selectedItems() []models.ProjectItem {
+	var items []models.ProjectItem
+	for _, item := range m.items {
+		if m.selected[item.ID] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// layoutColumns sizes the table's columns to the window, giving the title
+// whatever the other columns leave
+func (m *ProjectDetailModel) layoutColumns() {
+	// Adjust column widths based on terminal width
+	availableWidth := m.width - 10
+	if availableWidth < 60 {
+		availableWidth = 60
+	}
+
+	// Calculate column widths proportionally
+	titleWidth := availableWidth - 12 - 10 - 12 - 20 - 10
+	if m.showLabels {
+		titleWidth -= labelsWidth
+	}
+	if titleWidth < 20 {
+		titleWidth = 20
+	}
+
+	// Rows must match the columns, so clear them while the columns change
+	m.table.SetRows(nil)
+	m.table.SetColumns(itemColumns(titleWidth, m.showLabels))
+	m.refreshRows()
+}
+
+// labelsWidth is the width of the optional Labels column
+const labelsWidth = 24
+
+// itemColumns returns the table columns, with the Labels column after the
+// assignees when shown
+func itemColumns(titleWidth int, showLabels bool) []table.Column {
+	columns := []table.Column{
+		{Title: "Type", Width: 12},
+		{Title: "Title", Width: titleWidth},
+		{Title: "Assignees", Width: 20},
+	}
+	if showLabels {
+		columns = append(columns, table.Column{Title: "Labels", Width: labelsWidth})
+	}
+	return append(columns,
+		table.Column{Title: "Status", Width: 12},
+		table.Column{Title: "Number", Width: 10},
+	)
+}
+
+// replaceItem swaps in an updated copy of an item, matched by ID
+func (m *ProjectDetailModel) replaceItem(item models.ProjectItem) {
+	for i := range m.items {
+		if m.items[i].ID == item.ID {
+			m.items[i] = item
+			return
+		}
+	}
+}
+
+// setItems swaps in a fresh list of items, keeping the cursor on the same item
+// where possible. With highlight set, items that are new or changed are marked.
+func (m *ProjectDetailModel) setItems(items []models.ProjectItem, highlight bool) {
+	var selectedID string
+	if item, ok := m.cursorItem(); ok {
+		selectedID = item.ID
+	}
+
+	if highlight {
+		m.changed = changedItems(m.items, items)
+	}
+	m.items = items
+	m.refreshRows()
+	for i, item := range m.visible {
+		if item.ID == selectedID {
+			m.table.SetCursor(i)
+			return
+		}
+	}
+	if m.table.Cursor() >= len(m.visible) && len(m.visible) > 0 {
+		m.table.SetCursor(len(m.visible) - 1)
+	}
+}
+
+// setPending marks the items that have changes waiting in the outbox
+func (m *ProjectDetailModel) setPending(pending []outbox.Mutation) {
+	m.pending = make(map[string]bool)
+	m.queued = 0
+	for _, mutation := range pending {
+		if mutation.ProjectID == m.project.ID {
+			m.queued++
+		}
+		if mutation.ItemID != "" {
+			m.pending[mutation.ItemID] = m.pending[mutation.ItemID] || mutation.Conflict != ""
+		}
+	}
+	m.refreshRows()
+}
+
+// refreshRows rebuilds the table rows with the current change markers
+func (m *ProjectDetailModel) refreshRows() {
+	marks := make(map[string]string)
+	for id := range m.changed {
+		marks[id] = "✦ "
+	}
+	for id, conflict := range m.pending {
+		marks[id] = "⏳ "
+		if conflict {
+			marks[id] = "⚠ "
+		}
+	}
+	for id := range m.selected {
+		marks[id] = "● "
+	}
+	items := m.items
+	if m.readyOnly {
+		items = readyWork(items)
+	}
+	if !m.tree {
+		m.visible = items
+		m.parents = nil
+		m.table.SetRows(itemRows(items, marks, nil, m.showLabels))
+		if m.table.Cursor() >= len(items) && len(items) > 0 {
+			m.table.SetCursor(len(items) - 1)
+		}
+		return
+	}
+
+	var depths []int
+	m.visible, depths, m.parents = treeOrder(items, m.collapsed)
+	m.table.SetRows(itemRows(m.visible, marks, treePrefixes(m.visible, depths, m.parents, m.collapsed), m.showLabels))
+	if m.table.Cursor() >= len(m.visible) && len(m.visible) > 0 {
+		m.table.SetCursor(len(m.visible) - 1)
+	}
+}
+
+// changedItems returns the IDs of items that are new or were updated since before
+func changedItems(before, after []models.ProjectItem) map[string]bool {
+	previous := make(map[string]models.ProjectItem, len(before))
+	for _, item := range before {
+		previous[item.ID] = item
+	}
+
+	changed := make(map[string]bool)
+	for _, item := range after {
+		old, ok := previous[item.ID]
+		if !ok || !old.UpdatedAt.Equal(item.UpdatedAt) || old.Title != item.Title || old.State != item.State {
+			changed[item.ID] = true
+		}
+	}
+	return changed
+}
+
+// itemRows builds the table rows for a list of items, prefixing the type with
+// any marker given for the item and the title with any prefix given for it.
+// Label names are included when the Labels column is shown.
+func itemRows(items []models.ProjectItem, marks, titlePrefixes map[string]string, showLabels bool) []table.Row {
+	rows := make([]table.Row, len(items))
+	for i, item := range items {
+		itemType := item.Type
+		if itemType == "" {
+			itemType = "Unknown"
+		}
+		itemType = marks[item.ID] + itemType
+		
+		status := rowStatus(item)
+		if status == "" {
+			status = "-"
+		}
+
+		number := "-"
+		if item.Number > 0 {
+			number = fmt.Sprintf("#%d", item.Number)
+		}
+
+		// Format assignees as comma-separated list with @ prefix
+		assignees := "-"
+		if len(item.Assignees) > 0 {
+			assigneeList := make([]string, len(item.Assignees))
+			for j, a := range item.Assignees {
+				assigneeList[j] = "@" + a
+			}
+			assignees = strings.Join(assigneeList, ", ")
+			assignees = truncate(assignees, 20)
+		}
+
+		// Issues with sub-issues show how many are done
+		progress := subIssuesProgress(item)
+		row := table.Row{
+			itemType,
+			truncate(titlePrefixes[item.ID]+item.Title, 40-len(progress)) + progress,
+			assignees,
+		}
+		if showLabels {
+			labels := "-"
+			if len(item.Labels) > 0 {
+				names := make([]string, len(item.Labels))
+				for j, label := range item.Labels {
+					names[j] = label.Name
+				}
+				labels = truncate(strings.Join(names, ", "), labelsWidth)
+			}
+			row = append(row, labels)
+		}
+		rows[i] = append(row, status, number)
+	}
+	return rows
+}
+
 func truncate(s string, max int) string {
 	if len(s) <= max {
 		return s
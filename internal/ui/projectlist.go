@@ -17,10 +17,16 @@ var (
 // projectItem implements list.Item for the project list
 type projectItem struct {
 	project models.Project
+	changed bool // Changed when the cached list was refreshed
 }
 
 func (i projectItem) FilterValue() string { return i.project.Title }
-func (i projectItem) Title() string       { return i.project.Title }
+func (i projectItem) Title() string {
+	if i.changed {
+		return "✦ " + i.project.Title
+	}
+	return i.project.Title
+}
 func (i projectItem) Description() string {
 	desc := i.project.ShortDescription
 	if desc == "" {
@@ -34,24 +40,24 @@ func (i projectItem) Description() string {
 	if i.project.Public {
 		visibility = "Public"
 	}
+	if i.project.Template {
+		return fmt.Sprintf("%s • %s • Template • %d items", status, visibility, i.project.ItemCount)
+	}
 	return fmt.Sprintf("%s • %s • %d items", status, visibility, i.project.ItemCount)
 }
 
 // ProjectListModel represents the project list view
 type ProjectListModel struct {
-	list     list.Model
-	projects []models.Project
-	width    int
-	height   int
+	list          list.Model
+	projects      []models.Project
+	changed       map[string]bool // Projects that changed when the cached list was refreshed
+	templatesOnly bool            // Only list projects marked as templates
+	width         int
+	height        int
 }
 
 func NewProjectListModel(projects []models.Project) ProjectListModel {
-	items := make([]list.Item, len(projects))
-	for i, p := range projects {
-		items[i] = projectItem{project: p}
-	}
-
-	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l := list.New(projectListItems(projects, nil, false), list.NewDefaultDelegate(), 0, 0)
 	l.Title = "GitHub Projects"
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
@@ -91,6 +97,21 @@ func (m ProjectListModel) Update(msg tea.Msg) (ProjectListModel, tea.Cmd) {
 		case "n":
 			// Create new project
 			return m, NewProjectCmd()
+		case "t":
+			// Mark or unmark the selected project as a template
+			if m.list.FilterState() != list.Filtering {
+				if i, ok := m.list.SelectedItem().(projectItem); ok {
+					if !canBeTemplate(i.project) {
+						return m, m.list.NewStatusMessage("Only organization projects can be templates")
+					}
+					return m, ToggleTemplateCmd(i.project)
+				}
+			}
+		case "T":
+			// Switch between all projects and templates only
+			if m.list.FilterState() != list.Filtering {
+				return m, m.setTemplatesOnly(!m.templatesOnly)
+			}
 		}
 	}
 
@@ -104,11 +125,76 @@ func (m ProjectListModel) View() string {
 		Foreground(lipgloss.Color("#626262")).
 		Padding(0, 2)
 
-	help := helpStyle.Render("enter: open • n: new project • esc: back • /: filter • q: quit")
+	keys := "enter: open • n: new project"
+	if i, ok := m.list.SelectedItem().(projectItem); ok && canBeTemplate(i.project) {
+		keys += " • t: toggle template"
+	}
+	help := helpStyle.Render(keys + " • T: templates only • esc: back • /: filter • q: quit")
 	
 	return lipgloss.JoinVertical(lipgloss.Left, m.list.View(), help)
 }
 
+// canBeTemplate reports whether a project can be marked as a template, which
+// GitHub only allows for organization projects
+func canBeTemplate(project models.Project) bool {
+	return project.Owner.Type == "Organization"
+}
+
+// setTemplatesOnly switches between listing all projects and only templates
+func (m *ProjectListModel) setTemplatesOnly(templatesOnly bool) tea.Cmd {
+	m.templatesOnly = templatesOnly
+	if templatesOnly {
+		m.list.Title = "Project Templates"
+	} else {
+		m.list.Title = "GitHub Projects"
+	}
+	return m.list.SetItems(projectListItems(m.projects, m.changed, m.templatesOnly))
+}
+
+// setProjects swaps in a refreshed project list, marking new or updated projects
+// and keeping the selection on the same project where possible
+func (m *ProjectListModel) setProjects(projects []models.Project) tea.Cmd {
+	var selectedID string
+	if i, ok := m.list.SelectedItem().(projectItem); ok {
+		selectedID = i.project.ID
+	}
+
+	previous := make(map[string]models.Project, len(m.projects))
+	for _, p := range m.projects {
+		previous[p.ID] = p
+	}
+	m.changed = make(map[string]bool)
+	for _, p := range projects {
+		old, ok := previous[p.ID]
+		if !ok || !old.UpdatedAt.Equal(p.UpdatedAt) {
+			m.changed[p.ID] = true
+		}
+	}
+
+	m.projects = projects
+	items := projectListItems(projects, m.changed, m.templatesOnly)
+	cmd := m.list.SetItems(items)
+	for i, item := range items {
+		if item.(projectItem).project.ID == selectedID {
+			m.list.Select(i)
+			break
+		}
+	}
+	return cmd
+}
+
+// projectListItems builds list items, optionally keeping only templates
+func projectListItems(projects []models.Project, changed map[string]bool, templatesOnly bool) []list.Item {
+	items := make([]list.Item, 0, len(projects))
+	for _, p := range projects {
+		if templatesOnly && !p.Template {
+			continue
+		}
+		items = append(items, projectItem{project: p, changed: changed[p.ID]})
+	}
+	return items
+}
+
 func (m ProjectListModel) GetSelectedProject() *models.Project {
 	if i, ok := m.list.SelectedItem().(projectItem); ok {
 		return &i.project
@@ -130,6 +216,13 @@ func NewProjectCmd() tea.Cmd {
 	}
 }
 
+// ToggleTemplateCmd signals marking or unmarking a project as a template
+func ToggleTemplateCmd(project models.Project) tea.Cmd {
+	return func() tea.Msg {
+		return ToggleTemplateMsg{Project: project}
+	}
+}
+
 // ProjectSelectedMsg is sent when a project is selected
 type ProjectSelectedMsg struct {
 	Project models.Project
@@ -138,3 +231,8 @@ type ProjectSelectedMsg struct {
 // NewProjectMsg is sent when user wants to create a new project
 type NewProjectMsg struct{}
 
+// ToggleTemplateMsg is sent to flip a project's template status
+type ToggleTemplateMsg struct {
+	Project models.Project
+}
+
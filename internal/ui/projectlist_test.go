@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+func TestShowProjectsKeepsTemplatesOnly(t *testing.T) {
+	projects := []models.Project{
+		{ID: "P1", Title: "Roadmap"},
+		{ID: "P2", Title: "Sprint template", Template: true},
+	}
+	m := Model{currentView: viewProjectList, projectList: NewProjectListModel(projects)}
+	m.projectList.setTemplatesOnly(true)
+
+	// Toggling a template reloads the projects
+	projects[0].Template = true
+	m.showProjects(projects)
+
+	if !m.projectList.templatesOnly || m.projectList.list.Title != "Project Templates" {
+		t.Errorf("templatesOnly = %v, title %q, want the templates kept", m.projectList.templatesOnly, m.projectList.list.Title)
+	}
+	if n := len(m.projectList.list.Items()); n != 2 {
+		t.Errorf("listed %d projects, want both templates", n)
+	}
+
+	// Coming back from another view starts with every project
+	projects[0].Template = false
+	m.currentView = viewProjectDetail
+	m.showProjects(projects)
+
+	if m.projectList.templatesOnly || len(m.projectList.list.Items()) != 2 {
+		t.Errorf("templatesOnly = %v with %d projects, want all projects", m.projectList.templatesOnly, len(m.projectList.list.Items()))
+	}
+}
+
+func TestToggleTemplateOnlyForOrganizationProjects(t *testing.T) {
+	t.Run("organization", func(t *testing.T) {
+		project := models.Project{ID: "P1", Title: "Roadmap", Owner: models.ProjectOwner{Login: "github", Type: "Organization"}}
+		m := NewProjectListModel([]models.Project{project})
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+
+		if msg, ok := cmd().(ToggleTemplateMsg); !ok || msg.Project.ID != project.ID {
+			t.Errorf("got %#v, want ToggleTemplateMsg", msg)
+		}
+		if !strings.Contains(m.View(), "t: toggle template") {
+			t.Error("help leaves out t")
+		}
+	})
+
+	t.Run("user", func(t *testing.T) {
+		project := models.Project{ID: "P1", Title: "Roadmap", Owner: models.ProjectOwner{Login: "octocat", Type: "User"}}
+		m := NewProjectListModel([]models.Project{project})
+
+		// The status hint's command only clears it later, so it isn't run
+		if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}); cmd == nil {
+			t.Error("got no status hint")
+		}
+		if strings.Contains(m.View(), "t: toggle template") {
+			t.Error("help offers t for a user project")
+		}
+	})
+}
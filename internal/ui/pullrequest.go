@@ -0,0 +1,423 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+var (
+	prGoodStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	prBadStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87"))
+	prPendingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD700"))
+)
+
+// reviewGlyph is the compact form of a review decision
+func reviewGlyph(decision string) string {
+	switch decision {
+	case "APPROVED":
+		return "✓"
+	case "CHANGES_REQUESTED":
+		return "✗"
+	case "REVIEW_REQUIRED":
+		return "○"
+	}
+	return ""
+}
+
+// checksGlyph is the compact form of a CI status rollup
+func checksGlyph(checks string) string {
+	switch checks {
+	case "SUCCESS":
+		return "✔"
+	case "FAILURE", "ERROR":
+		return "✘"
+	case "PENDING", "EXPECTED":
+		return "…"
+	}
+	return ""
+}
+
+// rowStatus is the Status column of a table row. Pull requests show DRAFT
+// while in draft, followed by glyphs for review, checks and merge conflicts.
+// Issues blocked by an open issue are flagged with ⛔.
+func rowStatus(item models.ProjectItem) string {
+	pr := item.PullRequest
+	if pr == nil {
+		if isBlocked(item) && item.State == "OPEN" {
+			return item.State + " ⛔"
+		}
+		return item.State
+	}
+
+	state := item.State
+	if pr.IsDraft && state == "OPEN" {
+		state = "DRAFT"
+	}
+	glyphs := reviewGlyph(pr.ReviewDecision) + checksGlyph(pr.Checks)
+	if pr.Mergeable == "CONFLICTING" {
+		glyphs += "⚠"
+	}
+	if glyphs == "" || state != "OPEN" && state != "DRAFT" {
+		// Review and CI no longer matter once closed or merged
+		return state
+	}
+	return state + " " + glyphs
+}
+
+// pullRequestBreakdown describes a pull request's branches, review, checks
+// and, while it's open, mergeability, one line each
+func pullRequestBreakdown(state string, pr models.PullRequestStatus) []string {
+	var lines []string
+	if pr.HeadRef != "" {
+		lines = append(lines, fmt.Sprintf("Branch: %s → %s", pr.HeadRef, pr.BaseRef))
+	}
+	if pr.IsDraft {
+		lines = append(lines, "Draft: "+prPendingStyle.Render("not ready for review"))
+	}
+
+	switch pr.ReviewDecision {
+	case "APPROVED":
+		lines = append(lines, "Review: "+prGoodStyle.Render("✓ approved"))
+	case "CHANGES_REQUESTED":
+		lines = append(lines, "Review: "+prBadStyle.Render("✗ changes requested"))
+	case "REVIEW_REQUIRED":
+		lines = append(lines, "Review: "+prPendingStyle.Render("○ review required"))
+	default:
+		lines = append(lines, "Review: not required")
+	}
+	if len(pr.Reviewers) > 0 {
+		lines = append(lines, "Requested reviewers: @"+strings.Join(pr.Reviewers, ", @"))
+	}
+
+	switch pr.Checks {
+	case "SUCCESS":
+		lines = append(lines, "Checks: "+prGoodStyle.Render("✔ passing"))
+	case "FAILURE":
+		lines = append(lines, "Checks: "+prBadStyle.Render("✘ failing"))
+	case "ERROR":
+		lines = append(lines, "Checks: "+prBadStyle.Render("✘ errored"))
+	case "PENDING":
+		lines = append(lines, "Checks: "+prPendingStyle.Render("… running"))
+	case "EXPECTED":
+		lines = append(lines, "Checks: "+prPendingStyle.Render("… waiting for a status"))
+	default:
+		lines = append(lines, "Checks: none")
+	}
+
+	if state != "OPEN" {
+		return lines
+	}
+	switch pr.Mergeable {
+	case "MERGEABLE":
+		lines = append(lines, "Merge: "+prGoodStyle.Render("no conflicts"))
+	case "CONFLICTING":
+		lines = append(lines, "Merge: "+prBadStyle.Render("⚠ conflicts with "+pr.BaseRef))
+	default:
+		lines = append(lines, "Merge: still being checked")
+	}
+	return lines
+}
+
+// reviewEvents are the kinds of review that can be submitted, in the order offered
+var reviewEvents = []struct {
+	event string
+	label string
+}{
+	{"COMMENT", "Comment"},
+	{"APPROVE", "Approve"},
+	{"REQUEST_CHANGES", "Request changes"},
+}
+
+// mergeMethods are the ways a pull request can be merged, in the order offered
+var mergeMethods = []struct {
+	method string
+	label  string
+}{
+	{"MERGE", "Create a merge commit"},
+	{"SQUASH", "Squash and merge"},
+	{"REBASE", "Rebase and merge"},
+}
+
+// pullRequestForm asks for what a pull request action needs: who to request
+// reviews from, the kind and body of a review, or how to merge
+type pullRequestForm struct {
+	kind           string // "reviewers", "review" or "merge"
+	item           models.ProjectItem
+	choice         int  // Index into reviewEvents or mergeMethods
+	editingBody    bool // The review body has focus rather than the review kinds
+	reviewersInput textinput.Model
+	bodyInput      textarea.Model
+	err            string
+}
+
+func newPullRequestForm(kind string, item models.ProjectItem, width int) (pullRequestForm, tea.Cmd) {
+	inputWidth := width - 10
+	if inputWidth < 40 {
+		inputWidth = 40
+	}
+
+	ri := textinput.New()
+	ri.Placeholder = "login, org/team"
+	ri.Width = inputWidth
+
+	ta := textarea.New()
+	ta.Placeholder = "Review comment"
+	ta.CharLimit = 65536
+	ta.SetWidth(inputWidth)
+	ta.SetHeight(6)
+
+	f := pullRequestForm{
+		kind:           kind,
+		item:           item,
+		reviewersInput: ri,
+		bodyInput:      ta,
+	}
+	if kind == "reviewers" {
+		return f, f.reviewersInput.Focus()
+	}
+	return f, nil
+}
+
+func (f pullRequestForm) update(msg tea.KeyMsg) (pullRequestForm, tea.Cmd) {
+	f.err = ""
+	var cmd tea.Cmd
+	switch f.kind {
+	case "reviewers":
+		f.reviewersInput, cmd = f.reviewersInput.Update(msg)
+	case "review":
+		if msg.String() == "tab" || msg.String() == "shift+tab" {
+			f.editingBody = !f.editingBody
+			if f.editingBody {
+				return f, f.bodyInput.Focus()
+			}
+			f.bodyInput.Blur()
+			return f, nil
+		}
+		if f.editingBody {
+			f.bodyInput, cmd = f.bodyInput.Update(msg)
+			return f, cmd
+		}
+		f.choice = moveChoice(f.choice, len(reviewEvents), msg.String())
+	case "merge":
+		f.choice = moveChoice(f.choice, len(mergeMethods), msg.String())
+	}
+	return f, cmd
+}
+
+func moveChoice(choice, count int, key string) int {
+	switch key {
+	case "up", "k":
+		if choice > 0 {
+			choice--
+		}
+	case "down", "j":
+		if choice < count-1 {
+			choice++
+		}
+	}
+	return choice
+}
+
+// submit checks the form and returns the command for the action, or no
+// command when the form needs fixing
+func (f pullRequestForm) submit() (pullRequestForm, tea.Cmd) {
+	item := f.item
+	switch f.kind {
+	case "reviewers":
+		reviewers := parseReviewers(f.reviewersInput.Value())
+		if len(reviewers) == 0 {
+			f.err = "Enter the logins or org/team names to request reviews from"
+			return f, nil
+		}
+		return f, func() tea.Msg { return RequestReviewsMsg{Item: item, Reviewers: reviewers} }
+	case "review":
+		event := reviewEvents[f.choice].event
+		body := strings.TrimSpace(f.bodyInput.Value())
+		if body == "" && event != "APPROVE" {
+			f.err = "A comment is needed unless you approve"
+			f.editingBody = true
+			return f, f.bodyInput.Focus()
+		}
+		return f, func() tea.Msg { return SubmitReviewMsg{Item: item, Event: event, Body: body} }
+	case "merge":
+		method := mergeMethods[f.choice].method
+		return f, func() tea.Msg { return MergePullRequestMsg{Item: item, Method: method} }
+	}
+	return f, nil
+}
+
+// parseReviewers splits a list of reviewers separated by commas or spaces,
+// dropping any leading @
+func parseReviewers(input string) []string {
+	var reviewers []string
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if reviewer := strings.TrimPrefix(field, "@"); reviewer != "" {
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	return reviewers
+}
+
+func (f pullRequestForm) view() string {
+	var b strings.Builder
+
+	switch f.kind {
+	case "reviewers":
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Request reviews on #%d", f.item.Number)))
+		b.WriteString("\n")
+		b.WriteString("  " + f.reviewersInput.View())
+		b.WriteString("\n")
+	case "review":
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Review #%d", f.item.Number)))
+		b.WriteString("\n")
+		for i, event := range reviewEvents {
+			b.WriteString(choiceLine(event.label, i == f.choice, !f.editingBody))
+		}
+		b.WriteString(issueStateLabelStyle.Render("Comment:"))
+		b.WriteString("\n")
+		b.WriteString("  " + f.bodyInput.View())
+		b.WriteString("\n")
+	case "merge":
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Merge #%d", f.item.Number)))
+		b.WriteString("\n")
+		if pr := f.item.PullRequest; pr != nil && pr.HeadRef != "" {
+			b.WriteString(itemDetailMetaStyle.Render(fmt.Sprintf("%s into %s", pr.HeadRef, pr.BaseRef)))
+			b.WriteString("\n")
+		}
+		for i, method := range mergeMethods {
+			b.WriteString(choiceLine(method.label, i == f.choice, true))
+		}
+	}
+
+	if f.err != "" {
+		b.WriteString(issueStateErrorStyle.Render(f.err))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// choiceLine renders one option of a list where a single option is chosen
+func choiceLine(label string, chosen, focused bool) string {
+	cursor, check := "  ", "( )"
+	if chosen {
+		check = "(•)"
+		if focused {
+			cursor = "▸ "
+		}
+	}
+	return "  " + cursor + check + " " + label + "\n"
+}
+
+func (f pullRequestForm) helpText() string {
+	switch f.kind {
+	case "review":
+		return "↑/↓: kind of review • tab: comment • ctrl+s: submit • esc: cancel"
+	case "merge":
+		return "↑/↓: merge method • enter: merge • esc: cancel"
+	}
+	return "ctrl+s: request • esc: cancel"
+}
+
+// RequestReviewsMsg is sent to request reviews on a pull request
+type RequestReviewsMsg struct {
+	Item      models.ProjectItem
+	Reviewers []string // User logins, and teams as "org/slug"
+}
+
+// SubmitReviewMsg is sent to review a pull request
+type SubmitReviewMsg struct {
+	Item  models.ProjectItem
+	Event string // See reviewEvents
+	Body  string
+}
+
+// MarkReadyForReviewMsg is sent to take a pull request out of draft
+type MarkReadyForReviewMsg struct {
+	Item models.ProjectItem
+}
+
+// MergePullRequestMsg is sent to merge a pull request
+type MergePullRequestMsg struct {
+	Item   models.ProjectItem
+	Method string // See mergeMethods
+}
+
+// PullRequestUpdatedMsg carries a pull request with its state and status
+// fetched again after an action
+type PullRequestUpdatedMsg struct {
+	Item models.ProjectItem
+}
+
+// MarkReadyForReviewCmd signals taking a pull request out of draft
+func MarkReadyForReviewCmd(item models.ProjectItem) tea.Cmd {
+	return func() tea.Msg {
+		return MarkReadyForReviewMsg{Item: item}
+	}
+}
+
+// pullRequestAction runs an action on a pull request, then fetches its state
+// and status again so the table and item view show the outcome
+func pullRequestAction(ctx context.Context, client api.Interface, item models.ProjectItem, action func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := action(); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		state, status, err := client.GetPullRequestStatus(ctx, item.ContentID)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		item.State = state
+		item.PullRequest = status
+		return PullRequestUpdatedMsg{Item: item}
+	}
+}
+
+func requestReviews(ctx context.Context, client api.Interface, msg RequestReviewsMsg) tea.Cmd {
+	return pullRequestAction(ctx, client, msg.Item, func() error {
+		userIDs, teamIDs, err := reviewerIDs(ctx, client, msg.Reviewers)
+		if err != nil {
+			return err
+		}
+		return client.RequestReviews(ctx, msg.Item.ContentID, userIDs, teamIDs)
+	})
+}
+
+// reviewerIDs resolves reviewers to user and team node IDs. Teams are given
+// as "org/slug".
+func reviewerIDs(ctx context.Context, client api.Interface, reviewers []string) ([]string, []string, error) {
+	var logins, teamIDs []string
+	for _, reviewer := range reviewers {
+		org, slug, isTeam := strings.Cut(reviewer, "/")
+		if !isTeam {
+			logins = append(logins, reviewer)
+			continue
+		}
+		teams, err := client.SearchOrgTeams(ctx, org, slug, 10)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find team %s: %w", reviewer, err)
+		}
+		i := slices.IndexFunc(teams, func(team models.Team) bool {
+			return strings.EqualFold(team.Slug, slug)
+		})
+		if i < 0 {
+			return nil, nil, apierrors.ValidationError(fmt.Sprintf("Team %s was not found", reviewer), nil)
+		}
+		teamIDs = append(teamIDs, teams[i].ID)
+	}
+
+	userIDs, err := userNodeIDs(ctx, client, logins)
+	if err != nil {
+		return nil, nil, err
+	}
+	return userIDs, teamIDs, nil
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	apierrors "github.com/thomaskoefod/githubProjectTUI/internal/errors"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+func TestRowStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status models.PullRequestStatus
+		merge  bool
+		want   string
+	}{
+		{"no review or checks", models.PullRequestStatus{Mergeable: "MERGEABLE"}, false, "OPEN"},
+		{"draft", models.PullRequestStatus{IsDraft: true, ReviewDecision: "REVIEW_REQUIRED", Checks: "PENDING"}, false, "DRAFT ○…"},
+		{"ready", models.PullRequestStatus{ReviewDecision: "APPROVED", Checks: "SUCCESS", Mergeable: "MERGEABLE"}, false, "OPEN ✓✔"},
+		{"failing", models.PullRequestStatus{ReviewDecision: "CHANGES_REQUESTED", Checks: "FAILURE", Mergeable: "CONFLICTING"}, false, "OPEN ✗✘⚠"},
+		{"merged", models.PullRequestStatus{ReviewDecision: "APPROVED", Checks: "SUCCESS", Mergeable: "MERGEABLE"}, true, "MERGED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, p, r := newBoard()
+			pr := c.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+			c.SetPullRequestStatus(pr.ContentID, tt.status)
+			if tt.merge {
+				if err := c.MergePullRequest(context.Background(), pr.ContentID, "MERGE"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := rowStatus(findItem(t, c, p.ID, pr.ID)); got != tt.want {
+				t.Errorf("rowStatus = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestReviews(t *testing.T) {
+	c, p, r := newBoard()
+	c.AddUser("hubot")
+	c.AddOrg("github", "octocat", "hubot")
+	c.AddTeam("github", "core", "Core")
+	pr := c.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+
+	msg := requestReviews(context.Background(), c, RequestReviewsMsg{Item: pr, Reviewers: []string{"hubot", "github/core"}})()
+
+	updated, ok := msg.(PullRequestUpdatedMsg)
+	if !ok {
+		t.Fatalf("got %#v, want PullRequestUpdatedMsg", msg)
+	}
+	if reviewers := updated.Item.PullRequest.Reviewers; !slices.Equal(reviewers, []string{"hubot", "github/core"}) {
+		t.Errorf("reviewers = %v, want [hubot github/core]", reviewers)
+	}
+}
+
+func TestRequestReviewsUnknownTeam(t *testing.T) {
+	c, p, r := newBoard()
+	c.AddOrg("github", "octocat")
+	pr := c.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+
+	msg := requestReviews(context.Background(), c, RequestReviewsMsg{Item: pr, Reviewers: []string{"github/nobody"}})()
+
+	if errMsg, ok := msg.(ErrorMsg); !ok || apiErrorType(errMsg.Err) != apierrors.ErrorTypeValidation {
+		t.Fatalf("got %#v, want a validation error", msg)
+	}
+	if slices.Contains(c.Calls(), "RequestReviews") {
+		t.Error("requested reviews despite the unknown team")
+	}
+}
+
+func TestMergeRefusedByBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	c, p, r := newBoard()
+	pr := c.AddPullRequest(p.ID, r.ID, "Fix crash", "")
+	c.SetPullRequestStatus(pr.ContentID, models.PullRequestStatus{ReviewDecision: "REVIEW_REQUIRED", Checks: "SUCCESS", Mergeable: "MERGEABLE"})
+
+	msg := pullRequestAction(ctx, c, pr, func() error {
+		return c.MergePullRequest(ctx, pr.ContentID, "MERGE")
+	})()
+
+	if errMsg, ok := msg.(ErrorMsg); !ok || apiErrorType(errMsg.Err) != apierrors.ErrorTypeBranchProtection {
+		t.Fatalf("got %#v, want a branch protection error", msg)
+	}
+	if item := findItem(t, c, p.ID, pr.ID); item.State != "OPEN" {
+		t.Errorf("state = %s, want OPEN", item.State)
+	}
+}
+
+// apiErrorType returns the type of the APIError in err, or unknown without one
+func apiErrorType(err error) apierrors.ErrorType {
+	var apiErr *apierrors.APIError
+	if !errors.As(err, &apiErr) {
+		return apierrors.ErrorTypeUnknown
+	}
+	return apiErr.Type
+}
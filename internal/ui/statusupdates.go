@@ -0,0 +1,386 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// statusOptions lists the statuses a project update can report, in cycling order
+var statusOptions = []string{"ON_TRACK", "AT_RISK", "OFF_TRACK", "COMPLETE", "INACTIVE"}
+
+var (
+	statusUpdateLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#888888")).
+				MarginLeft(2)
+
+	statusUpdateBoxStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#444444")).
+				Padding(0, 1).
+				MarginLeft(2)
+
+	statusUpdateErrorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FF0000")).
+				MarginLeft(2)
+)
+
+// StatusUpdatesModel is the status update pane shown inside the project detail view
+type StatusUpdatesModel struct {
+	project       models.Project
+	updates       []models.StatusUpdate
+	loading       bool
+	err           error // Why the updates failed to load, r retries
+	offset        int   // Index of the first update shown
+	composing     bool
+	statusIndex   int
+	startInput    textinput.Model
+	targetInput   textinput.Model
+	bodyInput     textarea.Model
+	focusIndex    int
+	validationErr string
+	width         int
+	height        int
+}
+
+func NewStatusUpdatesModel(project models.Project) StatusUpdatesModel {
+	start := textinput.New()
+	start.Placeholder = "YYYY-MM-DD (optional)"
+	start.CharLimit = 10
+	start.Width = 20
+
+	target := textinput.New()
+	target.Placeholder = "YYYY-MM-DD (optional)"
+	target.CharLimit = 10
+	target.Width = 20
+
+	body := textarea.New()
+	body.Placeholder = "What's the latest? (optional)"
+	body.CharLimit = 5000
+	body.SetWidth(80)
+	body.SetHeight(6)
+
+	return StatusUpdatesModel{
+		project:     project,
+		loading:     true,
+		startInput:  start,
+		targetInput: target,
+		bodyInput:   body,
+	}
+}
+
+func (m StatusUpdatesModel) Update(msg tea.Msg) (StatusUpdatesModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		inputWidth := msg.Width - 10
+		if inputWidth < 40 {
+			inputWidth = 40
+		}
+		m.bodyInput.SetWidth(inputWidth)
+		return m, nil
+
+	case StatusUpdatesLoadedMsg:
+		if msg.Project.ID == m.project.ID {
+			m.updates = msg.Updates
+			m.loading = false
+			m.err = nil
+			m.offset = 0
+		}
+		return m, nil
+
+	case StatusUpdatesFailedMsg:
+		if msg.Project.ID == m.project.ID {
+			m.loading = false
+			if msg.Posting {
+				// Back to the composer, which still has what was written
+				m.composing = true
+				m.validationErr = msg.Err.Error()
+			} else {
+				m.err = msg.Err
+			}
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.composing {
+			return m.updateComposer(msg)
+		}
+
+		switch msg.String() {
+		case "n":
+			m.composing = true
+			m.focusIndex = 0
+			m.validationErr = ""
+			m.startInput.SetValue("")
+			m.targetInput.SetValue("")
+			m.bodyInput.SetValue("")
+			// Carry over the dates of the latest update, they rarely change week to week
+			if len(m.updates) > 0 {
+				m.startInput.SetValue(m.updates[0].StartDate)
+				m.targetInput.SetValue(m.updates[0].TargetDate)
+				m.statusIndex = statusIndexOf(m.updates[0].Status)
+			}
+			m.updateFocus()
+			return m, nil
+		case "j", "down":
+			if m.offset < len(m.updates)-1 {
+				m.offset++
+			}
+		case "k", "up":
+			if m.offset > 0 {
+				m.offset--
+			}
+		case "r":
+			m.loading = true
+			return m, LoadStatusUpdatesCmd(m.project)
+		}
+	}
+
+	return m, nil
+}
+
+func (m StatusUpdatesModel) updateComposer(msg tea.KeyMsg) (StatusUpdatesModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.composing = false
+		return m, nil
+	case "ctrl+s":
+		for _, date := range []string{m.startInput.Value(), m.targetInput.Value()} {
+			if date == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				m.validationErr = fmt.Sprintf("Invalid date %q, use YYYY-MM-DD", date)
+				return m, nil
+			}
+		}
+		m.validationErr = ""
+		m.composing = false
+		m.loading = true
+		return m, PostStatusUpdateCmd(m.project, models.CreateStatusUpdateInput{
+			ProjectID:  m.project.ID,
+			Status:     statusOptions[m.statusIndex],
+			Body:       m.bodyInput.Value(),
+			StartDate:  m.startInput.Value(),
+			TargetDate: m.targetInput.Value(),
+		})
+	case "tab", "shift+tab":
+		if msg.String() == "tab" {
+			m.focusIndex = (m.focusIndex + 1) % 4
+		} else {
+			m.focusIndex = (m.focusIndex + 3) % 4
+		}
+		m.updateFocus()
+		return m, nil
+	case " ", "left", "right":
+		if m.focusIndex == 0 {
+			if msg.String() == "left" {
+				m.statusIndex = (m.statusIndex + len(statusOptions) - 1) % len(statusOptions)
+			} else {
+				m.statusIndex = (m.statusIndex + 1) % len(statusOptions)
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.focusIndex {
+	case 1:
+		m.startInput, cmd = m.startInput.Update(msg)
+	case 2:
+		m.targetInput, cmd = m.targetInput.Update(msg)
+	case 3:
+		m.bodyInput, cmd = m.bodyInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *StatusUpdatesModel) updateFocus() {
+	m.startInput.Blur()
+	m.targetInput.Blur()
+	m.bodyInput.Blur()
+	switch m.focusIndex {
+	case 1:
+		m.startInput.Focus()
+	case 2:
+		m.targetInput.Focus()
+	case 3:
+		m.bodyInput.Focus()
+	}
+}
+
+func (m StatusUpdatesModel) View() string {
+	var b strings.Builder
+
+	if m.composing {
+		focusIndicator := func(index int) string {
+			if m.focusIndex == index {
+				return "▶ "
+			}
+			return "  "
+		}
+
+		b.WriteString(statusUpdateLabelStyle.Render(focusIndicator(0) + "Status: ◂ " + renderStatus(statusOptions[m.statusIndex]) + " ▸"))
+		b.WriteString("\n")
+		b.WriteString(statusUpdateLabelStyle.Render(focusIndicator(1) + "Start date:  " + m.startInput.View()))
+		b.WriteString("\n")
+		b.WriteString(statusUpdateLabelStyle.Render(focusIndicator(2) + "Target date: " + m.targetInput.View()))
+		b.WriteString("\n")
+		b.WriteString(statusUpdateLabelStyle.Render(focusIndicator(3) + "Update:"))
+		b.WriteString("\n")
+		b.WriteString("  " + m.bodyInput.View())
+		b.WriteString("\n")
+		if m.validationErr != "" {
+			b.WriteString(statusUpdateErrorStyle.Render("⚠ " + m.validationErr))
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+
+	if m.loading {
+		b.WriteString(statusUpdateLabelStyle.Render("Loading status updates..."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString(statusUpdateErrorStyle.Render("⚠ " + m.err.Error()))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if len(m.updates) == 0 {
+		b.WriteString(statusUpdateLabelStyle.Render("No status updates yet"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	boxWidth := m.width - 10
+	if boxWidth < 40 {
+		boxWidth = 40
+	}
+
+	// Show as many updates as comfortably fit, starting at the scroll offset
+	maxShown := (m.height - 10) / 6
+	if maxShown < 1 {
+		maxShown = 1
+	}
+	end := m.offset + maxShown
+	if end > len(m.updates) {
+		end = len(m.updates)
+	}
+
+	for _, update := range m.updates[m.offset:end] {
+		var text strings.Builder
+		text.WriteString(renderStatus(update.Status))
+		text.WriteString("  ")
+		text.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4")).Render("@" + update.Author))
+		text.WriteString(" ")
+		text.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Render(formatTime(update.CreatedAt)))
+		if update.StartDate != "" || update.TargetDate != "" {
+			text.WriteString("\n")
+			text.WriteString(fmt.Sprintf("Start: %s • Target: %s", orDash(update.StartDate), orDash(update.TargetDate)))
+		}
+		if update.Body != "" {
+			text.WriteString("\n")
+			text.WriteString(wordWrap(update.Body, boxWidth-4))
+		}
+		b.WriteString(statusUpdateBoxStyle.Width(boxWidth).Render(text.String()))
+		b.WriteString("\n")
+	}
+
+	if end < len(m.updates) {
+		b.WriteString(statusUpdateLabelStyle.Render(fmt.Sprintf("... %d older updates", len(m.updates)-end)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// HelpText returns the key help for the pane's current mode
+func (m StatusUpdatesModel) HelpText() string {
+	if m.composing {
+		return "tab: next field • space/←/→: change status • ctrl+s: post • esc: cancel"
+	}
+	return "n: new update • j/k: scroll • r: refresh • s/esc: close status pane"
+}
+
+// renderStatus renders a status as a colored label
+func renderStatus(status string) string {
+	color := "#888888"
+	switch status {
+	case "ON_TRACK":
+		color = "#00AF00"
+	case "AT_RISK":
+		color = "#FFA500"
+	case "OFF_TRACK":
+		color = "#FF0000"
+	case "COMPLETE":
+		color = "#7D56F4"
+	}
+	label := strings.ReplaceAll(strings.ToLower(status), "_", " ")
+	return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(color)).Render("● " + label)
+}
+
+func statusIndexOf(status string) int {
+	for i, option := range statusOptions {
+		if option == status {
+			return i
+		}
+	}
+	return 0
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// LoadStatusUpdatesCmd signals loading a project's status updates
+func LoadStatusUpdatesCmd(project models.Project) tea.Cmd {
+	return func() tea.Msg {
+		return LoadStatusUpdatesMsg{Project: project}
+	}
+}
+
+// PostStatusUpdateCmd signals posting a new status update
+func PostStatusUpdateCmd(project models.Project, input models.CreateStatusUpdateInput) tea.Cmd {
+	return func() tea.Msg {
+		return PostStatusUpdateMsg{Project: project, Input: input}
+	}
+}
+
+// LoadStatusUpdatesMsg is sent to load a project's status updates
+type LoadStatusUpdatesMsg struct {
+	Project models.Project
+}
+
+// StatusUpdatesLoadedMsg is sent when status updates are loaded
+type StatusUpdatesLoadedMsg struct {
+	Project models.Project
+	Updates []models.StatusUpdate
+}
+
+// StatusUpdatesFailedMsg is sent when status updates couldn't be loaded, or a
+// new one couldn't be posted
+type StatusUpdatesFailedMsg struct {
+	Project models.Project
+	Err     error
+	Posting bool // The post failed, rather than loading the updates
+}
+
+// PostStatusUpdateMsg is sent to post a new status update
+type PostStatusUpdateMsg struct {
+	Project models.Project
+	Input   models.CreateStatusUpdateInput
+}
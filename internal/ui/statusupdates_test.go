@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+)
+
+func TestStatusUpdatesLoadFailure(t *testing.T) {
+	c := fake.New("octocat")
+	p := c.AddProject("octocat", "Roadmap")
+	c.FailNext("ListStatusUpdates", errOffline)
+	pane := NewStatusUpdatesModel(p)
+
+	msg := loadStatusUpdates(context.Background(), c, p)().(cancellableMsg).msg
+	pane, _ = pane.Update(msg)
+
+	if pane.loading || pane.err == nil {
+		t.Fatalf("loading = %v, err = %v, want the failure shown", pane.loading, pane.err)
+	}
+	if view := pane.View(); strings.Contains(view, "Loading") || !strings.Contains(view, "failed to load status updates") {
+		t.Errorf("view = %q, want the error", view)
+	}
+}
+
+func TestStatusUpdatePostFailureKeepsText(t *testing.T) {
+	c := fake.New("octocat")
+	p := c.AddProject("octocat", "Roadmap")
+	pane := NewStatusUpdatesModel(p)
+	pane, _ = pane.Update(StatusUpdatesLoadedMsg{Project: p})
+	pane, _ = pane.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	pane.bodyInput.SetValue("Shipped the beta")
+
+	pane, cmd := pane.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	post := cmd().(PostStatusUpdateMsg)
+	c.FailNext("CreateStatusUpdate", errOffline)
+	pane, _ = pane.Update(postStatusUpdate(context.Background(), c, post)())
+
+	if pane.loading || !pane.composing || pane.validationErr == "" {
+		t.Fatalf("loading = %v, composing = %v, error %q, want the composer back with the error", pane.loading, pane.composing, pane.validationErr)
+	}
+	if body := pane.bodyInput.Value(); body != "Shipped the beta" {
+		t.Errorf("body = %q, want the text kept", body)
+	}
+
+	// Posting again sends the same text
+	pane, cmd = pane.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	msg := postStatusUpdate(context.Background(), c, cmd().(PostStatusUpdateMsg))().(cancellableMsg).msg
+	pane, _ = pane.Update(msg)
+	if pane.composing || len(pane.updates) != 1 || pane.updates[0].Body != "Shipped the beta" {
+		t.Errorf("updates = %+v, want the posted update", pane.updates)
+	}
+}
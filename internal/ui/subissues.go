@@ -0,0 +1,296 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// subIssueForm asks which issue to add as a sub-issue, which sub-issue to
+// remove, or which issue to move an issue under
+type subIssueForm struct {
+	kind        string // "add", "remove" or "parent"
+	project     models.Project
+	item        models.ProjectItem
+	numberInput textinput.Model
+	choice      int // Index into item.SubIssues when removing
+	err         string
+}
+
+func newSubIssueForm(kind string, project models.Project, item models.ProjectItem) (subIssueForm, tea.Cmd) {
+	ni := textinput.New()
+	ni.Placeholder = "Issue number"
+	ni.CharLimit = 10
+	ni.Width = 20
+
+	f := subIssueForm{
+		kind:        kind,
+		project:     project,
+		item:        item,
+		numberInput: ni,
+	}
+	if kind == "remove" {
+		return f, nil
+	}
+	return f, f.numberInput.Focus()
+}
+
+func (f subIssueForm) update(msg tea.KeyMsg) (subIssueForm, tea.Cmd) {
+	f.err = ""
+	if f.kind == "remove" {
+		f.choice = moveChoice(f.choice, len(f.item.SubIssues), msg.String())
+		return f, nil
+	}
+	var cmd tea.Cmd
+	f.numberInput, cmd = f.numberInput.Update(msg)
+	return f, cmd
+}
+
+// submit checks the form and returns the command for the change, or no
+// command when the form needs fixing
+func (f subIssueForm) submit() (subIssueForm, tea.Cmd) {
+	project, item := f.project, f.item
+	if f.kind == "remove" {
+		if f.choice >= len(item.SubIssues) {
+			return f, nil
+		}
+		subIssue := item.SubIssues[f.choice]
+		return f, func() tea.Msg { return RemoveSubIssueMsg{Project: project, Item: item, SubIssue: subIssue} }
+	}
+
+	input := strings.TrimPrefix(strings.TrimSpace(f.numberInput.Value()), "#")
+	if input == "" && f.kind == "parent" && item.Parent != nil {
+		// No parent given takes the issue out from under its current one
+		return f, func() tea.Msg { return SetParentMsg{Project: project, Item: item} }
+	}
+	number, err := strconv.Atoi(input)
+	if err != nil || number <= 0 {
+		f.err = "Enter an issue number"
+		return f, nil
+	}
+	if number == item.Number {
+		f.err = "An issue can't be its own sub-issue"
+		return f, nil
+	}
+	if f.kind == "parent" {
+		return f, func() tea.Msg { return SetParentMsg{Project: project, Item: item, Number: number} }
+	}
+	return f, func() tea.Msg { return AddSubIssueMsg{Project: project, Item: item, Number: number} }
+}
+
+func (f subIssueForm) view() string {
+	var b strings.Builder
+
+	switch f.kind {
+	case "add":
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Add a sub-issue to #%d", f.item.Number)))
+		b.WriteString("\n")
+		b.WriteString(issueStateLabelStyle.Render("Issue #"))
+		b.WriteString(f.numberInput.View())
+		b.WriteString("\n")
+	case "remove":
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Remove a sub-issue from #%d", f.item.Number)))
+		b.WriteString("\n")
+		for i, subIssue := range f.item.SubIssues {
+			b.WriteString(choiceLine(issueRefText(subIssue), i == f.choice, true))
+		}
+	case "parent":
+		b.WriteString(itemDetailLabelStyle.Render(fmt.Sprintf("Move #%d under another issue", f.item.Number)))
+		b.WriteString("\n")
+		if f.item.Parent != nil {
+			b.WriteString(itemDetailMetaStyle.Render("Currently under " + issueRefText(*f.item.Parent) + ", leave empty to remove it"))
+			b.WriteString("\n")
+		}
+		b.WriteString(issueStateLabelStyle.Render("Parent #"))
+		b.WriteString(f.numberInput.View())
+		b.WriteString("\n")
+	}
+
+	if f.err != "" {
+		b.WriteString(issueStateErrorStyle.Render(f.err))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (f subIssueForm) helpText() string {
+	if f.kind == "remove" {
+		return "↑/↓: sub-issue • enter: remove • esc: cancel"
+	}
+	return "enter: save • esc: cancel"
+}
+
+// issueRefText describes a related issue in one line
+func issueRefText(ref models.IssueRef) string {
+	mark := "○"
+	if ref.State == "CLOSED" || ref.State == "MERGED" {
+		mark = "✓"
+	}
+	return fmt.Sprintf("%s %s#%d %s", mark, ref.Repository, ref.Number, ref.Title)
+}
+
+// AddSubIssueMsg is sent to add an issue as a sub-issue of an item
+type AddSubIssueMsg struct {
+	Project models.Project
+	Item    models.ProjectItem
+	Number  int // Number of the sub-issue, in the item's repository
+}
+
+// RemoveSubIssueMsg is sent to remove one of an item's sub-issues
+type RemoveSubIssueMsg struct {
+	Project  models.Project
+	Item     models.ProjectItem
+	SubIssue models.IssueRef
+}
+
+// SetParentMsg is sent to move an item under another issue
+type SetParentMsg struct {
+	Project models.Project
+	Item    models.ProjectItem
+	Number  int // Number of the new parent in the item's repository, 0 for none
+}
+
+// IssueRelationsChangedMsg carries the project's items fetched again after
+// sub-issues or dependencies changed, since the change touches more than one item
+type IssueRelationsChangedMsg struct {
+	Project models.Project
+	Items   []models.ProjectItem
+	ItemID  string // The item the change was made from
+}
+
+func addSubIssue(ctx context.Context, client api.Interface, msg AddSubIssueMsg) tea.Cmd {
+	return issueRelationAction(ctx, client, msg.Project, msg.Item, func() error {
+		subIssueID, err := client.GetIssueID(ctx, msg.Item.RepositoryID, msg.Number)
+		if err != nil {
+			return err
+		}
+		return client.AddSubIssue(ctx, msg.Item.ContentID, subIssueID, false)
+	})
+}
+
+func removeSubIssue(ctx context.Context, client api.Interface, msg RemoveSubIssueMsg) tea.Cmd {
+	return issueRelationAction(ctx, client, msg.Project, msg.Item, func() error {
+		return client.RemoveSubIssue(ctx, msg.Item.ContentID, msg.SubIssue.ID)
+	})
+}
+
+// setParent moves an item under a new parent, replacing the one it has, or
+// takes it out from under its parent when no number is given
+func setParent(ctx context.Context, client api.Interface, msg SetParentMsg) tea.Cmd {
+	return issueRelationAction(ctx, client, msg.Project, msg.Item, func() error {
+		if msg.Number == 0 {
+			if msg.Item.Parent == nil {
+				return nil
+			}
+			return client.RemoveSubIssue(ctx, msg.Item.Parent.ID, msg.Item.ContentID)
+		}
+		parentID, err := client.GetIssueID(ctx, msg.Item.RepositoryID, msg.Number)
+		if err != nil {
+			return err
+		}
+		return client.AddSubIssue(ctx, parentID, msg.Item.ContentID, true)
+	})
+}
+
+// issueRelationAction runs a change to the sub-issue hierarchy or the
+// dependencies between issues, then fetches the project's items again so both
+// ends of the change show it
+func issueRelationAction(ctx context.Context, client api.Interface, project models.Project, item models.ProjectItem, action func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := action(); err != nil {
+			return ErrorMsg{Err: err}
+		}
+		items, err := client.ListProjectItems(ctx, project.ID, 100)
+		if err != nil {
+			return ErrorMsg{Err: fmt.Errorf("failed to load items: %w", err)}
+		}
+		return IssueRelationsChangedMsg{Project: project, Items: items, ItemID: item.ID}
+	}
+}
+
+// treeOrder orders items as a tree of sub-issues under their parents, each
+// parent followed by its children. Items whose parent isn't in the project are
+// roots. It returns the depth of each item and whether it has children shown
+// in the project; children of collapsed items are left out.
+func treeOrder(items []models.ProjectItem, collapsed map[string]bool) ([]models.ProjectItem, []int, map[string]bool) {
+	byContent := make(map[string]int, len(items))
+	for i, item := range items {
+		if item.ContentID != "" {
+			byContent[item.ContentID] = i
+		}
+	}
+
+	children := make(map[int][]int)
+	var roots []int
+	for i, item := range items {
+		parent, ok := -1, false
+		if item.Parent != nil {
+			parent, ok = byContent[item.Parent.ID]
+		}
+		if ok && parent != i {
+			children[parent] = append(children[parent], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	var ordered []models.ProjectItem
+	var depths []int
+	hasChildren := make(map[string]bool)
+	visited := make(map[int]bool)
+	var walk func(i, depth int, hidden bool)
+	walk = func(i, depth int, hidden bool) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		item := items[i]
+		if !hidden {
+			ordered = append(ordered, item)
+			depths = append(depths, depth)
+			hasChildren[item.ID] = len(children[i]) > 0
+		}
+		for _, child := range children[i] {
+			walk(child, depth+1, hidden || collapsed[item.ID])
+		}
+	}
+	for _, i := range roots {
+		walk(i, 0, false)
+	}
+	// Items in a parent cycle have no root to hang from, show them at the top level
+	for i := range items {
+		walk(i, 0, false)
+	}
+	return ordered, depths, hasChildren
+}
+
+// treePrefixes indents each item's title by its depth in the tree, with a
+// marker on items that have sub-issues showing whether they're expanded
+func treePrefixes(items []models.ProjectItem, depths []int, hasChildren, collapsed map[string]bool) map[string]string {
+	prefixes := make(map[string]string, len(items))
+	for i, item := range items {
+		marker := "  "
+		if hasChildren[item.ID] {
+			marker = "▾ "
+			if collapsed[item.ID] {
+				marker = "▸ "
+			}
+		}
+		prefixes[item.ID] = strings.Repeat("  ", depths[i]) + marker
+	}
+	return prefixes
+}
+
+// subIssuesProgress is the compact count of an item's finished sub-issues
+func subIssuesProgress(item models.ProjectItem) string {
+	if item.SubIssuesSummary.Total == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [%d/%d]", item.SubIssuesSummary.Completed, item.SubIssuesSummary.Total)
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// treeText lists items as "title@depth", in tree order
+func treeText(items []models.ProjectItem, depths []int) string {
+	var lines []string
+	for i, item := range items {
+		lines = append(lines, fmt.Sprintf("%s@%d", item.Title, depths[i]))
+	}
+	return fmt.Sprint(lines)
+}
+
+func TestSubIssueTree(t *testing.T) {
+	ctx := context.Background()
+	c, p, r := newBoard()
+	epic := c.AddIssue(p.ID, r.ID, "Epic", "")
+	c.AddDraft(p.ID, "Idea", "")
+	task := c.AddIssue(p.ID, r.ID, "Task", "")
+	step := c.AddIssue(p.ID, r.ID, "Step", "")
+
+	// Build Epic > Task > Step with the item view's actions
+	msg := addSubIssue(ctx, c, AddSubIssueMsg{Project: p, Item: epic, Number: task.Number})()
+	if _, ok := msg.(IssueRelationsChangedMsg); !ok {
+		t.Fatalf("got %#v, want IssueRelationsChangedMsg", msg)
+	}
+	msg = setParent(ctx, c, SetParentMsg{Project: p, Item: step, Number: task.Number})()
+	changed, ok := msg.(IssueRelationsChangedMsg)
+	if !ok {
+		t.Fatalf("got %#v, want IssueRelationsChangedMsg", msg)
+	}
+
+	items, depths, hasChildren := treeOrder(changed.Items, nil)
+	if got, want := treeText(items, depths), "[Epic@0 Task@1 Step@2 Idea@0]"; got != want {
+		t.Errorf("tree = %s, want %s", got, want)
+	}
+	if !hasChildren[epic.ID] || !hasChildren[task.ID] || hasChildren[step.ID] {
+		t.Errorf("hasChildren = %v", hasChildren)
+	}
+
+	// Collapsing the epic hides everything under it
+	items, depths, _ = treeOrder(changed.Items, map[string]bool{epic.ID: true})
+	if got, want := treeText(items, depths), "[Epic@0 Idea@0]"; got != want {
+		t.Errorf("collapsed tree = %s, want %s", got, want)
+	}
+
+	// Moving the step to the top level
+	step = findItem(t, c, p.ID, step.ID)
+	msg = setParent(ctx, c, SetParentMsg{Project: p, Item: step})()
+	changed = msg.(IssueRelationsChangedMsg)
+	items, depths, _ = treeOrder(changed.Items, nil)
+	if got, want := treeText(items, depths), "[Epic@0 Task@1 Idea@0 Step@0]"; got != want {
+		t.Errorf("tree = %s, want %s", got, want)
+	}
+}
+
+func TestTreeOrderParentCycle(t *testing.T) {
+	a := models.ProjectItem{ID: "A", ContentID: "I_A", Title: "A", Parent: &models.IssueRef{ID: "I_B"}}
+	b := models.ProjectItem{ID: "B", ContentID: "I_B", Title: "B", Parent: &models.IssueRef{ID: "I_A"}}
+
+	items, depths, _ := treeOrder([]models.ProjectItem{a, b}, nil)
+
+	if got, want := treeText(items, depths), "[A@0 B@1]"; got != want {
+		t.Errorf("tree = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+var timelineSelectedStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#7D56F4")).
+	MarginLeft(4)
+
+// timelinePageSize is how many events each page of the timeline fetches
+const timelinePageSize = 30
+
+// timelineFilter picks the kinds of events the timeline tab shows, by their
+// timeline item types
+type timelineFilter struct {
+	name      string
+	itemTypes []string
+}
+
+// timelineFilters are the filters f cycles through, All first
+var timelineFilters = []timelineFilter{
+	{name: "All"},
+	{name: "Labels", itemTypes: []string{"LABELED_EVENT", "UNLABELED_EVENT"}},
+	{name: "Assignees", itemTypes: []string{"ASSIGNED_EVENT", "UNASSIGNED_EVENT"}},
+	{name: "References", itemTypes: []string{"CROSS_REFERENCED_EVENT"}},
+	{name: "State", itemTypes: []string{"CLOSED_EVENT", "REOPENED_EVENT", "MERGED_EVENT"}},
+	{name: "Title", itemTypes: []string{"RENAMED_TITLE_EVENT"}},
+	{name: "Milestones", itemTypes: []string{"MILESTONED_EVENT", "DEMILESTONED_EVENT"}},
+	{name: "Projects", itemTypes: []string{"ADDED_TO_PROJECT_V2_EVENT", "REMOVED_FROM_PROJECT_V2_EVENT", "PROJECT_V2_ITEM_STATUS_CHANGED_EVENT"}},
+}
+
+// timelineItemTypes returns the item types a filter shows. All asks for every
+// type the tab can describe rather than none, which would mean any event, so
+// pages aren't filled with events it leaves out.
+func timelineItemTypes(filter int) []string {
+	if filter > 0 {
+		return timelineFilters[filter].itemTypes
+	}
+	var itemTypes []string
+	for _, f := range timelineFilters[1:] {
+		itemTypes = append(itemTypes, f.itemTypes...)
+	}
+	return itemTypes
+}
+
+// timelineTab is the item view's timeline, oldest event first. Pages are
+// fetched as the cursor reaches the last loaded event.
+type timelineTab struct {
+	filter  int // Index into timelineFilters
+	events  []models.TimelineEvent
+	page    models.PageInfo
+	loading bool
+	err     error
+	cursor  int
+	seq     int // Tags loads so pages for an earlier filter are dropped
+}
+
+// reset drops the loaded events and fetches the first page again
+func (t timelineTab) reset(item models.ProjectItem) (timelineTab, tea.Cmd) {
+	t.seq++
+	t.events = nil
+	t.page = models.PageInfo{}
+	t.err = nil
+	t.cursor = 0
+	return t.load(item, "")
+}
+
+func (t timelineTab) load(item models.ProjectItem, after string) (timelineTab, tea.Cmd) {
+	t.loading = true
+	return t, LoadTimelineCmd(item, timelineItemTypes(t.filter), after, t.seq)
+}
+
+func (t timelineTab) update(msg tea.KeyMsg, item models.ProjectItem) (timelineTab, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k", "down", "j":
+		t.cursor = moveChoice(t.cursor, len(t.events), msg.String())
+		// Fetch the next page on reaching the end of what's loaded
+		if t.cursor >= len(t.events)-1 && t.page.HasNextPage && !t.loading {
+			return t.load(item, t.page.EndCursor)
+		}
+	case "f":
+		t.filter = (t.filter + 1) % len(timelineFilters)
+		return t.reset(item)
+	case "F":
+		t.filter = (t.filter + len(timelineFilters) - 1) % len(timelineFilters)
+		return t.reset(item)
+	}
+	return t, nil
+}
+
+// loaded adds a page of events, unless it was fetched for an earlier filter
+func (t timelineTab) loaded(msg TimelineLoadedMsg) timelineTab {
+	if msg.Seq != t.seq {
+		return t
+	}
+	t.loading = false
+	t.err = msg.Err
+	if msg.Err == nil {
+		t.events = append(t.events, msg.Events...)
+		t.page = msg.PageInfo
+	}
+	return t
+}
+
+// view shows a window of height events around the cursor
+func (t timelineTab) view(height int) string {
+	var b strings.Builder
+
+	var names []string
+	for i, filter := range timelineFilters {
+		if i == t.filter {
+			names = append(names, "["+filter.name+"]")
+		} else {
+			names = append(names, filter.name)
+		}
+	}
+	b.WriteString(itemDetailLabelStyle.Render("Timeline:"))
+	b.WriteString("\n")
+	b.WriteString(itemDetailMetaStyle.Render("Show: " + strings.Join(names, " ")))
+	b.WriteString("\n")
+
+	if height < 5 {
+		height = 5
+	}
+	start := t.cursor - height/2
+	if start > len(t.events)-height {
+		start = len(t.events) - height
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + height
+	if end > len(t.events) {
+		end = len(t.events)
+	}
+
+	if start > 0 {
+		b.WriteString(itemDetailMetaStyle.Render(fmt.Sprintf("... %d earlier events", start)))
+		b.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		event := t.events[i]
+		line := fmt.Sprintf("@%s %s • %s", event.Actor, event.Summary, formatTime(event.CreatedAt))
+		if i == t.cursor {
+			b.WriteString(timelineSelectedStyle.Render("▸ " + line))
+		} else {
+			b.WriteString(itemDetailValueStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	switch {
+	case t.loading:
+		b.WriteString(itemDetailMetaStyle.Render("Loading events..."))
+		b.WriteString("\n")
+	case t.err != nil:
+		b.WriteString(issueStateErrorStyle.Render(t.err.Error()))
+		b.WriteString("\n")
+	case len(t.events) == 0:
+		b.WriteString(itemDetailMetaStyle.Render("(No events)"))
+		b.WriteString("\n")
+	case end < len(t.events) || t.page.HasNextPage:
+		b.WriteString(itemDetailMetaStyle.Render("More events below"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (t timelineTab) helpText() string {
+	return "↑/↓: scroll • f/F: filter • tab/esc: back to details • q: quit"
+}
+
+// LoadTimelineCmd fetches a page of an item's timeline
+func LoadTimelineCmd(item models.ProjectItem, itemTypes []string, after string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		return LoadTimelineMsg{Item: item, ItemTypes: itemTypes, After: after, Seq: seq}
+	}
+}
+
+// LoadTimelineMsg is sent to load a page of an item's timeline
+type LoadTimelineMsg struct {
+	Item      models.ProjectItem
+	ItemTypes []string
+	After     string // Cursor of the last event already loaded
+	Seq       int
+}
+
+// TimelineLoadedMsg is sent when a page of the timeline is loaded, or
+// failed to load. It's shown in the tab rather than as an error.
+type TimelineLoadedMsg struct {
+	ItemID   string
+	Seq      int
+	Events   []models.TimelineEvent
+	PageInfo models.PageInfo
+	Err      error
+}
+
+func loadTimeline(ctx context.Context, client api.Interface, msg LoadTimelineMsg) tea.Cmd {
+	return cancellable(ctx, func() tea.Msg {
+		events, pageInfo, err := client.ListTimeline(ctx, msg.Item.ContentID, msg.ItemTypes, timelinePageSize, msg.After)
+		if err != nil {
+			err = fmt.Errorf("failed to load timeline: %w", err)
+		}
+		return TimelineLoadedMsg{
+			ItemID:   msg.Item.ID,
+			Seq:      msg.Seq,
+			Events:   events,
+			PageInfo: pageInfo,
+			Err:      err,
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thomaskoefod/githubProjectTUI/internal/api/fake"
+	"github.com/thomaskoefod/githubProjectTUI/internal/models"
+)
+
+// busyIssue returns a board with an issue that has 40 label events and an
+// assignment, after being added to the project
+func busyIssue(t *testing.T) (*fake.Client, models.ProjectItem) {
+	t.Helper()
+	ctx := context.Background()
+	c, p, r := newBoard()
+	hubot := c.AddUser("hubot")
+	bug := c.AddLabel(r.ID, "bug", "d73a4a")
+	issue := c.AddIssue(p.ID, r.ID, "Crash on start", "")
+	for i := 0; i < 20; i++ {
+		if err := c.AddLabels(ctx, issue.ContentID, []string{bug.ID}); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.RemoveLabels(ctx, issue.ContentID, []string{bug.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.AddAssignees(ctx, issue.ContentID, []string{hubot}); err != nil {
+		t.Fatal(err)
+	}
+	return c, issue
+}
+
+// runTimelineLoad runs the load a timeline command asks for against the fake
+func runTimelineLoad(t *testing.T, c *fake.Client, cmd tea.Cmd) TimelineLoadedMsg {
+	t.Helper()
+	load, ok := cmd().(LoadTimelineMsg)
+	if !ok {
+		t.Fatal("timeline didn't ask for a page")
+	}
+	return loadTimeline(context.Background(), c, load)().(cancellableMsg).msg.(TimelineLoadedMsg)
+}
+
+func TestTimelinePaging(t *testing.T) {
+	c, issue := busyIssue(t)
+
+	tab, cmd := timelineTab{}.reset(issue)
+	tab = tab.loaded(runTimelineLoad(t, c, cmd))
+
+	if len(tab.events) != timelinePageSize || !tab.page.HasNextPage {
+		t.Fatalf("got %d events, more %v, want a full first page", len(tab.events), tab.page.HasNextPage)
+	}
+	if tab.events[0].Summary != "added this to Roadmap" {
+		t.Errorf("first event = %q, want the oldest", tab.events[0].Summary)
+	}
+
+	// Reaching the last loaded event fetches the rest
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}
+	for i := 0; i < timelinePageSize-2; i++ {
+		if tab, cmd = tab.update(down, issue); cmd != nil {
+			t.Fatalf("fetched a page at event %d", tab.cursor)
+		}
+	}
+	tab, cmd = tab.update(down, issue)
+	if cmd == nil {
+		t.Fatal("didn't fetch the next page at the last event")
+	}
+	tab = tab.loaded(runTimelineLoad(t, c, cmd))
+
+	if len(tab.events) != 42 || tab.page.HasNextPage {
+		t.Fatalf("got %d events, more %v, want all 42", len(tab.events), tab.page.HasNextPage)
+	}
+	if last := tab.events[41]; last.Summary != "assigned @hubot" {
+		t.Errorf("last event = %q, want the assignment", last.Summary)
+	}
+}
+
+func TestTimelineFilter(t *testing.T) {
+	c, issue := busyIssue(t)
+	tab, cmd := timelineTab{}.reset(issue)
+	stale := runTimelineLoad(t, c, cmd)
+
+	// f twice picks Assignees
+	f := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")}
+	tab, _ = tab.update(f, issue)
+	tab, cmd = tab.update(f, issue)
+	if timelineFilters[tab.filter].name != "Assignees" {
+		t.Fatalf("filter = %s, want Assignees", timelineFilters[tab.filter].name)
+	}
+	page := runTimelineLoad(t, c, cmd)
+
+	// The page for All arriving late is dropped
+	tab = tab.loaded(stale)
+	if len(tab.events) != 0 || !tab.loading {
+		t.Fatalf("got %d events from the earlier filter", len(tab.events))
+	}
+	tab = tab.loaded(page)
+	if len(tab.events) != 1 || tab.events[0].Summary != "assigned @hubot" || tab.page.HasNextPage {
+		t.Errorf("events = %+v, want only the assignment", tab.events)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -16,14 +17,14 @@ func main() {
 		os.Exit(1)
 	}
 
-	viewer, _ := client.GetViewer()
-	orgs, _ := client.GetUserOrganizations(viewer)
+	viewer, _ := client.GetViewer(context.Background())
+	orgs, _ := client.GetUserOrganizations(context.Background(), viewer)
 
 	fmt.Printf("Username: %s\n", viewer)
 	fmt.Printf("Orgs: %v\n", orgs)
 
 	if len(orgs) > 0 {
-		projects, err := client.ListOrgProjects(orgs[0], 10)
+		projects, err := client.ListOrgProjects(context.Background(), orgs[0], 10)
 		if err != nil {
 			fmt.Printf("Error loading projects: %v\n", err)
 			os.Exit(1)